@@ -23,14 +23,19 @@ func createCanvas() *js.Object {
 func main() {
 	canvas := browser.NewCanvasFromJsObject(createCanvas())
 
+	appModel := model.NewSample()
+
 	// Create and configure the Scheduler.
-	scheduler.New(
+	s := scheduler.New(
 		browser.NewWindow(
 			browser.BrowserWindow(js.Global.Get("window")),
 			browser.Title("Todo MVC"),
 		),
 		browser.NewSurface(canvas),
-		ctrl.AppRenderer(model.NewSample()),
+		ctrl.AppRenderer(appModel),
 		clock.New(),
-	).Listen()
+	)
+	// Render whenever the model changes.
+	s.Watch(appModel)
+	s.Listen()
 }
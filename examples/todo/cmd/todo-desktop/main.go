@@ -1,7 +1,6 @@
 package main
 
 import (
-	"path/filepath"
 	"runtime"
 
 	"github.com/waybeams/waybeams/examples/todo/ctrl"
@@ -16,33 +15,21 @@ func init() {
 	runtime.LockOSThread()
 }
 
-// Get the relative font path for the provided font name.
-func fontPathFor(fontFileName string) string {
-	return filepath.Join(
-		"src",
-		"github.com",
-		"waybeams",
-		"waybeams",
-		"third_party",
-		"fonts",
-		"Roboto",
-		fontFileName,
-	)
-}
-
 func main() {
+	appModel := model.NewSample()
+
 	// Create and configure the Scheduler.
-	scheduler.New(
+	s := scheduler.New(
 		glfw.NewWindow(
 			glfw.Width(800),
 			glfw.Height(600),
 			glfw.Title("Todo"),
 		),
-		nano.NewSurface(
-			nano.AddFont("Roboto", fontPathFor("Roboto-Regular.ttf")),
-			nano.AddFont("Roboto Light", fontPathFor("Roboto-Light.ttf")),
-		),
-		ctrl.AppRenderer(model.NewSample()),
+		nano.NewSurface(),
+		ctrl.AppRenderer(appModel),
 		clock.New(),
-	).Listen()
+	)
+	// Render whenever the model changes.
+	s.Watch(appModel)
+	s.Listen()
 }
@@ -3,6 +3,7 @@ package ctrl
 import (
 	"github.com/waybeams/waybeams/examples/todo/model"
 	"github.com/waybeams/waybeams/pkg/ctrl"
+	"github.com/waybeams/waybeams/pkg/font"
 	"github.com/waybeams/waybeams/pkg/opts"
 	"github.com/waybeams/waybeams/pkg/spec"
 )
@@ -39,7 +40,8 @@ func AppRenderer(appModel *model.App) func() spec.ReadWriter {
 				opts.HAlign(spec.AlignCenter),
 				opts.Child(ctrl.Label(
 					opts.FontColor(0xaf2f2f26),
-					opts.FontFace("Roboto Light"),
+					opts.FontFace("Roboto"),
+					opts.FontWeight(font.Light),
 					opts.FontSize(100),
 					opts.Text("TODO"),
 				)),
@@ -4,6 +4,9 @@ import (
 	"github.com/waybeams/assert"
 	"github.com/waybeams/waybeams/examples/todo/ctrl"
 	"github.com/waybeams/waybeams/examples/todo/model"
+	"github.com/waybeams/waybeams/pkg/env/fake"
+	"github.com/waybeams/waybeams/pkg/input"
+	"github.com/waybeams/waybeams/pkg/spec"
 	"testing"
 )
 
@@ -27,4 +30,59 @@ func TestAppControl(t *testing.T) {
 		assert.Equal(len(items), 5)
 		assert.Equal(items[0].Children()[1].Text(), "Item One")
 	})
+	t.Run("Filters items from footer clicks", func(t *testing.T) {
+		m := model.NewSample()
+		m.AllItems()[0].ToggleCompleted()
+
+		driver := fake.NewDriver(ctrl.AppRenderer(m))
+		defer driver.Close()
+		assert.Equal(driver.Find("Todo Items").ChildCount(), 6)
+
+		driver.Click(ctrl.ActiveButton)
+		assert.Equal(m.Showing(), model.ActiveItems)
+		items := driver.Find("Todo Items").Children()
+		assert.Equal(len(items), 5)
+		assert.Equal(items[0].Children()[1].Text(), "Item Two")
+
+		driver.Click(ctrl.CompletedButton)
+		assert.Equal(driver.Find("Todo Items").ChildCount(), 1)
+	})
+
+	t.Run("Creates items from typed text", func(t *testing.T) {
+		m := model.New()
+		driver := fake.NewDriver(ctrl.AppRenderer(m))
+		defer driver.Close()
+
+		driver.Click(ctrl.NewItemInput)
+		driver.Type("Buy milk")
+		driver.Press(input.KeyEnter)
+
+		assert.Equal(len(m.AllItems()), 1)
+		assert.Equal(m.AllItems()[0].Description, "Buy milk")
+		assert.Equal(driver.Find("Todo Items").ChildCount(), 1)
+	})
+
+	t.Run("Watched model skips factory for gestures", func(t *testing.T) {
+		m := model.NewSample()
+		renders := 0
+		render := ctrl.AppRenderer(m)
+		driver := fake.NewDriver(func() spec.ReadWriter {
+			renders++
+			return render()
+		})
+		defer driver.Close()
+		driver.Scheduler().Watch(m)
+		assert.Equal(renders, 1)
+
+		driver.Hover(ctrl.AllButton)
+		assert.Equal(renders, 1)
+
+		driver.Click(ctrl.ActiveButton)
+		assert.Equal(renders, 2)
+
+		driver.Scheduler().Post(func() { m.CreateItem("Posted") })
+		driver.AdvanceFrames(1)
+		assert.Equal(renders, 3)
+		assert.Equal(driver.Find("Todo Items").ChildCount(), 7)
+	})
 }
@@ -9,10 +9,6 @@ import (
 )
 
 func ItemSpec(model *model.Item, index int) spec.ReadWriter {
-	var completedLabel string = "[  ]"
-	if !model.CompletedAt.IsZero() {
-		completedLabel = "[X]"
-	}
 	var bgColor uint = 0xdededeff
 	if !model.CompletedAt.IsZero() {
 		bgColor = 0x9e9e9eff
@@ -23,10 +19,11 @@ func ItemSpec(model *model.Item, index int) spec.ReadWriter {
 		opts.StrokeColor(0x333333ff),
 		opts.StrokeSize(1),
 		opts.FlexWidth(1),
-		opts.Child(ctrl.Button(
+		opts.Child(ctrl.Checkbox(
 			opts.Key("btn"),
-			opts.Text(completedLabel),
-			opts.OnClick(events.EmptyHandler(model.ToggleCompleted)),
+			opts.Padding(5),
+			ctrl.Checked(!model.CompletedAt.IsZero()),
+			opts.On(events.Changed, events.EmptyHandler(model.ToggleCompleted)),
 		)),
 		opts.Child(ctrl.Label(
 			opts.BgColor(0),
@@ -23,10 +23,10 @@ func TestItemList(t *testing.T) {
 		instance := ctrl.ItemList(model)
 		kids := instance.Children()
 		child := spec.FirstByKey(kids[0], "btn")
-		assert.Equal(child.Text(), "[  ]", "Expected btn label NOT to be selected")
+		assert.Equal(child.Value(), false, "Expected btn NOT to be checked")
 
 		child = spec.FirstByKey(kids[2], "btn")
-		assert.Equal(child.Text(), "[X]", "Expected btn label to be selected")
+		assert.Equal(child.Value(), true, "Expected btn to be checked")
 	})
 
 	t.Run("Accepts option overrides", func(t *testing.T) {
@@ -22,14 +22,14 @@ func TestItemSpec(t *testing.T) {
 		assert.Equal(desc.Text(), "Item One")
 
 		toggle := spec.FirstByKey(s, "btn")
-		assert.Equal(toggle.Text(), "[  ]")
+		assert.Equal(toggle.Value(), false)
 
-		// Click the toggle completed button
+		// Click the toggle completed checkbox
 		toggle.Emit(events.New(events.Clicked, toggle, nil))
 
 		// Manually build a new component from the updated model state
 		s = ctrl.ItemSpec(itemModel, 2)
 		toggle = spec.FirstByKey(s, "btn")
-		assert.Equal(toggle.Text(), "[X]")
+		assert.Equal(toggle.Value(), true)
 	})
 }
@@ -1,6 +1,7 @@
 package ctrl
 
 import (
+	"github.com/waybeams/waybeams/pkg/font"
 	"github.com/waybeams/waybeams/pkg/opts"
 	"github.com/waybeams/waybeams/pkg/spec"
 )
@@ -34,7 +35,8 @@ func CreateStyles() *Styles {
 		),
 		Header: opts.Bag(
 			opts.FontColor(0xaf2f2f26),
-			opts.FontFace("Roboto Light"),
+			opts.FontFace("Roboto"),
+			opts.FontWeight(font.Light),
 			opts.FontSize(100),
 		),
 		Main: opts.Bag(
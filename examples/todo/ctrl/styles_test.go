@@ -4,6 +4,7 @@ import (
 	"github.com/waybeams/assert"
 	ctrl2 "github.com/waybeams/waybeams/examples/todo/ctrl"
 	"github.com/waybeams/waybeams/pkg/ctrl"
+	"github.com/waybeams/waybeams/pkg/font"
 	"testing"
 )
 
@@ -24,7 +25,8 @@ func TestStyles(t *testing.T) {
 	t.Run("Header style", func(t *testing.T) {
 		styles := ctrl2.CreateStyles()
 		instance := ctrl.VBox(styles.Header)
-		assert.Equal(instance.FontFace(), "Roboto Light")
+		assert.Equal(instance.FontFace(), "Roboto")
+		assert.Equal(instance.FontWeight(), font.Light)
 		assert.Equal(instance.FontSize(), 100)
 	})
 
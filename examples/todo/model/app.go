@@ -2,6 +2,8 @@ package model
 
 import (
 	"time"
+
+	"github.com/waybeams/waybeams/pkg/store"
 )
 
 type ItemsShown int
@@ -12,7 +14,11 @@ const (
 	CompletedItems
 )
 
+// App is an Observable model that emits events.Changed whenever any of its
+// items or filters are mutated.
 type App struct {
+	store.Model
+
 	showing     ItemsShown
 	allItems    []*Item
 	enteredText string
@@ -33,8 +39,9 @@ func (t *App) OnItemChanged(item *Item) {
 		!t.hasCompletedItems() ||
 		t.showing == ActiveItems &&
 			!t.hasActiveItems() {
-		t.ShowAllItems()
+		t.showing = AllItems
 	}
+	t.Changed()
 }
 
 func (t *App) Showing() ItemsShown {
@@ -44,21 +51,24 @@ func (t *App) Showing() ItemsShown {
 func (t *App) ClearCompleted() {
 	t.allItems = t.ActiveItems()
 	if t.showing == CompletedItems {
-		t.ShowAllItems()
+		t.showing = AllItems
 	}
+	t.Changed()
 }
 
 func (t *App) ShowActiveItems() {
 	t.showing = ActiveItems
-
+	t.Changed()
 }
 
 func (t *App) ShowCompletedItems() {
 	t.showing = CompletedItems
+	t.Changed()
 }
 
 func (t *App) ShowAllItems() {
 	t.showing = AllItems
+	t.Changed()
 }
 
 func (t *App) DeleteItem(deletedItem *Item) {
@@ -70,6 +80,7 @@ func (t *App) DeleteItem(deletedItem *Item) {
 		}
 	}
 	t.allItems = result
+	t.Changed()
 }
 
 func (t *App) EnteredText() string {
@@ -77,7 +88,11 @@ func (t *App) EnteredText() string {
 }
 
 func (t *App) UpdateEnteredText(str string) {
+	if t.enteredText == str {
+		return
+	}
 	t.enteredText = str
+	t.Changed()
 }
 
 func (t *App) CreateItem(desc string) *Item {
@@ -89,6 +104,7 @@ func (t *App) CreateItem(desc string) *Item {
 
 	t.enteredText = ""
 	t.allItems = append(t.AllItems(), item)
+	t.Changed()
 	return item
 }
 
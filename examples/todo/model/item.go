@@ -11,6 +11,9 @@ type Item struct {
 
 func (t *Item) SetDescription(text string) {
 	t.Description = text
+	if t.collection != nil {
+		t.collection.OnItemChanged(t)
+	}
 }
 
 func (t *Item) Delete() {
@@ -0,0 +1,44 @@
+package anim
+
+import "time"
+
+// Animation is anything that changes over time. Step advances the animation
+// by the provided delta, and returns the portion of delta that was not
+// needed along with whether the animation has completed. Leftover time
+// allows groups like Sequence to remain exact regardless of frame rate.
+type Animation interface {
+	Step(delta time.Duration) (leftover time.Duration, done bool)
+}
+
+type callAnimation struct {
+	fn func()
+}
+
+func (c *callAnimation) Step(delta time.Duration) (time.Duration, bool) {
+	c.fn()
+	return delta, true
+}
+
+// Call returns an Animation that calls the provided function once and then
+// completes immediately. It is useful at the end of a Sequence.
+func Call(fn func()) Animation {
+	return &callAnimation{fn: fn}
+}
+
+type waitAnimation struct {
+	duration time.Duration
+	elapsed  time.Duration
+}
+
+func (w *waitAnimation) Step(delta time.Duration) (time.Duration, bool) {
+	w.elapsed += delta
+	if w.elapsed >= w.duration {
+		return w.elapsed - w.duration, true
+	}
+	return 0, false
+}
+
+// Wait returns an Animation that does nothing for the provided duration.
+func Wait(duration time.Duration) Animation {
+	return &waitAnimation{duration: duration}
+}
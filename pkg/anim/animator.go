@@ -0,0 +1,99 @@
+package anim
+
+import (
+	"sync"
+	"time"
+
+	"github.com/waybeams/waybeams/pkg/clock"
+	"github.com/waybeams/waybeams/pkg/events"
+)
+
+type entry struct {
+	animation Animation
+	id        int64
+	lastTime  time.Time
+}
+
+// Animator steps any number of running Animations by the time that has
+// passed on the provided clock since each one was last updated. Tests that
+// use clock.NewFake() get fully deterministic animations by calling Add on
+// the fake clock between updates.
+type Animator struct {
+	clock   clock.Clock
+	entries []*entry
+	lastID  int64
+	mutex   sync.Mutex
+}
+
+// Start begins running the provided Animation from the current clock time.
+// The returned Unsubscriber stops the Animation early and returns false if
+// it had already completed.
+func (a *Animator) Start(animation Animation) events.Unsubscriber {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.lastID++
+	id := a.lastID
+	a.entries = append(a.entries, &entry{
+		animation: animation,
+		id:        id,
+		lastTime:  a.clock.Now(),
+	})
+
+	return func() bool {
+		a.mutex.Lock()
+		defer a.mutex.Unlock()
+		for index, e := range a.entries {
+			if e.id == id {
+				a.entries = append(a.entries[:index:index], a.entries[index+1:]...)
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// IsActive returns true if any Animations are running.
+func (a *Animator) IsActive() bool {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	return len(a.entries) > 0
+}
+
+// Update steps every running Animation and removes those that completed.
+// It returns true if any Animation was stepped.
+func (a *Animator) Update() bool {
+	a.mutex.Lock()
+	entries := a.entries
+	a.mutex.Unlock()
+
+	if len(entries) == 0 {
+		return false
+	}
+
+	now := a.clock.Now()
+	finished := map[int64]bool{}
+	for _, e := range entries {
+		delta := now.Sub(e.lastTime)
+		e.lastTime = now
+		if _, done := e.animation.Step(delta); done {
+			finished[e.id] = true
+		}
+	}
+
+	a.mutex.Lock()
+	remaining := a.entries[:0:0]
+	for _, e := range a.entries {
+		if !finished[e.id] {
+			remaining = append(remaining, e)
+		}
+	}
+	a.entries = remaining
+	a.mutex.Unlock()
+	return true
+}
+
+// New returns an Animator that reads time from the provided clock.
+func New(c clock.Clock) *Animator {
+	return &Animator{clock: c}
+}
@@ -0,0 +1,44 @@
+package anim_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/waybeams/assert"
+	"github.com/waybeams/waybeams/pkg/anim"
+	"github.com/waybeams/waybeams/pkg/clock"
+)
+
+func TestAnimator(t *testing.T) {
+	t.Run("Steps by clock time", func(t *testing.T) {
+		value := 0.0
+		fakeClock := clock.NewFake()
+		animator := anim.New(fakeClock)
+		assert.False(animator.IsActive())
+		assert.False(animator.Update())
+
+		animator.Start(anim.NewTween(time.Second, 0, 100, func(v float64) { value = v }))
+		assert.True(animator.IsActive())
+
+		fakeClock.Add(250 * time.Millisecond)
+		assert.True(animator.Update())
+		assert.Equal(value, 25.0)
+
+		fakeClock.Add(time.Second)
+		animator.Update()
+		assert.Equal(value, 100.0)
+		assert.False(animator.IsActive())
+	})
+
+	t.Run("Stop removes running animations", func(t *testing.T) {
+		value := 0.0
+		fakeClock := clock.NewFake()
+		animator := anim.New(fakeClock)
+		stop := animator.Start(anim.NewTween(time.Second, 0, 100, func(v float64) { value = v }))
+		assert.True(stop())
+		assert.False(stop())
+		fakeClock.Add(500 * time.Millisecond)
+		animator.Update()
+		assert.Equal(value, 0.0)
+	})
+}
@@ -0,0 +1,92 @@
+package anim
+
+import "math"
+
+// EasingFunc maps linear progress (0.0 - 1.0) onto eased progress. Most
+// easing functions return 0 for 0 and 1 for 1, but some (e.g., OutBack and
+// OutElastic) overshoot in between.
+type EasingFunc func(t float64) float64
+
+func Linear(t float64) float64 {
+	return t
+}
+
+func InQuad(t float64) float64 {
+	return t * t
+}
+
+func OutQuad(t float64) float64 {
+	return t * (2 - t)
+}
+
+func InOutQuad(t float64) float64 {
+	if t < 0.5 {
+		return 2 * t * t
+	}
+	return -1 + (4-2*t)*t
+}
+
+func InCubic(t float64) float64 {
+	return t * t * t
+}
+
+func OutCubic(t float64) float64 {
+	t--
+	return t*t*t + 1
+}
+
+func InOutCubic(t float64) float64 {
+	if t < 0.5 {
+		return 4 * t * t * t
+	}
+	t = 2*t - 2
+	return 0.5*t*t*t + 1
+}
+
+func InSine(t float64) float64 {
+	return 1 - math.Cos(t*math.Pi/2)
+}
+
+func OutSine(t float64) float64 {
+	return math.Sin(t * math.Pi / 2)
+}
+
+func InOutSine(t float64) float64 {
+	return -(math.Cos(math.Pi*t) - 1) / 2
+}
+
+// OutBack overshoots the target slightly before settling.
+func OutBack(t float64) float64 {
+	const c1 = 1.70158
+	const c3 = c1 + 1
+	t--
+	return 1 + c3*t*t*t + c1*t*t
+}
+
+// OutElastic oscillates around the target before settling.
+func OutElastic(t float64) float64 {
+	if t == 0 || t == 1 {
+		return t
+	}
+	const c4 = (2 * math.Pi) / 3
+	return math.Pow(2, -10*t)*math.Sin((t*10-0.75)*c4) + 1
+}
+
+// OutBounce bounces against the target like a dropped ball.
+func OutBounce(t float64) float64 {
+	const n1 = 7.5625
+	const d1 = 2.75
+	switch {
+	case t < 1/d1:
+		return n1 * t * t
+	case t < 2/d1:
+		t -= 1.5 / d1
+		return n1*t*t + 0.75
+	case t < 2.5/d1:
+		t -= 2.25 / d1
+		return n1*t*t + 0.9375
+	default:
+		t -= 2.625 / d1
+		return n1*t*t + 0.984375
+	}
+}
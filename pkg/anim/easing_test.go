@@ -0,0 +1,45 @@
+package anim_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/waybeams/assert"
+	"github.com/waybeams/waybeams/pkg/anim"
+)
+
+func TestEasing(t *testing.T) {
+	all := map[string]anim.EasingFunc{
+		"Linear":     anim.Linear,
+		"InQuad":     anim.InQuad,
+		"OutQuad":    anim.OutQuad,
+		"InOutQuad":  anim.InOutQuad,
+		"InCubic":    anim.InCubic,
+		"OutCubic":   anim.OutCubic,
+		"InOutCubic": anim.InOutCubic,
+		"InSine":     anim.InSine,
+		"OutSine":    anim.OutSine,
+		"InOutSine":  anim.InOutSine,
+		"OutBack":    anim.OutBack,
+		"OutElastic": anim.OutElastic,
+		"OutBounce":  anim.OutBounce,
+	}
+
+	t.Run("Start at zero and end at one", func(t *testing.T) {
+		for name, easing := range all {
+			if math.Abs(easing(0)) > 1e-9 || math.Abs(easing(1)-1) > 1e-9 {
+				t.Errorf("%v returned %v and %v", name, easing(0), easing(1))
+			}
+		}
+	})
+
+	t.Run("Quad values", func(t *testing.T) {
+		assert.Equal(anim.InQuad(0.5), 0.25)
+		assert.Equal(anim.OutQuad(0.5), 0.75)
+		assert.Equal(anim.InOutQuad(0.5), 0.5)
+	})
+
+	t.Run("OutBack overshoots", func(t *testing.T) {
+		assert.True(anim.OutBack(0.8) > 1)
+	})
+}
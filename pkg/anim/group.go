@@ -0,0 +1,71 @@
+package anim
+
+import "time"
+
+type sequence struct {
+	animations []Animation
+	index      int
+}
+
+func (s *sequence) Step(delta time.Duration) (time.Duration, bool) {
+	for s.index < len(s.animations) {
+		leftover, done := s.animations[s.index].Step(delta)
+		if !done {
+			return 0, false
+		}
+		s.index++
+		delta = leftover
+	}
+	return delta, true
+}
+
+// Sequence returns an Animation that runs each of the provided animations
+// one after another.
+func Sequence(animations ...Animation) Animation {
+	return &sequence{animations: animations}
+}
+
+type parallel struct {
+	animations []Animation
+	done       []bool
+	leftover   time.Duration
+}
+
+func (p *parallel) Step(delta time.Duration) (time.Duration, bool) {
+	if len(p.animations) == 0 {
+		return delta, true
+	}
+	isDone := true
+	var leftover time.Duration = -1
+	for index, animation := range p.animations {
+		if p.done[index] {
+			continue
+		}
+		remaining, done := animation.Step(delta)
+		if done {
+			p.done[index] = true
+			if leftover < 0 || remaining < leftover {
+				leftover = remaining
+			}
+		} else {
+			isDone = false
+		}
+	}
+	if leftover >= 0 {
+		// Track the time left over by the animation that completed last.
+		p.leftover = leftover
+	}
+	if isDone {
+		return p.leftover, true
+	}
+	return 0, false
+}
+
+// Parallel returns an Animation that runs all of the provided animations at
+// the same time and completes when the longest one completes.
+func Parallel(animations ...Animation) Animation {
+	return &parallel{
+		animations: animations,
+		done:       make([]bool, len(animations)),
+	}
+}
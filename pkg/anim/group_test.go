@@ -0,0 +1,56 @@
+package anim_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/waybeams/assert"
+	"github.com/waybeams/waybeams/pkg/anim"
+)
+
+func TestGroups(t *testing.T) {
+	t.Run("Sequence carries leftover time", func(t *testing.T) {
+		first, second := 0.0, 0.0
+		completed := false
+		seq := anim.Sequence(
+			anim.NewTween(100*time.Millisecond, 0, 1, func(v float64) { first = v }),
+			anim.Wait(50*time.Millisecond),
+			anim.NewTween(100*time.Millisecond, 0, 1, func(v float64) { second = v }),
+			anim.Call(func() { completed = true }),
+		)
+
+		_, done := seq.Step(200 * time.Millisecond)
+		assert.False(done)
+		assert.Equal(first, 1.0)
+		assert.Equal(second, 0.5)
+		assert.False(completed)
+
+		leftover, done := seq.Step(60 * time.Millisecond)
+		assert.True(done)
+		assert.True(completed)
+		assert.Equal(leftover, 10*time.Millisecond)
+	})
+
+	t.Run("Parallel completes with the longest child", func(t *testing.T) {
+		short, long := 0.0, 0.0
+		group := anim.Parallel(
+			anim.NewTween(100*time.Millisecond, 0, 1, func(v float64) { short = v }),
+			anim.NewTween(200*time.Millisecond, 0, 1, func(v float64) { long = v }),
+		)
+		_, done := group.Step(150 * time.Millisecond)
+		assert.False(done)
+		assert.Equal(short, 1.0)
+		assert.Equal(long, 0.75)
+
+		leftover, done := group.Step(100 * time.Millisecond)
+		assert.True(done)
+		assert.Equal(leftover, 50*time.Millisecond)
+	})
+
+	t.Run("Empty groups complete immediately", func(t *testing.T) {
+		_, done := anim.Sequence().Step(0)
+		assert.True(done)
+		_, done = anim.Parallel().Step(0)
+		assert.True(done)
+	})
+}
@@ -0,0 +1,52 @@
+package anim
+
+import (
+	"time"
+
+	"github.com/waybeams/waybeams/pkg/spec"
+)
+
+// NOTE: Spec properties that are managed by layout (position and
+// size) are reset whenever layout runs, so the Scheduler steps animations
+// after layout and before draw. Specs are also recreated on every render,
+// so long-lived animations should usually drive a store.Value instead.
+
+// X tweens the horizontal position of the provided Spec.
+func X(s spec.ReadWriter, to float64, duration time.Duration, options ...TweenOption) *Tween {
+	return To(duration, s.X, s.SetX, to, options...)
+}
+
+// Y tweens the vertical position of the provided Spec.
+func Y(s spec.ReadWriter, to float64, duration time.Duration, options ...TweenOption) *Tween {
+	return To(duration, s.Y, s.SetY, to, options...)
+}
+
+// Width tweens the width of the provided Spec.
+func Width(s spec.ReadWriter, to float64, duration time.Duration, options ...TweenOption) *Tween {
+	return To(duration, s.Width, s.SetWidth, to, options...)
+}
+
+// Height tweens the height of the provided Spec.
+func Height(s spec.ReadWriter, to float64, duration time.Duration, options ...TweenOption) *Tween {
+	return To(duration, s.Height, s.SetHeight, to, options...)
+}
+
+// BgColor tweens the background color of the provided Spec.
+func BgColor(s spec.ReadWriter, to uint, duration time.Duration, options ...TweenOption) *Tween {
+	return ToColor(duration, s.BgColor, s.SetBgColor, to, options...)
+}
+
+// FontColor tweens the font color of the provided Spec.
+func FontColor(s spec.ReadWriter, to uint, duration time.Duration, options ...TweenOption) *Tween {
+	return ToColor(duration, s.FontColor, s.SetFontColor, to, options...)
+}
+
+// Opacity tweens the opacity of the provided Spec.
+func Opacity(s spec.ReadWriter, to float64, duration time.Duration, options ...TweenOption) *Tween {
+	return To(duration, s.Opacity, s.SetOpacity, to, options...)
+}
+
+// StrokeColor tweens the stroke color of the provided Spec.
+func StrokeColor(s spec.ReadWriter, to uint, duration time.Duration, options ...TweenOption) *Tween {
+	return ToColor(duration, s.StrokeColor, s.SetStrokeColor, to, options...)
+}
@@ -0,0 +1,111 @@
+package anim
+
+import (
+	"math"
+	"time"
+)
+
+const DefaultStiffness = 170
+const DefaultDamping = 26
+const DefaultMass = 1
+
+// Springs integrate with a fixed step so that results do not depend on the
+// frame rate.
+const springStep = time.Millisecond
+const springRestThreshold = 0.001
+
+// SpringOption configures a Spring.
+type SpringOption func(s *Spring)
+
+// Stiffness sets the spring constant. Stiffer springs move faster.
+func Stiffness(value float64) SpringOption {
+	return func(s *Spring) {
+		s.stiffness = value
+	}
+}
+
+// Damping sets the friction applied to a Spring. Lower values oscillate
+// more before coming to rest.
+func Damping(value float64) SpringOption {
+	return func(s *Spring) {
+		s.damping = value
+	}
+}
+
+// Mass sets the mass of the object attached to a Spring.
+func Mass(value float64) SpringOption {
+	return func(s *Spring) {
+		s.mass = value
+	}
+}
+
+// Spring moves a value toward a target with damped harmonic motion. Unlike
+// a Tween, it has no fixed duration and completes once it comes to rest.
+type Spring struct {
+	apply     func(value float64)
+	damping   float64
+	mass      float64
+	remainder time.Duration
+	stiffness float64
+	target    float64
+	value     float64
+	velocity  float64
+}
+
+// SetTarget moves the rest position of the Spring while preserving its
+// current velocity.
+func (s *Spring) SetTarget(target float64) {
+	s.target = target
+}
+
+func (s *Spring) Value() float64 {
+	return s.value
+}
+
+func (s *Spring) Velocity() float64 {
+	return s.velocity
+}
+
+func (s *Spring) isAtRest() bool {
+	return math.Abs(s.velocity) < springRestThreshold &&
+		math.Abs(s.target-s.value) < springRestThreshold
+}
+
+// Step integrates the spring forward by delta and applies the result.
+func (s *Spring) Step(delta time.Duration) (time.Duration, bool) {
+	s.remainder += delta
+	dt := springStep.Seconds()
+	for s.remainder >= springStep {
+		s.remainder -= springStep
+		force := -s.stiffness*(s.value-s.target) - s.damping*s.velocity
+		s.velocity += force / s.mass * dt
+		s.value += s.velocity * dt
+		if s.isAtRest() {
+			s.value = s.target
+			s.velocity = 0
+			s.apply(s.value)
+			leftover := s.remainder
+			s.remainder = 0
+			return leftover, true
+		}
+	}
+	s.apply(s.value)
+	return 0, false
+}
+
+// NewSpring returns a Spring that starts at 'from' and comes to rest at
+// 'to'.
+func NewSpring(from, to float64, apply func(value float64), options ...SpringOption) *Spring {
+	s := &Spring{
+		apply:     apply,
+		damping:   DefaultDamping,
+		mass:      DefaultMass,
+		stiffness: DefaultStiffness,
+		target:    to,
+		value:     from,
+	}
+	for _, option := range options {
+		option(s)
+	}
+	return s
+}
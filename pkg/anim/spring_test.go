@@ -0,0 +1,56 @@
+package anim_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/waybeams/assert"
+	"github.com/waybeams/waybeams/pkg/anim"
+)
+
+func TestSpring(t *testing.T) {
+	t.Run("Comes to rest at target", func(t *testing.T) {
+		value := 0.0
+		spring := anim.NewSpring(0, 100, func(v float64) { value = v })
+
+		_, done := spring.Step(100 * time.Millisecond)
+		assert.False(done)
+		assert.True(value > 0 && value < 100)
+
+		_, done = spring.Step(5 * time.Second)
+		assert.True(done)
+		assert.Equal(value, 100.0)
+		assert.Equal(spring.Velocity(), 0.0)
+	})
+
+	t.Run("Is independent of frame size", func(t *testing.T) {
+		coarse := anim.NewSpring(0, 1, func(v float64) {})
+		fine := anim.NewSpring(0, 1, func(v float64) {})
+		coarse.Step(160 * time.Millisecond)
+		for i := 0; i < 10; i++ {
+			fine.Step(16 * time.Millisecond)
+		}
+		assert.Equal(coarse.Value(), fine.Value())
+	})
+
+	t.Run("Low damping overshoots", func(t *testing.T) {
+		max := 0.0
+		spring := anim.NewSpring(0, 1, func(v float64) {
+			if v > max {
+				max = v
+			}
+		}, anim.Damping(5), anim.Stiffness(200), anim.Mass(1))
+		for i := 0; i < 100; i++ {
+			spring.Step(16 * time.Millisecond)
+		}
+		assert.True(max > 1)
+	})
+
+	t.Run("SetTarget retargets", func(t *testing.T) {
+		value := 0.0
+		spring := anim.NewSpring(0, 10, func(v float64) { value = v })
+		spring.SetTarget(-10)
+		spring.Step(10 * time.Second)
+		assert.Equal(value, -10.0)
+	})
+}
@@ -0,0 +1,101 @@
+package anim
+
+import (
+	"time"
+
+	"github.com/waybeams/waybeams/pkg/helpers"
+)
+
+// TweenOption configures a Tween.
+type TweenOption func(t *Tween)
+
+// Ease sets the easing function that is applied to a Tween. The default is
+// Linear.
+func Ease(easing EasingFunc) TweenOption {
+	return func(t *Tween) {
+		t.easing = easing
+	}
+}
+
+// Delay holds a Tween at its starting value for the provided duration.
+func Delay(delay time.Duration) TweenOption {
+	return func(t *Tween) {
+		t.delay = delay
+	}
+}
+
+// Tween interpolates from one float64 value to another over a fixed
+// duration and provides each intermediate value to an apply function.
+type Tween struct {
+	apply     func(value float64)
+	delay     time.Duration
+	duration  time.Duration
+	easing    EasingFunc
+	elapsed   time.Duration
+	from      float64
+	getFrom   func() float64
+	isStarted bool
+	to        float64
+}
+
+// Step advances the Tween by delta and applies the resulting value.
+func (t *Tween) Step(delta time.Duration) (time.Duration, bool) {
+	if !t.isStarted {
+		t.isStarted = true
+		if t.getFrom != nil {
+			t.from = t.getFrom()
+		}
+	}
+	t.elapsed += delta
+	active := t.elapsed - t.delay
+	if active < 0 {
+		t.apply(t.from)
+		return 0, false
+	}
+	if active >= t.duration {
+		t.apply(t.to)
+		return active - t.duration, true
+	}
+	progress := t.easing(float64(active) / float64(t.duration))
+	t.apply(t.from + (t.to-t.from)*progress)
+	return 0, false
+}
+
+// NewTween returns a Tween that calls apply with values from 'from' to 'to'
+// over the provided duration.
+func NewTween(duration time.Duration, from, to float64, apply func(value float64), options ...TweenOption) *Tween {
+	t := &Tween{
+		apply:    apply,
+		duration: duration,
+		easing:   Linear,
+		from:     from,
+		to:       to,
+	}
+	for _, option := range options {
+		option(t)
+	}
+	return t
+}
+
+// To returns a Tween that reads its starting value from get when it first
+// steps, which allows it to begin from wherever a property is at that time
+// (e.g., after an earlier step in a Sequence).
+func To(duration time.Duration, get func() float64, set func(value float64), to float64, options ...TweenOption) *Tween {
+	t := NewTween(duration, 0, to, set, options...)
+	t.getFrom = get
+	return t
+}
+
+// ToColor returns a Tween that moves a uint RGBA color from whatever get
+// returns when it starts toward the provided color.
+func ToColor(duration time.Duration, get func() uint, set func(color uint), to uint, options ...TweenOption) *Tween {
+	var from uint
+	t := NewTween(duration, 0, 1, func(progress float64) {
+		set(helpers.LerpColor(from, to, progress))
+	}, options...)
+	t.getFrom = func() float64 {
+		from = get()
+		return 0
+	}
+	return t
+}
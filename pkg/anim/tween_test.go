@@ -0,0 +1,58 @@
+package anim_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/waybeams/assert"
+	"github.com/waybeams/waybeams/pkg/anim"
+	"github.com/waybeams/waybeams/pkg/ctrl"
+	"github.com/waybeams/waybeams/pkg/opts"
+)
+
+func TestTween(t *testing.T) {
+	t.Run("Interpolates linearly", func(t *testing.T) {
+		value := 0.0
+		tween := anim.NewTween(100*time.Millisecond, 10, 20, func(v float64) { value = v })
+
+		_, done := tween.Step(50 * time.Millisecond)
+		assert.False(done)
+		assert.Equal(value, 15.0)
+
+		leftover, done := tween.Step(70 * time.Millisecond)
+		assert.True(done)
+		assert.Equal(value, 20.0)
+		assert.Equal(leftover, 20*time.Millisecond)
+	})
+
+	t.Run("Applies easing", func(t *testing.T) {
+		value := 0.0
+		tween := anim.NewTween(time.Second, 0, 100, func(v float64) { value = v }, anim.Ease(anim.InQuad))
+		tween.Step(500 * time.Millisecond)
+		assert.Equal(value, 25.0)
+	})
+
+	t.Run("Delay holds the starting value", func(t *testing.T) {
+		value := -1.0
+		tween := anim.NewTween(time.Second, 0, 100, func(v float64) { value = v }, anim.Delay(time.Second))
+		tween.Step(500 * time.Millisecond)
+		assert.Equal(value, 0.0)
+		tween.Step(time.Second)
+		assert.Equal(value, 50.0)
+	})
+
+	t.Run("To reads the starting value on first step", func(t *testing.T) {
+		box := ctrl.Box(opts.X(10))
+		tween := anim.X(box, 30, time.Second)
+		box.SetX(20)
+		tween.Step(500 * time.Millisecond)
+		assert.Equal(box.X(), 25.0)
+	})
+
+	t.Run("Colors interpolate by channel", func(t *testing.T) {
+		box := ctrl.Box(opts.BgColor(0x00000000))
+		tween := anim.BgColor(box, 0xffffffff, time.Second)
+		tween.Step(time.Second)
+		assert.Equal(box.BgColor(), uint(0xffffffff))
+	})
+}
@@ -0,0 +1,197 @@
+package ctrl
+
+import (
+	"math"
+
+	"github.com/waybeams/waybeams/pkg/events"
+	"github.com/waybeams/waybeams/pkg/input"
+	"github.com/waybeams/waybeams/pkg/opts"
+	"github.com/waybeams/waybeams/pkg/spec"
+	"github.com/waybeams/waybeams/pkg/views"
+)
+
+// CheckableSpec is a Label that draws an indicator (e.g., a box or a
+// switch) before its text, which is either checked or unchecked.
+type CheckableSpec struct {
+	LabelSpec
+
+	// indicatorAspect is the width of the indicator relative to its height.
+	indicatorAspect float64
+	isChecked       bool
+	isIndeterminate bool
+}
+
+// IndicatorRect returns the bounds of the indicator, in the same
+// coordinate space as X and Y, as of the last Measure.
+func (c *CheckableSpec) IndicatorRect() (x, y, width, height float64) {
+	width, height = c.indicatorSize()
+	x = c.X() + c.PaddingLeft()
+	y = c.Y() + c.PaddingTop() + (c.ContentHeight()-height)/2
+	return x, y, width, height
+}
+
+// IsChecked returns true if the control is checked.
+func (c *CheckableSpec) IsChecked() bool {
+	return c.isChecked
+}
+
+// IsIndeterminate returns true if a Checkbox is drawn as neither checked
+// nor unchecked (e.g., when it summarizes a partially checked list).
+func (c *CheckableSpec) IsIndeterminate() bool {
+	return c.isIndeterminate
+}
+
+func (c *CheckableSpec) Measure(s spec.Surface) {
+	width, height := c.indicatorSize()
+	if c.Text() == "" {
+		c.lines = nil
+		c.SetTextX(0)
+		c.SetTextY(0)
+		c.SetContentWidth(width)
+		c.SetContentHeight(height)
+		return
+	}
+
+	c.measure(s, c.Text())
+	gap := height / 2
+	textWidth, textHeight := c.ContentWidth(), c.ContentHeight()
+	// Recover the text offsets that measure stored, then move the text after
+	// the indicator and center it vertically against the indicator.
+	textX := c.X() + c.PaddingLeft() - c.TextX()
+	textY := c.Y() + c.PaddingTop() - c.TextY()
+	c.SetTextX(textX - width - gap)
+	c.SetTextY(textY - math.Max(0, height-textHeight)/2)
+	c.SetContentWidth(textWidth + width + gap)
+	c.SetContentHeight(math.Max(textHeight, height))
+}
+
+// SetChecked checks or unchecks the control, clears the indeterminate state
+// and emits Changed with the new value if it has changed.
+func (c *CheckableSpec) SetChecked(isChecked bool) {
+	isChanged := c.isChecked != isChecked || c.isIndeterminate
+	c.isChecked = isChecked
+	c.isIndeterminate = false
+	if isChanged {
+		c.Invalidate()
+		c.Emit(events.New(events.Changed, c, isChecked))
+	}
+}
+
+// SetIndeterminate changes whether the control is drawn as neither checked
+// nor unchecked.
+func (c *CheckableSpec) SetIndeterminate(isIndeterminate bool) {
+	if c.isIndeterminate != isIndeterminate {
+		c.isIndeterminate = isIndeterminate
+		c.Invalidate()
+	}
+}
+
+// Toggle checks an unchecked (or indeterminate) control and unchecks a
+// checked one.
+func (c *CheckableSpec) Toggle() {
+	c.SetChecked(!c.isChecked || c.isIndeterminate)
+}
+
+// Value returns true if the control is checked.
+func (c *CheckableSpec) Value() interface{} {
+	return c.isChecked
+}
+
+// isFormField returns true, so that a Form collects whether the control is
+// checked.
+func (c *CheckableSpec) isFormField() bool {
+	return true
+}
+
+// indicatorSize returns the size of the indicator, which scales with the
+// FontSize.
+func (c *CheckableSpec) indicatorSize() (width, height float64) {
+	height = math.Round(c.FontSize() * 0.8)
+	return math.Round(height * c.indicatorAspect), height
+}
+
+// isDisabled returns true if the Spec was configured with opts.IsDisabled.
+func isDisabled(r spec.Reader) bool {
+	return r.State() == "disabled"
+}
+
+// onFocusStates changes the state of a control that is not disabled to
+// "focused" while it is focused, and back to "active" when it is blurred.
+func onFocusStates(instance spec.ReadWriter) {
+	setState := func(state string) events.EventHandler {
+		return func(e events.Event) {
+			if e.Target() == instance && !isDisabled(instance) {
+				instance.SetState(state)
+			}
+		}
+	}
+	instance.PushUnsub(instance.On(events.Blurred, setState("active")))
+	instance.PushUnsub(instance.On(events.Focused, setState("focused")))
+}
+
+// initCheckable configures a CheckableSpec that is focusable and draws a
+// focus ring when it is focused.
+func initCheckable(instance *CheckableSpec, name string, aspect float64, view spec.RenderHandler) {
+	instance.indicatorAspect = aspect
+	onFocusStates(instance)
+	instance.SetIsFocusable(true)
+	instance.SetIsMeasured(true)
+	instance.SetSpecName(name)
+	instance.SetView(view)
+	opts.OnState("active", opts.StrokeColor(0))(instance)
+	opts.OnState("focused", opts.StrokeColor(0x44d9e6ff), opts.StrokeSize(1))(instance)
+}
+
+// newToggleable returns a CheckableSpec that toggles when it is clicked, or
+// when Space is released while it is focused.
+func newToggleable(name string, aspect float64, view spec.RenderHandler, options []spec.Option) spec.ReadWriter {
+	instance := &CheckableSpec{}
+	initCheckable(instance, name, aspect, view)
+	toggle := func(e events.Event) {
+		if e.Target() != instance || isDisabled(instance) {
+			return
+		}
+		if e.Name() == events.KeyReleased && e.Payload() != input.KeySpace {
+			return
+		}
+		instance.Toggle()
+	}
+	instance.PushUnsub(instance.On(events.Clicked, toggle))
+	instance.PushUnsub(instance.On(events.KeyReleased, toggle))
+	spec.Apply(instance, options...)
+	return instance
+}
+
+// Checkbox is a control that draws a box before its text, which is checked
+// and unchecked when it is clicked or when Space is released. Checkbox
+// emits Changed with its new value.
+var Checkbox = func(options ...spec.Option) spec.ReadWriter {
+	return newToggleable("Checkbox", 1, views.CheckboxView, options)
+}
+
+// Toggle is a control that draws an on/off switch before its text and
+// otherwise behaves like a Checkbox.
+var Toggle = func(options ...spec.Option) spec.ReadWriter {
+	return newToggleable("Toggle", 1.8, views.ToggleView, options)
+}
+
+// Checked Option that only works with Checkbox and Toggle instances.
+func Checked(isChecked bool) spec.Option {
+	return func(d spec.ReadWriter) {
+		d.(*CheckableSpec).isChecked = isChecked
+	}
+}
+
+// Indeterminate Option that only works with Checkbox and ProgressBar
+// instances. A Checkbox is drawn as neither checked nor unchecked until it
+// is toggled, and a ProgressBar draws a moving bar in place of its value.
+func Indeterminate(isIndeterminate bool) spec.Option {
+	return func(d spec.ReadWriter) {
+		switch instance := d.(type) {
+		case *ProgressBarSpec:
+			instance.isIndeterminate = isIndeterminate
+		default:
+			d.(*CheckableSpec).isIndeterminate = isIndeterminate
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package ctrl_test
+
+import (
+	"testing"
+
+	"github.com/waybeams/assert"
+	"github.com/waybeams/waybeams/pkg/ctrl"
+	"github.com/waybeams/waybeams/pkg/env/fake"
+	"github.com/waybeams/waybeams/pkg/events"
+	"github.com/waybeams/waybeams/pkg/input"
+	"github.com/waybeams/waybeams/pkg/layout"
+	"github.com/waybeams/waybeams/pkg/opts"
+	"github.com/waybeams/waybeams/pkg/spec"
+	"github.com/waybeams/waybeams/pkg/views"
+)
+
+func TestCheckbox(t *testing.T) {
+	t.Run("Toggles when clicked", func(t *testing.T) {
+		var changes []bool
+		instance := ctrl.Checkbox(
+			opts.Text("Remember me"),
+			opts.On(events.Changed, func(e events.Event) {
+				changes = append(changes, e.Payload().(bool))
+			}),
+		).(*ctrl.CheckableSpec)
+		assert.True(instance.IsFocusable())
+		assert.Equal(instance.Value(), false)
+
+		instance.Emit(events.New(events.Clicked, instance, nil))
+		assert.True(instance.IsChecked())
+		assert.Equal(instance.Value(), true)
+		instance.Emit(events.New(events.Clicked, instance, nil))
+		assert.Equal(changes, []bool{true, false})
+	})
+
+	t.Run("Toggles when Space is released", func(t *testing.T) {
+		instance := ctrl.Checkbox(ctrl.Checked(true)).(*ctrl.CheckableSpec)
+		instance.Emit(events.New(events.KeyReleased, instance, input.KeyEnter))
+		assert.True(instance.IsChecked())
+		instance.Emit(events.New(events.KeyReleased, instance, input.KeySpace))
+		assert.False(instance.IsChecked())
+	})
+
+	t.Run("Ignores input when disabled", func(t *testing.T) {
+		instance := ctrl.Checkbox(opts.IsDisabled(true)).(*ctrl.CheckableSpec)
+		// The Controller focuses a control before it is clicked.
+		instance.Emit(events.New(events.Focused, instance, nil))
+		assert.Equal(instance.State(), "disabled")
+		instance.Emit(events.New(events.Clicked, instance, nil))
+		assert.False(instance.IsChecked())
+	})
+
+	t.Run("Indeterminate", func(t *testing.T) {
+		instance := ctrl.Checkbox(ctrl.Checked(true), ctrl.Indeterminate(true)).(*ctrl.CheckableSpec)
+		assert.True(instance.IsIndeterminate())
+		instance.Toggle()
+		assert.True(instance.IsChecked())
+		assert.False(instance.IsIndeterminate())
+	})
+
+	t.Run("Measures the indicator before the text", func(t *testing.T) {
+		options := []spec.Option{opts.Text("abcd"), opts.FontSize(20), opts.Padding(2)}
+		label := ctrl.Label(options...)
+		instance := ctrl.Checkbox(options...).(*ctrl.CheckableSpec)
+		s := fake.NewSurface()
+		layout.Layout(label, s)
+		layout.Layout(instance, s)
+
+		// The box is 16 x 16 with a gap of 8 before the text.
+		assert.Equal(instance.Width(), label.Width()+16+8)
+		assert.Equal(instance.Height(), label.Height())
+		x, y, width, height := instance.IndicatorRect()
+		assert.Equal([]float64{x, y, width, height}, []float64{2, 2 + (label.ContentHeight()-16)/2, 16, 16})
+		assert.Equal(instance.TextX(), label.TextX()+16+8)
+		assert.Equal(instance.TextY(), label.TextY())
+
+		empty := ctrl.Checkbox().(*ctrl.CheckableSpec)
+		layout.Layout(empty, s)
+		assert.Equal(empty.Width(), 19)
+		assert.Equal(empty.Height(), 19)
+	})
+
+	t.Run("Draws a check mark", func(t *testing.T) {
+		instance := ctrl.Checkbox(opts.Text("abcd")).(*ctrl.CheckableSpec)
+		s := fake.NewSurface()
+		layout.Layout(instance, s)
+		layout.Draw(instance, s)
+		assert.Equal(len(s.CommandsNamed("LineTo")), 0)
+
+		instance.SetChecked(true)
+		s = fake.NewSurface()
+		layout.Draw(instance, s)
+		assert.Equal(len(s.CommandsNamed("LineTo")), 2)
+		assert.Equal(len(s.CommandsNamed("Text")), 1)
+
+		instance.SetIndeterminate(true)
+		s = fake.NewSurface()
+		layout.Draw(instance, s)
+		assert.Equal(len(s.CommandsNamed("LineTo")), 1)
+	})
+}
+
+func TestToggle(t *testing.T) {
+	t.Run("Draws a switch", func(t *testing.T) {
+		instance := ctrl.Toggle(opts.FontSize(20)).(*ctrl.CheckableSpec)
+		assert.Equal(instance.SpecName(), "Toggle")
+		s := fake.NewSurface()
+		layout.Layout(instance, s)
+		_, _, width, height := instance.IndicatorRect()
+		assert.Equal(width, 29)
+		assert.Equal(height, 16)
+
+		layout.Draw(instance, s)
+		assert.Equal(s.CommandNames(), []string{"SetGlobalAlpha", "BeginPath", "RoundedRect", "SetFillColor", "Fill", "BeginPath", "Ellipse", "SetFillColor", "Fill", "SetGlobalAlpha"})
+		assert.Equal(s.GetCommands()[3].Args[0], views.DefaultTrackColor)
+		assert.Equal(s.GetCommands()[6].Args[0], 8.0)
+
+		instance.Emit(events.New(events.KeyReleased, instance, input.KeySpace))
+		assert.Equal(instance.Value(), true)
+		s = fake.NewSurface()
+		layout.Draw(instance, s)
+		assert.Equal(s.GetCommands()[3].Args[0], views.DefaultAccentColor)
+		assert.Equal(s.GetCommands()[6].Args[0], 21.0)
+	})
+}
@@ -0,0 +1,205 @@
+package ctrl
+
+import (
+	"strings"
+
+	"github.com/waybeams/waybeams/pkg/events"
+	"github.com/waybeams/waybeams/pkg/input"
+	"github.com/waybeams/waybeams/pkg/spec"
+	"github.com/waybeams/waybeams/pkg/views"
+)
+
+// ComboBoxSpec is a TextInput that opens a list of the choices that contain
+// its text, from which the text can be chosen.
+type ComboBoxSpec struct {
+	TextInputSpec
+	dropDown
+
+	isChoosing     bool
+	isEnterHandled bool
+}
+
+// ChevronRect returns the bounds of the chevron after the text, in the
+// same coordinate space as X and Y.
+func (c *ComboBoxSpec) ChevronRect() (x, y, width, height float64) {
+	return chevronRect(c)
+}
+
+// Close hides the list of choices.
+func (c *ComboBoxSpec) Close() {
+	if c.IsOpen() {
+		c.close()
+		c.Invalidate()
+	}
+}
+
+func (c *ComboBoxSpec) Measure(s spec.Surface) {
+	c.TextInputSpec.Measure(s)
+	c.SetContentWidth(c.ContentWidth() + chevronWidth(c)*2)
+}
+
+// Open shows the choices that contain the text, or closes the list if
+// there are none.
+func (c *ComboBoxSpec) Open() {
+	listed := c.filter()
+	if len(listed) == 0 {
+		c.Close()
+		return
+	}
+	c.open(listed, -1)
+	c.Invalidate()
+}
+
+// Reconcile carries the caret forward and keeps the list open, with the
+// same entry highlighted, if it was open in the previous tree.
+func (c *ComboBoxSpec) Reconcile(previous spec.ReadWriter) {
+	c.TextInputSpec.Reconcile(previous)
+	if previous, ok := previous.(*ComboBoxSpec); ok {
+		c.isEnterHandled = previous.isEnterHandled
+		if previous.IsOpen() {
+			c.open(c.filter(), previous.highlighted)
+		}
+	}
+}
+
+// Value returns the Value of the Choice whose Label is the text, or the
+// text if it does not match a Choice.
+func (c *ComboBoxSpec) Value() interface{} {
+	for _, choice := range c.choices {
+		if choice.Label == c.Text() {
+			return choice.Value
+		}
+	}
+	return c.Text()
+}
+
+// choose replaces the text with the Label of the listed Choice at index and
+// closes the list.
+func (c *ComboBoxSpec) choose(index int) {
+	if index >= 0 && index < len(c.listed) {
+		label := c.listed[index].Label
+		c.isChoosing = true
+		c.setText(label, len(label))
+		c.isChoosing = false
+	}
+	c.Close()
+}
+
+// filter returns the choices whose Label contains the text, ignoring case.
+func (c *ComboBoxSpec) filter() []Choice {
+	text := strings.ToLower(c.Text())
+	listed := []Choice{}
+	for _, choice := range c.choices {
+		if strings.Contains(strings.ToLower(choice.Label), text) {
+			listed = append(listed, choice)
+		}
+	}
+	return listed
+}
+
+// isOnChevron returns true if the provided global coordinate is on the
+// chevron, or within the padding around it.
+func (c *ComboBoxSpec) isOnChevron(globalX, globalY float64) bool {
+	originX, originY := spec.LocalToGlobal(c, 0, 0)
+	x, _, width, _ := c.ChevronRect()
+	x += originX - c.X()
+	return globalX >= x-width && globalX <= originX+c.Width() &&
+		globalY >= originY && globalY <= originY+c.Height()
+}
+
+// ComboBox is a TextInput that opens a list of the choices that contain its
+// text above all other content as the text is edited, or when Down is
+// pressed or the chevron is clicked. Up and Down move through the list and
+// Enter replaces the text with the highlighted Choice. The list is closed
+// by choosing a Choice, pressing Escape or clicking outside of it.
+// ComboBox emits Changed with its Value whenever the text changes.
+var ComboBox = func(options ...spec.Option) spec.ReadWriter {
+	instance := &ComboBoxSpec{}
+	initTextInput(&instance.TextInputSpec, "ComboBox")
+
+	var textChangedHandler = func(e events.Event) {
+		if !instance.isChoosing {
+			instance.Open()
+		}
+		instance.Emit(events.New(events.Changed, instance, instance.Value()))
+	}
+
+	var clickedHandler = func(e events.Event) {
+		payload, ok := e.Payload().(*input.MouseEventPayload)
+		if !ok || e.Target() != instance || isDisabled(instance) {
+			return
+		}
+		if instance.IsOpen() && spec.ContainsCoordinate(instance.popup, payload.X, payload.Y) {
+			if index := instance.indexAt(payload.X, payload.Y); index >= 0 {
+				instance.choose(index)
+			}
+			return
+		}
+		if instance.isOnChevron(payload.X, payload.Y) {
+			if instance.IsOpen() {
+				instance.Close()
+			} else {
+				instance.Open()
+			}
+		}
+	}
+
+	var movedHandler = func(e events.Event) {
+		payload, ok := e.Payload().(*input.MouseEventPayload)
+		if !ok || e.Target() != instance {
+			return
+		}
+		if index := instance.indexAt(payload.X, payload.Y); index >= 0 && index != instance.highlighted {
+			instance.highlight(index)
+			instance.Invalidate()
+		}
+	}
+
+	var keyPressedHandler = func(e events.Event) {
+		if e.Target() != instance || isDisabled(instance) || instance.isComposing {
+			return
+		}
+		switch e.Payload() {
+		case input.KeyDown:
+			if instance.IsOpen() {
+				instance.moveHighlight(1)
+				instance.Invalidate()
+			} else {
+				instance.Open()
+			}
+		case input.KeyUp:
+			if instance.IsOpen() {
+				instance.moveHighlight(-1)
+				instance.Invalidate()
+			}
+		case input.KeyEnter:
+			if instance.IsOpen() && instance.highlighted >= 0 {
+				// Keep the release of Enter from submitting a Form.
+				instance.isEnterHandled = true
+				instance.choose(instance.highlighted)
+			}
+		case input.KeyEscape:
+			instance.Close()
+		}
+	}
+
+	var enterKeyReleasedHandler = func(e events.Event) {
+		if instance.isEnterHandled {
+			instance.isEnterHandled = false
+			e.Cancel()
+		}
+	}
+
+	instance.PushUnsub(instance.On(events.Blurred, func(e events.Event) { instance.Close() }))
+	instance.PushUnsub(instance.On(events.Clicked, clickedHandler))
+	instance.PushUnsub(instance.On(events.EnterKeyReleased, enterKeyReleasedHandler))
+	instance.PushUnsub(instance.On(events.KeyPressed, keyPressedHandler))
+	instance.PushUnsub(instance.On(events.Moved, movedHandler))
+	instance.PushUnsub(instance.On(events.TextChanged, textChangedHandler))
+	instance.SetView(views.ComboBoxView)
+
+	spec.Apply(instance, options...)
+	addPlaceholder(&instance.TextInputSpec)
+	instance.addPopup(instance)
+	return instance
+}
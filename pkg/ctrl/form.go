@@ -1,22 +1,286 @@
 package ctrl
 
 import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
 	"github.com/waybeams/waybeams/pkg/events"
+	"github.com/waybeams/waybeams/pkg/opts"
 	"github.com/waybeams/waybeams/pkg/spec"
+	"github.com/waybeams/waybeams/pkg/validate"
 )
 
+// ErrorColor is the FontColor of validation messages.
+const ErrorColor = 0xce3262ff
+
+// FieldErrorSuffix is appended to the Key of a field to find the Key of the
+// Label that displays its validation message.
+const FieldErrorSuffix = "Error"
+
+// FormSpec gathers the values of the keyed inputs it contains, validates
+// them and emits Submitted when they are valid.
+type FormSpec struct {
+	spec.Spec
+
+	errorLabels map[string]spec.ReadWriter
+	errors      map[string]string
+	isAttempted bool
+	validators  map[string][]validate.Validator
+}
+
+// formField is implemented by the controls whose Value a Form collects.
+type formField interface {
+	isFormField() bool
+}
+
+// Bind copies the current values into the struct that target points to
+// (see Bind).
+func (f *FormSpec) Bind(target interface{}) error {
+	return Bind(f.Values(), target)
+}
+
+// Error returns the validation message of the field with key, or an empty
+// string if it is valid.
+func (f *FormSpec) Error(key string) string {
+	return f.errors[key]
+}
+
+// Errors returns the validation messages by field key, as of the last call
+// to Validate.
+func (f *FormSpec) Errors() map[string]string {
+	return f.errors
+}
+
+// Fields returns the keyed inputs (e.g., TextInput, Checkbox, RadioGroup,
+// Slider, Select and ComboBox) that are descendants of the Form, in
+// depth-first order. The children of a field are not searched.
+func (f *FormSpec) Fields() []spec.ReadWriter {
+	var fields []spec.ReadWriter
+	var collect func(node spec.ReadWriter)
+	collect = func(node spec.ReadWriter) {
+		for _, child := range node.Children() {
+			if input, ok := child.(formField); ok && input.isFormField() && child.Key() != "" {
+				fields = append(fields, child)
+				continue
+			}
+			collect(child)
+		}
+	}
+	collect(f)
+	return fields
+}
+
+// Reconcile carries validation forward from the previous Form, so that the
+// messages of a failed submission remain, and are updated, when the tree is
+// rendered again.
+func (f *FormSpec) Reconcile(previous spec.ReadWriter) {
+	if form, ok := previous.(*FormSpec); ok && form.isAttempted {
+		f.isAttempted = true
+		f.Validate()
+	}
+}
+
+// Submit validates the fields and bubbles Submitted with their values if
+// they are valid. Submit returns false, and displays the validation message
+// next to each invalid field, if they are not.
+func (f *FormSpec) Submit() bool {
+	f.isAttempted = true
+	if !f.Validate() {
+		return false
+	}
+	f.Bubble(events.New(events.Submitted, f, f.Values()))
+	return true
+}
+
+// Validate checks each field against its validators, displays the messages
+// of the invalid fields and returns true if every field is valid.
+func (f *FormSpec) Validate() bool {
+	f.errors = map[string]string{}
+	for _, field := range f.Fields() {
+		if err := validate.All(field.Value(), f.validators[field.Key()]...); err != nil {
+			f.errors[field.Key()] = err.Error()
+		}
+	}
+	f.renderErrors()
+	return len(f.errors) == 0
+}
+
+// Values returns the value of each field by key.
+func (f *FormSpec) Values() map[string]interface{} {
+	values := make(map[string]interface{})
+	for _, field := range f.Fields() {
+		values[field.Key()] = field.Value()
+	}
+	return values
+}
+
+// renderErrors replaces the Labels of the previous validation with a Label
+// after each invalid field.
+func (f *FormSpec) renderErrors() {
+	for _, label := range f.errorLabels {
+		removeChild(label.Parent(), label)
+	}
+	f.errorLabels = map[string]spec.ReadWriter{}
+	for _, field := range f.Fields() {
+		message, ok := f.errors[field.Key()]
+		if !ok {
+			continue
+		}
+		label := Label(
+			opts.Key(field.Key()+FieldErrorSuffix),
+			opts.SpecName("FieldError"),
+			opts.FontColor(ErrorColor),
+			opts.Text(message),
+		)
+		insertAfter(field, label)
+		f.errorLabels[field.Key()] = label
+	}
+	f.Invalidate()
+}
+
+// insertAfter adds child to the parent of sibling, immediately after it.
+func insertAfter(sibling, child spec.ReadWriter) {
+	parent := sibling.Parent()
+	children := []spec.ReadWriter{}
+	for _, existing := range parent.Children() {
+		children = append(children, existing)
+		if existing == sibling {
+			children = append(children, child)
+		}
+	}
+	parent.SetChildren(children)
+	child.SetParent(parent)
+}
+
+func removeChild(parent, child spec.ReadWriter) {
+	children := []spec.ReadWriter{}
+	for _, existing := range parent.Children() {
+		if existing != child {
+			children = append(children, existing)
+		}
+	}
+	parent.SetChildren(children)
+	child.SetParent(nil)
+}
+
+// Form is a container that submits the values of the keyed inputs it
+// contains when Enter is released, or when Submit is called.
 var Form = func(options ...spec.Option) spec.ReadWriter {
-	f := spec.New()
+	f := &FormSpec{validators: map[string][]validate.Validator{}}
 	f.SetSpecName("Form")
 	f.SetLayoutType(spec.VerticalFlowLayoutType)
 	f.On(events.EnterKeyReleased, func(e events.Event) {
-		values := make(map[string]interface{})
-		kids := f.Children()
-		for i := 0; i < len(kids); i++ {
-			values[kids[i].Key()] = kids[i].Value()
-		}
-		f.Bubble(events.New(events.Submitted, f, values))
+		f.Submit()
 	})
 	spec.Apply(f, options...)
 	return f
 }
+
+// Validate Option that only works with FormSpec instances. The field with
+// key is checked against the provided validators, in order, before the Form
+// is submitted.
+func Validate(key string, validators ...validate.Validator) spec.Option {
+	return func(d spec.ReadWriter) {
+		form := d.(*FormSpec)
+		form.validators[key] = append(form.validators[key], validators...)
+	}
+}
+
+// Bind copies values into the struct that target points to. Each exported
+// field is bound to the value whose key matches its `form` tag, or its name
+// (ignoring case) if it has no tag. Fields tagged `form:"-"` are skipped.
+// Values are converted to string, bool, integer and float fields, where
+// empty strings become the zero value.
+func Bind(values map[string]interface{}, target interface{}) error {
+	pointer := reflect.ValueOf(target)
+	if pointer.Kind() != reflect.Ptr || pointer.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("ctrl: Bind target must be a pointer to a struct, not %T", target)
+	}
+	structValue := pointer.Elem()
+	structType := structValue.Type()
+	for index := 0; index < structType.NumField(); index++ {
+		field := structType.Field(index)
+		if field.PkgPath != "" {
+			continue
+		}
+		key, ok := field.Tag.Lookup("form")
+		if key == "-" {
+			continue
+		}
+		var value interface{}
+		var found bool
+		if ok {
+			value, found = values[key]
+		} else {
+			key, value, found = findFold(values, field.Name)
+		}
+		if !found {
+			continue
+		}
+		if err := assign(structValue.Field(index), value); err != nil {
+			return fmt.Errorf("ctrl: cannot bind %q to %s: %v", key, field.Name, err)
+		}
+	}
+	return nil
+}
+
+// assign converts value to the type of field and stores it.
+func assign(field reflect.Value, value interface{}) error {
+	if value == nil {
+		field.Set(reflect.Zero(field.Type()))
+		return nil
+	}
+	if reflected := reflect.ValueOf(value); reflected.Type().AssignableTo(field.Type()) {
+		field.Set(reflected)
+		return nil
+	}
+
+	str := strings.TrimSpace(validate.String(value))
+	if field.Kind() != reflect.String && str == "" {
+		field.Set(reflect.Zero(field.Type()))
+		return nil
+	}
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(validate.String(value))
+	case reflect.Bool:
+		parsed, err := strconv.ParseBool(str)
+		if err != nil {
+			return err
+		}
+		field.SetBool(parsed)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		parsed, err := strconv.ParseInt(str, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetInt(parsed)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		parsed, err := strconv.ParseUint(str, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetUint(parsed)
+	case reflect.Float32, reflect.Float64:
+		parsed, err := strconv.ParseFloat(str, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetFloat(parsed)
+	default:
+		return fmt.Errorf("unsupported field type %s", field.Type())
+	}
+	return nil
+}
+
+// findFold returns the value whose key matches name, ignoring case.
+func findFold(values map[string]interface{}, name string) (string, interface{}, bool) {
+	for key, value := range values {
+		if strings.EqualFold(key, name) {
+			return key, value, true
+		}
+	}
+	return "", nil, false
+}
@@ -7,7 +7,8 @@ import (
 	"github.com/waybeams/waybeams/pkg/ctrl"
 	"github.com/waybeams/waybeams/pkg/events"
 	"github.com/waybeams/waybeams/pkg/opts"
-	// "github.com/waybeams/waybeams/pkg/spec"
+	"github.com/waybeams/waybeams/pkg/spec"
+	"github.com/waybeams/waybeams/pkg/validate"
 )
 
 func TestForm(t *testing.T) {
@@ -44,4 +45,135 @@ func TestForm(t *testing.T) {
 		assert.Equal(m["one"], "abcd")
 		assert.Equal(m["two"], "efgh")
 	})
+
+	t.Run("Provides nested values", func(t *testing.T) {
+		instance := ctrl.Form(
+			opts.Child(ctrl.HBox(
+				opts.Key("name"),
+				opts.Child(ctrl.TextInput(opts.Key("first"), opts.Text("Ada"))),
+				opts.Child(ctrl.TextInput(opts.Key("last"), opts.Text("Lovelace"))),
+			)),
+			opts.Child(ctrl.Label(opts.Key("title"), opts.Text("Ignored"))),
+			opts.Child(ctrl.TextInput(opts.Key("email"), ctrl.Placeholder("Email"))),
+		).(*ctrl.FormSpec)
+
+		values := instance.Values()
+		assert.Equal(len(values), 3)
+		assert.Equal(values["first"], "Ada")
+		assert.Equal(values["last"], "Lovelace")
+		assert.Equal(values["email"], "")
+	})
+
+	t.Run("Only collects inputs", func(t *testing.T) {
+		instance := ctrl.Form(
+			opts.Child(ctrl.TextInput(opts.Key("name"), opts.Text("Ada"))),
+			opts.Child(ctrl.RadioGroup(
+				opts.Child(ctrl.Radio(opts.Key("small"), opts.Text("Small"))),
+			)),
+			opts.Child(ctrl.Button(opts.Key("save"), opts.Text("Save"))),
+		).(*ctrl.FormSpec)
+
+		values := instance.Values()
+		assert.Equal(values, map[string]interface{}{"name": "Ada"})
+		_, hasButton := values["save"]
+		assert.False(hasButton, "Buttons are not fields")
+		_, hasRadio := values["small"]
+		assert.False(hasRadio, "Radios are collected by their RadioGroup")
+	})
+
+	t.Run("Blocks invalid submissions", func(t *testing.T) {
+		submitted := 0
+		instance := ctrl.Form(
+			ctrl.Validate("name", validate.Required("")),
+			ctrl.Validate("email", validate.Required(""), validate.Pattern(`^[^@]+@[^@]+$`, "Enter an email address")),
+			opts.On(events.Submitted, func(e events.Event) {
+				submitted++
+			}),
+			opts.Child(ctrl.HBox(
+				opts.Child(ctrl.TextInput(opts.Key("name"))),
+			)),
+			opts.Child(ctrl.TextInput(opts.Key("email"), opts.Text("ada"))),
+		).(*ctrl.FormSpec)
+
+		assert.False(instance.Submit())
+		assert.Equal(submitted, 0)
+		assert.Equal(instance.Error("name"), validate.DefaultRequiredMessage)
+		assert.Equal(instance.Error("email"), "Enter an email address")
+
+		// Messages are displayed after each invalid field.
+		label := spec.FirstByKey(instance, "nameError")
+		assert.NotNil(label)
+		assert.Equal(label.Text(), validate.DefaultRequiredMessage)
+		assert.Equal(label.FontColor(), uint(ctrl.ErrorColor))
+		assert.Equal(label.Parent().ChildAt(1), label)
+		assert.Equal(instance.ChildAt(2).Key(), "emailError")
+
+		spec.FirstByKey(instance, "name").SetText("Ada")
+		spec.FirstByKey(instance, "email").SetText("ada@example.com")
+		instance.Emit(events.New(events.EnterKeyReleased, instance, nil))
+		assert.Equal(submitted, 1)
+		assert.Equal(len(instance.Errors()), 0)
+		assert.Nil(spec.FirstByKey(instance, "nameError"))
+		assert.Equal(instance.ChildCount(), 2)
+	})
+
+	t.Run("Keeps validation when rendered again", func(t *testing.T) {
+		render := func(email string) *ctrl.FormSpec {
+			return ctrl.Form(
+				ctrl.Validate("email", validate.MinLength(5, "")),
+				opts.Child(ctrl.TextInput(opts.Key("email"), opts.Text(email))),
+			).(*ctrl.FormSpec)
+		}
+
+		previous := render("ab")
+		spec.Reconcile(previous, render("ab"))
+		assert.Equal(len(previous.Errors()), 0, "Errors wait for a submission")
+
+		previous.Submit()
+		next := render("abc")
+		spec.Reconcile(previous, next)
+		assert.Equal(next.Error("email"), "Must be at least 5 characters")
+		assert.NotNil(spec.FirstByKey(next, "emailError"))
+
+		last := render("abcde")
+		spec.Reconcile(next, last)
+		assert.Equal(len(last.Errors()), 0)
+		assert.Equal(last.ChildCount(), 1)
+	})
+
+	t.Run("Bind", func(t *testing.T) {
+		type account struct {
+			Name     string
+			Age      int     `form:"years"`
+			Balance  float64 `form:"balance"`
+			IsActive bool    `form:"active"`
+			Ignored  string  `form:"-"`
+			hidden   string
+		}
+
+		instance := ctrl.Form(
+			opts.Child(ctrl.TextInput(opts.Key("name"), opts.Text("Ada"))),
+			opts.Child(ctrl.TextInput(opts.Key("years"), opts.Text("36"))),
+			opts.Child(ctrl.TextInput(opts.Key("balance"), opts.Text("12.5"))),
+			opts.Child(ctrl.TextInput(opts.Key("Ignored"), opts.Text("abcd"))),
+		).(*ctrl.FormSpec)
+
+		result := account{Ignored: "efgh"}
+		assert.Nil(instance.Bind(&result))
+		assert.Equal(result.Name, "Ada")
+		assert.Equal(result.Age, 36)
+		assert.Equal(result.Balance, 12.5)
+		assert.False(result.IsActive)
+		assert.Equal(result.Ignored, "efgh")
+
+		assert.Nil(ctrl.Bind(map[string]interface{}{"active": true, "years": ""}, &result))
+		assert.True(result.IsActive)
+		assert.Equal(result.Age, 0)
+
+		err := ctrl.Bind(map[string]interface{}{"years": "abcd"}, &result)
+		assert.Match(`cannot bind "years" to Age`, err.Error())
+
+		err = ctrl.Bind(map[string]interface{}{}, result)
+		assert.Match("must be a pointer to a struct", err.Error())
+	})
 }
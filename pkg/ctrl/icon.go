@@ -0,0 +1,76 @@
+package ctrl
+
+import (
+	"github.com/waybeams/waybeams/pkg/spec"
+	"github.com/waybeams/waybeams/pkg/svg"
+	"github.com/waybeams/waybeams/pkg/views"
+)
+
+// IconSpec draws an SVG icon tinted with the FontColor.
+type IconSpec struct {
+	spec.Spec
+
+	icon      *svg.Document
+	iconError error
+}
+
+func (i *IconSpec) Icon() *svg.Document {
+	return i.icon
+}
+
+// IconError returns the error (if any) that was encountered while loading
+// the SVG document.
+func (i *IconSpec) IconError() error {
+	return i.iconError
+}
+
+// Measure uses the icon view box size for any dimension that was not
+// configured.
+func (i *IconSpec) Measure(s spec.Surface) {
+	if i.icon == nil {
+		return
+	}
+	_, _, width, height := i.icon.ViewBox()
+	if i.Width() == 0 && i.PrefWidth() == 0 {
+		i.SetContentWidth(width)
+	}
+	if i.Height() == 0 && i.PrefHeight() == 0 {
+		i.SetContentHeight(height)
+	}
+}
+
+// Icon is a control that draws an SVG document scaled to fit its bounds and
+// tinted with FontColor, which (like Label) is inherited from its parent.
+func Icon(options ...spec.Option) *IconSpec {
+	icon := &IconSpec{}
+	icon.SetSpecName("Icon")
+	icon.SetIsMeasured(true)
+	icon.SetView(views.IconView)
+	spec.Apply(icon, options...)
+	return icon
+}
+
+// IconSource Option that only works with IconSpec instances. The SVG file at
+// the provided path is parsed once and cached.
+func IconSource(path string) spec.Option {
+	return func(d spec.ReadWriter) {
+		icon := d.(*IconSpec)
+		icon.icon, icon.iconError = svg.Load(path)
+	}
+}
+
+// IconBytes Option that only works with IconSpec instances. The provided SVG
+// data is parsed once and cached by name.
+func IconBytes(name string, data []byte) spec.Option {
+	return func(d spec.ReadWriter) {
+		icon := d.(*IconSpec)
+		icon.icon, icon.iconError = svg.LoadBytes(name, data)
+	}
+}
+
+// IconDocument Option that only works with IconSpec instances.
+func IconDocument(document *svg.Document) spec.Option {
+	return func(d spec.ReadWriter) {
+		d.(*IconSpec).icon = document
+	}
+}
@@ -0,0 +1,51 @@
+package ctrl_test
+
+import (
+	"testing"
+
+	"github.com/waybeams/assert"
+	"github.com/waybeams/waybeams/pkg/ctrl"
+	"github.com/waybeams/waybeams/pkg/env/fake"
+	"github.com/waybeams/waybeams/pkg/layout"
+	"github.com/waybeams/waybeams/pkg/opts"
+)
+
+var checkIcon = []byte(`<svg viewBox="0 0 24 24"><path d="M4 12 L10 18 L20 6"/></svg>`)
+
+func TestIcon(t *testing.T) {
+	t.Run("Measures from the view box", func(t *testing.T) {
+		icon := ctrl.Icon(ctrl.IconBytes("check", checkIcon))
+		assert.Nil(icon.IconError())
+		layout.Layout(icon, fake.NewSurface())
+		assert.Equal(icon.Width(), 24.0)
+		assert.Equal(icon.Height(), 24.0)
+	})
+
+	t.Run("Inherits FontColor as tint", func(t *testing.T) {
+		root := ctrl.HBox(
+			opts.FontColor(0x336699ff),
+			opts.Child(ctrl.Icon(opts.Width(48), opts.Height(48), ctrl.IconBytes("check", checkIcon))),
+		)
+		s := fake.NewSurface()
+		layout.Layout(root, s)
+		layout.Draw(root, s)
+
+		var fill, moveTo []interface{}
+		for _, command := range s.GetCommands() {
+			switch command.Name {
+			case "SetFillColor":
+				fill = command.Args
+			case "MoveTo":
+				moveTo = command.Args
+			}
+		}
+		assert.Equal(fill[0], uint(0x336699ff))
+		assert.Equal(moveTo, []interface{}{8.0, 24.0}, "Scaled to the spec size")
+	})
+
+	t.Run("Exposes load errors", func(t *testing.T) {
+		icon := ctrl.Icon(ctrl.IconSource("/does/not/exist.svg"))
+		assert.NotNil(icon.IconError())
+		assert.Nil(icon.Icon())
+	})
+}
@@ -0,0 +1,143 @@
+package ctrl
+
+import (
+	"github.com/waybeams/waybeams/pkg/spec"
+	"github.com/waybeams/waybeams/pkg/views"
+)
+
+// ImageSpec displays a bitmap image that has been created on the Surface.
+type ImageSpec struct {
+	spec.Spec
+
+	data       []byte
+	fit        spec.ImageFit
+	imageError error
+	isLoaded   bool
+	name       string
+	nineSlice  [4]float64
+	path       string
+}
+
+func (i *ImageSpec) ImageFit() spec.ImageFit {
+	return i.fit
+}
+
+func (i *ImageSpec) ImageName() string {
+	return i.name
+}
+
+// ImageError returns the error (if any) that was encountered while creating
+// the image on the Surface.
+func (i *ImageSpec) ImageError() error {
+	return i.imageError
+}
+
+func (i *ImageSpec) NineSlice() (top, right, bottom, left float64) {
+	return i.nineSlice[0], i.nineSlice[1], i.nineSlice[2], i.nineSlice[3]
+}
+
+// Reconcile carries a finished load, along with any error it returned,
+// forward from the previous tree when the image name has not changed, so
+// that each image is only created on the Surface once.
+func (i *ImageSpec) Reconcile(previous spec.ReadWriter) {
+	if image, ok := previous.(*ImageSpec); ok && image.isLoaded && image.name == i.name {
+		i.isLoaded = true
+		i.imageError = image.imageError
+	}
+}
+
+// Measure creates the image on the Surface the first time the name is seen
+// and uses the intrinsic size for any dimension that was not configured. If
+// only one dimension was configured, the other preserves the image aspect
+// ratio.
+func (i *ImageSpec) Measure(s spec.Surface) {
+	if !i.isLoaded {
+		i.load(s)
+	}
+
+	width, height, ok := s.ImageSize(i.name)
+	if !ok || width <= 0 || height <= 0 {
+		return
+	}
+	configuredWidth := i.Width()
+	if configuredWidth == 0 {
+		configuredWidth = i.PrefWidth()
+	}
+	configuredHeight := i.Height()
+	if configuredHeight == 0 {
+		configuredHeight = i.PrefHeight()
+	}
+
+	switch {
+	case configuredWidth == 0 && configuredHeight == 0:
+		i.SetContentWidth(width)
+		i.SetContentHeight(height)
+	case configuredHeight == 0:
+		i.SetContentHeight((configuredWidth - i.HorizontalPadding()) * height / width)
+	case configuredWidth == 0:
+		i.SetContentWidth((configuredHeight - i.VerticalPadding()) * width / height)
+	}
+}
+
+func (i *ImageSpec) load(s spec.Surface) {
+	if i.path != "" {
+		i.imageError = s.CreateImage(i.name, i.path)
+	} else if i.data != nil {
+		i.imageError = s.CreateImageFromBytes(i.name, i.data)
+	}
+	i.isLoaded = true
+}
+
+// Image is a control that draws a bitmap, scaled according to its ImageFit.
+func Image(options ...spec.Option) *ImageSpec {
+	image := &ImageSpec{}
+	image.SetSpecName("Image")
+	image.SetIsMeasured(true)
+	image.SetView(views.ImageView)
+	spec.Apply(image, options...)
+	return image
+}
+
+// ImageSource Option that only works with ImageSpec instances. The image at
+// the provided path (or, in the browser, URL) is loaded and cached by path.
+func ImageSource(path string) spec.Option {
+	return func(d spec.ReadWriter) {
+		image := d.(*ImageSpec)
+		image.name = path
+		image.path = path
+	}
+}
+
+// ImageBytes Option that only works with ImageSpec instances. The provided
+// encoded PNG, JPEG or GIF data is cached by name.
+func ImageBytes(name string, data []byte) spec.Option {
+	return func(d spec.ReadWriter) {
+		image := d.(*ImageSpec)
+		image.name = name
+		image.data = data
+	}
+}
+
+// ImageName Option that only works with ImageSpec instances. It displays an
+// image that was already created on the Surface with the provided name.
+func ImageName(name string) spec.Option {
+	return func(d spec.ReadWriter) {
+		d.(*ImageSpec).name = name
+	}
+}
+
+// Fit Option that only works with ImageSpec instances.
+func Fit(fit spec.ImageFit) spec.Option {
+	return func(d spec.ReadWriter) {
+		d.(*ImageSpec).fit = fit
+	}
+}
+
+// NineSlice Option that only works with ImageSpec instances. The provided
+// insets (in image pixels) mark the corners that keep their size while the
+// edges and center stretch, which is useful for skinnable backgrounds.
+func NineSlice(top, right, bottom, left float64) spec.Option {
+	return func(d spec.ReadWriter) {
+		d.(*ImageSpec).nineSlice = [4]float64{top, right, bottom, left}
+	}
+}
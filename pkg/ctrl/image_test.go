@@ -0,0 +1,67 @@
+package ctrl_test
+
+import (
+	"testing"
+
+	"github.com/waybeams/assert"
+	"github.com/waybeams/waybeams/pkg/ctrl"
+	"github.com/waybeams/waybeams/pkg/env/fake"
+	"github.com/waybeams/waybeams/pkg/fakes"
+	"github.com/waybeams/waybeams/pkg/layout"
+	"github.com/waybeams/waybeams/pkg/opts"
+	"github.com/waybeams/waybeams/pkg/spec"
+)
+
+func TestImage(t *testing.T) {
+	t.Run("Measures intrinsic size", func(t *testing.T) {
+		image := ctrl.Image(ctrl.ImageBytes("photo", fakes.PNG(64, 32)))
+		layout.Layout(image, fake.NewSurface())
+		assert.Nil(image.ImageError())
+		assert.Equal(image.Width(), 64.0)
+		assert.Equal(image.Height(), 32.0)
+	})
+
+	t.Run("Preserves aspect ratio with one configured dimension", func(t *testing.T) {
+		image := ctrl.Image(opts.Width(32), ctrl.ImageBytes("photo", fakes.PNG(64, 32)))
+		layout.Layout(image, fake.NewSurface())
+		assert.Equal(image.Width(), 32.0)
+		assert.Equal(image.Height(), 16.0)
+	})
+
+	t.Run("Exposes load errors", func(t *testing.T) {
+		image := ctrl.Image(ctrl.ImageSource("/does/not/exist.png"))
+		layout.Layout(image, fake.NewSurface())
+		assert.NotNil(image.ImageError())
+		assert.Equal(image.Width(), 0.0)
+	})
+
+	t.Run("Loads once per name", func(t *testing.T) {
+		s := fake.NewSurface()
+		first := ctrl.Image(ctrl.ImageBytes("broken", []byte("not an image")))
+		layout.Layout(first, s)
+		next := ctrl.Image(ctrl.ImageBytes("broken", []byte("not an image")))
+		spec.Reconcile(first, next)
+		layout.Layout(next, s)
+		layout.Layout(next, s)
+
+		assert.Equal(len(s.CommandsNamed("CreateImageFromBytes")), 1)
+		assert.NotNil(next.ImageError(), "Error is carried forward")
+
+		renamed := ctrl.Image(ctrl.ImageBytes("photo", fakes.PNG(64, 32)))
+		spec.Reconcile(next, renamed)
+		layout.Layout(renamed, s)
+		assert.Nil(renamed.ImageError())
+		assert.Equal(renamed.Width(), 64.0)
+	})
+
+	t.Run("Draws the image", func(t *testing.T) {
+		s := fake.NewSurface()
+		image := ctrl.Image(ctrl.ImageBytes("photo", fakes.PNG(64, 32)))
+		layout.Layout(image, s)
+		layout.Draw(image, s)
+
+		drawn := s.CommandsNamed("DrawImageRegion")[0].Args
+		assert.Equal(drawn[0], "photo")
+		assert.Equal(drawn[5:], []interface{}{0.0, 0.0, 64.0, 32.0})
+	})
+}
@@ -1,25 +1,91 @@
 package ctrl
 
 import (
+	"math"
+
 	"github.com/waybeams/waybeams/pkg/spec"
+	"github.com/waybeams/waybeams/pkg/text"
 	"github.com/waybeams/waybeams/pkg/views"
 )
 
 type LabelSpec struct {
 	spec.Spec
 
+	lines            []text.Line
 	measuredText     string
 	measuredFontSize float64
+	wrapText         bool
+}
+
+// Lines returns the shaped lines of text, which are only measured when the
+// text is wrapped or cannot be measured one character at a time (e.g., right
+// to left or complex scripts).
+func (l *LabelSpec) Lines() []text.Line {
+	return l.lines
 }
 
 func (l *LabelSpec) Measure(s spec.Surface) {
-	x, y, w, h := s.TextBounds(l.FontFace(), l.FontSize(), l.Text())
+	l.measure(s, l.Text())
+}
+
+// measure sizes the label to fit value, which is the text as it will be
+// drawn.
+func (l *LabelSpec) measure(s spec.Surface, value string) {
+	face := s.Fonts().FaceNameFor(l.FontFace(), l.FontWeight(), l.FontStyle())
+	l.lines = nil
+	if l.wrapText || text.NeedsShaping(value) {
+		if l.measureLines(s, face, value) {
+			return
+		}
+	}
+	x, y, w, h := s.TextBounds(face, l.FontSize(), value)
 	l.SetTextX(x)
 	l.SetTextY(y)
 	l.SetContentWidth(w)
 	l.SetContentHeight(h)
 }
 
+// measureLines shapes the text into lines with the Surface Shaper, and
+// returns false if the face could not be shaped.
+func (l *LabelSpec) measureLines(s spec.Surface, face, value string) bool {
+	var lines []text.Line
+	if l.wrapText {
+		lines = s.Shaper().Wrap(face, l.FontSize(), value, l.wrapWidth())
+	} else {
+		lines = []text.Line{s.Shaper().Shape(face, l.FontSize(), value)}
+	}
+	if len(lines) == 0 || lines[0].Height == 0 {
+		return false
+	}
+
+	width, height := 0.0, 0.0
+	for _, line := range lines {
+		width = math.Max(width, line.Width)
+		height += line.Height
+	}
+	l.lines = lines
+	l.SetTextX(0)
+	l.SetTextY(-lines[0].Ascent)
+	l.SetContentWidth(math.Ceil(width))
+	l.SetContentHeight(math.Ceil(height))
+	return true
+}
+
+// wrapWidth returns the width that wrapped lines must fit within, which is
+// the configured Width or MaxWidth without padding, or zero if neither is
+// configured.
+func (l *LabelSpec) wrapWidth() float64 {
+	l.SetContentWidth(0)
+	width := l.Width()
+	if width <= 0 {
+		width = l.MaxWidth()
+	}
+	if width <= 0 {
+		return 0
+	}
+	return math.Max(width-l.HorizontalPadding(), 1)
+}
+
 func Label(options ...spec.Option) *LabelSpec {
 	label := &LabelSpec{}
 	label.SetSpecName("Label")
@@ -29,3 +95,12 @@ func Label(options ...spec.Option) *LabelSpec {
 	spec.Apply(label, options...)
 	return label
 }
+
+// WrapText Option that only works with LabelSpec instances. The text will be
+// broken into lines that fit within the Width or MaxWidth of the Label, at
+// the opportunities defined by Unicode line breaking (UAX #14).
+func WrapText() spec.Option {
+	return func(d spec.ReadWriter) {
+		d.(*LabelSpec).wrapText = true
+	}
+}
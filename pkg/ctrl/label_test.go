@@ -6,10 +6,11 @@ import (
 	"github.com/waybeams/assert"
 	"github.com/waybeams/waybeams/pkg/ctrl"
 	"github.com/waybeams/waybeams/pkg/env/fake"
-	"github.com/waybeams/waybeams/pkg/env/nano"
+	"github.com/waybeams/waybeams/pkg/font"
 	"github.com/waybeams/waybeams/pkg/layout"
 	"github.com/waybeams/waybeams/pkg/opts"
 	"github.com/waybeams/waybeams/pkg/spec"
+	"github.com/waybeams/waybeams/pkg/text"
 )
 
 func TestLabel(t *testing.T) {
@@ -20,7 +21,7 @@ func TestLabel(t *testing.T) {
 			opts.Text("Hello World"))
 
 		assert.NotNil(label)
-		s := nano.NewWithRoboto()
+		s := fake.NewSurface(fake.FontMetrics())
 		layout.Layout(label, s)
 
 		assert.Equal(label.Width(), 107)
@@ -43,7 +44,7 @@ func TestLabel(t *testing.T) {
 
 		root := create()
 
-		s := nano.NewWithRoboto()
+		s := fake.NewSurface(fake.FontMetrics())
 		layout.Layout(root, s)
 
 		kids := root.Children()
@@ -67,13 +68,97 @@ func TestLabel(t *testing.T) {
 		layout.Draw(root.ChildAt(0), fakeSurface)
 		cmds := fakeSurface.GetCommands()
 
-		assert.Equal(len(cmds), 4)
-		assert.Equal(cmds[1].Name, "SetFontFace")
+		// Draw brackets each control with SetGlobalAlpha.
+		assert.Equal(len(cmds), 6)
+		assert.Equal(cmds[2].Name, "SetFontFace")
 		// NOTE(lbayes): The following will fail if AddFont is not called in the
 		// fake surface.
-		args := cmds[3].Args
+		args := cmds[4].Args
 		assert.Equal(args[0], 0)
 		assert.Equal(args[1], 13)
 		assert.Equal(args[2], "a")
 	})
 }
+
+func TestResolvedFontFace(t *testing.T) {
+	measuredFace := func(options ...spec.Option) interface{} {
+		s := fake.NewSurface()
+		label := ctrl.Label(append(options, opts.Text("abc"))...)
+		layout.Layout(label, s)
+
+		measured := s.CommandsNamed("Text")
+		return measured[len(measured)-1].Args[0]
+	}
+
+	t.Run("Uses embedded Roboto by default", func(t *testing.T) {
+		assert.Equal(measuredFace(), "Roboto")
+	})
+
+	t.Run("Resolves weight and style", func(t *testing.T) {
+		assert.Equal(measuredFace(opts.FontWeight(font.Light)), "Roboto-300")
+		assert.Equal(measuredFace(opts.FontWeight(250)), "Roboto-100")
+		assert.Equal(measuredFace(opts.FontWeight(font.Bold), opts.FontStyle(font.Italic)), "Roboto-700-Italic")
+	})
+
+	t.Run("Unknown families fall back to Roboto", func(t *testing.T) {
+		assert.Equal(measuredFace(opts.FontFace("Helvetica"), opts.FontWeight(font.Medium)), "Roboto-500")
+	})
+}
+
+func TestShapedLabel(t *testing.T) {
+	t.Run("Simple text is measured with TextBounds", func(t *testing.T) {
+		label := ctrl.Label(opts.Text("Hello World"))
+		layout.Layout(label, fake.NewSurface())
+		assert.Equal(len(label.Lines()), 0)
+	})
+
+	t.Run("Right to left text is shaped", func(t *testing.T) {
+		label := ctrl.Label(opts.Text("שלום abc"), opts.Width(200))
+		s := fake.NewSurface()
+		layout.Layout(label, s)
+		assert.Equal(len(label.Lines()), 1)
+		assert.Equal(label.Height(), 24)
+
+		layout.Draw(label, s)
+		assert.Equal(len(s.CommandsNamed("Text")), 0, "Shaped text is not measured with TextBounds")
+		drawn := s.CommandsNamed("DrawGlyphs")
+		assert.Equal(len(drawn), 1)
+		line := drawn[0].Args[2].(text.Line)
+		// Right to left paragraphs are aligned to the right edge.
+		assert.Equal(drawn[0].Args[0], 200-line.Width)
+	})
+
+	t.Run("Wraps to the width", func(t *testing.T) {
+		label := ctrl.Label(
+			ctrl.WrapText(),
+			opts.Padding(5),
+			opts.Text("The quick brown fox jumps over the lazy dog"),
+			opts.Width(150),
+		)
+		s := fake.NewSurface()
+		layout.Layout(label, s)
+		lines := label.Lines()
+		assert.True(len(lines) > 1)
+		for _, line := range lines {
+			assert.True(line.Width <= 140)
+		}
+		assert.Equal(label.Width(), 150)
+		assert.Equal(label.Height(), 24*float64(len(lines))+10)
+
+		layout.Draw(label, s)
+		drawn := s.CommandsNamed("DrawGlyphs")
+		assert.Equal(len(drawn), len(lines))
+		assert.Equal(drawn[1].Args[1].(float64)-drawn[0].Args[1].(float64), 24)
+	})
+
+	t.Run("Wraps to the max width", func(t *testing.T) {
+		label := ctrl.Label(
+			ctrl.WrapText(),
+			opts.MaxWidth(100),
+			opts.Text("The quick brown fox"),
+		)
+		layout.Layout(label, fake.NewSurface())
+		assert.True(len(label.Lines()) > 1)
+		assert.True(label.Width() <= 100)
+	})
+}
@@ -0,0 +1,116 @@
+package ctrl
+
+import (
+	"math"
+	"time"
+
+	"github.com/waybeams/waybeams/pkg/events"
+	"github.com/waybeams/waybeams/pkg/spec"
+	"github.com/waybeams/waybeams/pkg/views"
+)
+
+// IndeterminatePeriod is the time it takes the moving bar of an
+// indeterminate ProgressBar to cross the track.
+const IndeterminatePeriod = 1500 * time.Millisecond
+
+// ProgressBarSpec is a RangeSpec that draws its value as a filled part of a
+// track, or a moving bar when the value is not known.
+type ProgressBarSpec struct {
+	RangeSpec
+
+	elapsed         time.Duration
+	isIndeterminate bool
+}
+
+// FillRect returns the filled part of the track, in the same coordinate
+// space as X and Y. An indeterminate ProgressBar fills a third of the track
+// that moves from the start to the end every IndeterminatePeriod.
+func (p *ProgressBarSpec) FillRect() (x, y, width, height float64) {
+	x, y, width, height = p.TrackRect()
+	length := width
+	if p.isVertical {
+		length = height
+	}
+
+	start, end := 0.0, p.Fraction()*length
+	if p.isIndeterminate {
+		phase := float64(p.elapsed%IndeterminatePeriod) / float64(IndeterminatePeriod)
+		bar := length / 3
+		start = math.Max(0, phase*(length+bar)-bar)
+		end = math.Min(length, phase*(length+bar))
+	}
+
+	if p.isVertical {
+		return x, y + height - end, width, end - start
+	}
+	return x + start, y, end - start, height
+}
+
+// IsIndeterminate returns true if the value is not known.
+func (p *ProgressBarSpec) IsIndeterminate() bool {
+	return p.isIndeterminate
+}
+
+func (p *ProgressBarSpec) Measure(s spec.Surface) {
+	length := math.Round(p.FontSize()*0.8) * 5
+	thickness := p.thickness()
+	if p.isVertical {
+		p.SetContentWidth(thickness)
+		p.SetContentHeight(length)
+		return
+	}
+	p.SetContentWidth(length)
+	p.SetContentHeight(thickness)
+}
+
+// Reconcile carries the animation of an indeterminate ProgressBar forward
+// from the previous tree.
+func (p *ProgressBarSpec) Reconcile(previous spec.ReadWriter) {
+	if bar, ok := previous.(*ProgressBarSpec); ok {
+		p.elapsed = bar.elapsed
+	}
+}
+
+// SetIndeterminate changes whether the value is known.
+func (p *ProgressBarSpec) SetIndeterminate(isIndeterminate bool) {
+	if p.isIndeterminate != isIndeterminate {
+		p.isIndeterminate = isIndeterminate
+		p.Invalidate()
+	}
+}
+
+// TrackRect returns the bounds of the track, which fills the padded bounds,
+// in the same coordinate space as X and Y.
+func (p *ProgressBarSpec) TrackRect() (x, y, width, height float64) {
+	return p.innerRect()
+}
+
+// thickness returns the height of a horizontal bar, or the width of a
+// vertical one, which scales with the FontSize.
+func (p *ProgressBarSpec) thickness() float64 {
+	return math.Max(TrackThickness, math.Round(p.FontSize()/4))
+}
+
+// ProgressBar is a control that displays progress between a minimum and a
+// maximum, which defaults to 0 to 100.
+var ProgressBar = func(options ...spec.Option) spec.ReadWriter {
+	instance := &ProgressBarSpec{}
+	instance.max = 100
+
+	var frameEnteredHandler = func(e events.Event) {
+		payload, ok := e.Payload().(events.FramePayload)
+		if ok && instance.isIndeterminate {
+			instance.elapsed += payload.Delta
+			instance.Invalidate()
+		}
+	}
+
+	instance.PushUnsub(instance.On(events.FrameEntered, frameEnteredHandler))
+	instance.SetIsMeasured(true)
+	instance.SetSpecName("ProgressBar")
+	instance.SetView(views.ProgressBarView)
+
+	spec.Apply(instance, options...)
+	instance.value = instance.constrain(instance.value)
+	return instance
+}
@@ -0,0 +1,160 @@
+package ctrl
+
+import (
+	"github.com/waybeams/waybeams/pkg/events"
+	"github.com/waybeams/waybeams/pkg/input"
+	"github.com/waybeams/waybeams/pkg/spec"
+	"github.com/waybeams/waybeams/pkg/views"
+)
+
+// RadioSpec is one option of a RadioGroup, which checks it when it is
+// selected.
+type RadioSpec struct {
+	CheckableSpec
+}
+
+// Value returns the Key of the Radio, or its Text if it has no Key.
+func (r *RadioSpec) Value() interface{} {
+	if r.Key() != "" {
+		return r.Key()
+	}
+	return r.Text()
+}
+
+// isFormField returns false, because a Form collects the Value of the
+// RadioGroup instead.
+func (r *RadioSpec) isFormField() bool {
+	return false
+}
+
+// RadioGroupSpec contains Radio controls, of which at most one is
+// selected.
+type RadioGroupSpec struct {
+	spec.Spec
+
+	selected string
+}
+
+// Radios returns the Radio controls within the group, in depth-first
+// order.
+func (g *RadioGroupSpec) Radios() []*RadioSpec {
+	var radios []*RadioSpec
+	spec.Walk(g, func(node spec.ReadWriter) {
+		if radio, ok := node.(*RadioSpec); ok {
+			radios = append(radios, radio)
+		}
+	})
+	return radios
+}
+
+// Select checks the Radio with the provided value, unchecks the others and
+// emits Changed with the value if the selection has changed.
+func (g *RadioGroupSpec) Select(value string) {
+	if g.selected == value {
+		return
+	}
+	g.selected = value
+	g.updateRadios()
+	g.Invalidate()
+	g.Emit(events.New(events.Changed, g, value))
+}
+
+// Value returns the value of the selected Radio, or an empty string if none
+// is selected.
+func (g *RadioGroupSpec) Value() interface{} {
+	return g.selected
+}
+
+// isFormField returns true, so that a Form collects the selected value.
+func (g *RadioGroupSpec) isFormField() bool {
+	return true
+}
+
+// selectNext selects the Radio that is offset positions from the selected
+// one, wrapping around at either end.
+func (g *RadioGroupSpec) selectNext(offset int) {
+	radios := g.Radios()
+	if len(radios) == 0 {
+		return
+	}
+	index := -1
+	for i, radio := range radios {
+		if radio.Value() == g.selected {
+			index = i
+		}
+	}
+	if index == -1 {
+		index = 0
+	} else {
+		index = (index + offset + len(radios)) % len(radios)
+	}
+	g.Select(radios[index].Value().(string))
+}
+
+// updateRadios checks the selected Radio, without emitting Changed from
+// the Radios.
+func (g *RadioGroupSpec) updateRadios() {
+	for _, radio := range g.Radios() {
+		radio.isChecked = radio.Value() == g.selected
+		radio.isIndeterminate = false
+	}
+}
+
+// Radio is an option of a RadioGroup, that draws a circle before its text.
+var Radio = func(options ...spec.Option) spec.ReadWriter {
+	instance := &RadioSpec{}
+	initCheckable(&instance.CheckableSpec, "Radio", 1, views.RadioView)
+	spec.Apply(instance, options...)
+	return instance
+}
+
+// RadioGroup is a vertical container of Radio controls that selects a Radio
+// when it is clicked, or when an arrow key is pressed while the group or
+// one of its Radios is focused. RadioGroup emits Changed with the value of
+// the selected Radio, which is also its Value for a Form.
+var RadioGroup = func(options ...spec.Option) spec.ReadWriter {
+	instance := &RadioGroupSpec{}
+	instance.SetIsFocusable(true)
+	instance.SetLayoutType(spec.VerticalFlowLayoutType)
+	instance.SetSpecName("RadioGroup")
+
+	var clickedHandler = func(e events.Event) {
+		if radio, ok := e.Target().(*RadioSpec); ok && !isDisabled(instance) && !isDisabled(radio) {
+			instance.Select(radio.Value().(string))
+		}
+	}
+
+	var keyPressedHandler = func(e events.Event) {
+		if isDisabled(instance) {
+			return
+		}
+		switch e.Payload() {
+		case input.KeyDown, input.KeyRight:
+			instance.selectNext(1)
+		case input.KeyUp, input.KeyLeft:
+			instance.selectNext(-1)
+		case input.KeySpace:
+			if instance.selected == "" {
+				instance.selectNext(0)
+			}
+		}
+	}
+
+	instance.PushUnsub(instance.On(events.Clicked, clickedHandler))
+	instance.PushUnsub(instance.On(events.KeyPressed, keyPressedHandler))
+	spec.Apply(instance, options...)
+	instance.updateRadios()
+	return instance
+}
+
+// Selected Option that only works with RadioGroup and Select instances.
+func Selected(value string) spec.Option {
+	return func(d spec.ReadWriter) {
+		switch instance := d.(type) {
+		case *SelectSpec:
+			instance.selected = value
+		default:
+			d.(*RadioGroupSpec).selected = value
+		}
+	}
+}
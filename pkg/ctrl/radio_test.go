@@ -0,0 +1,103 @@
+package ctrl_test
+
+import (
+	"testing"
+
+	"github.com/waybeams/assert"
+	"github.com/waybeams/waybeams/pkg/ctrl"
+	"github.com/waybeams/waybeams/pkg/env/fake"
+	"github.com/waybeams/waybeams/pkg/events"
+	"github.com/waybeams/waybeams/pkg/input"
+	"github.com/waybeams/waybeams/pkg/layout"
+	"github.com/waybeams/waybeams/pkg/opts"
+	"github.com/waybeams/waybeams/pkg/spec"
+)
+
+func TestRadioGroup(t *testing.T) {
+	createGroup := func(options ...spec.Option) *ctrl.RadioGroupSpec {
+		return ctrl.RadioGroup(append([]spec.Option{
+			opts.Key("size"),
+			opts.Child(ctrl.Radio(opts.Key("small"), opts.Text("Small"))),
+			opts.Child(ctrl.HBox(
+				opts.Child(ctrl.Radio(opts.Key("medium"), opts.Text("Medium"))),
+			)),
+			opts.Child(ctrl.Radio(opts.Text("Large"))),
+		}, options...)...).(*ctrl.RadioGroupSpec)
+	}
+
+	t.Run("Selected", func(t *testing.T) {
+		group := createGroup(ctrl.Selected("medium"))
+		radios := group.Radios()
+		assert.Equal(len(radios), 3)
+		assert.False(radios[0].IsChecked())
+		assert.True(radios[1].IsChecked())
+		assert.Equal(radios[2].Value(), "Large")
+		assert.Equal(group.Value(), "medium")
+	})
+
+	t.Run("Selection is exclusive", func(t *testing.T) {
+		var changes []string
+		group := createGroup(opts.On(events.Changed, events.StringPayload(func(value string) {
+			changes = append(changes, value)
+		})))
+		radios := group.Radios()
+		assert.Equal(group.Value(), "")
+
+		radios[2].Bubble(events.New(events.Clicked, radios[2], nil))
+		assert.True(radios[2].IsChecked())
+		radios[0].Bubble(events.New(events.Clicked, radios[0], nil))
+		radios[0].Bubble(events.New(events.Clicked, radios[0], nil))
+		assert.True(radios[0].IsChecked())
+		assert.False(radios[2].IsChecked())
+		assert.Equal(changes, []string{"Large", "small"})
+	})
+
+	t.Run("Arrow keys move the selection", func(t *testing.T) {
+		group := createGroup()
+		radios := group.Radios()
+		press := func(key input.Key) {
+			radios[1].Bubble(events.New(events.KeyPressed, radios[1], key))
+		}
+
+		press(input.KeySpace)
+		assert.Equal(group.Value(), "small")
+		press(input.KeyDown)
+		assert.Equal(group.Value(), "medium")
+		press(input.KeyRight)
+		assert.Equal(group.Value(), "Large")
+		press(input.KeyDown)
+		assert.Equal(group.Value(), "small")
+		press(input.KeyUp)
+		assert.Equal(group.Value(), "Large")
+		press(input.KeySpace)
+		assert.Equal(group.Value(), "Large")
+	})
+
+	t.Run("Disabled radios are not selected", func(t *testing.T) {
+		group := ctrl.RadioGroup(
+			opts.Child(ctrl.Radio(opts.Key("a"), opts.IsDisabled(true))),
+		).(*ctrl.RadioGroupSpec)
+		radio := group.Radios()[0]
+		radio.Bubble(events.New(events.Clicked, radio, nil))
+		assert.Equal(group.Value(), "")
+	})
+
+	t.Run("Provides its value to a Form", func(t *testing.T) {
+		form := ctrl.Form(
+			opts.Child(createGroup(ctrl.Selected("small"))),
+			opts.Child(ctrl.Checkbox(opts.Key("gift"), ctrl.Checked(true))),
+			opts.Child(ctrl.Toggle(opts.Key("express"))),
+		).(*ctrl.FormSpec)
+		assert.Equal(form.Values(), map[string]interface{}{"size": "small", "gift": true, "express": false})
+	})
+
+	t.Run("Draws the selected dot", func(t *testing.T) {
+		group := createGroup(ctrl.Selected("small"))
+		s := fake.NewSurface()
+		layout.Layout(group, s)
+		s = fake.NewSurface()
+		layout.Draw(group, s)
+		assert.Equal(len(s.CommandsNamed("Ellipse")), 4)
+		assert.Equal(len(s.CommandsNamed("Text")), 3)
+	})
+}
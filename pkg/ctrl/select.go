@@ -0,0 +1,478 @@
+package ctrl
+
+import (
+	"math"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/waybeams/waybeams/pkg/events"
+	"github.com/waybeams/waybeams/pkg/input"
+	"github.com/waybeams/waybeams/pkg/opts"
+	"github.com/waybeams/waybeams/pkg/spec"
+	"github.com/waybeams/waybeams/pkg/views"
+)
+
+// PopupKey is the Key of the overlay that lists the choices of a Select or
+// a ComboBox.
+const PopupKey = "DropDown.Popup"
+
+// TypeAheadTimeout is how long a Select remembers typed characters, so that
+// typing quickly moves to the first choice that starts with all of them.
+const TypeAheadTimeout = time.Second
+
+// Choice is an entry in the list of a Select or a ComboBox, where Label is
+// drawn and Value is reported.
+type Choice struct {
+	Label string
+	Value string
+}
+
+// dropDown is the list of choices of a Select or a ComboBox, along with the
+// overlay that shows them while the list is open.
+type dropDown struct {
+	choices     []Choice
+	highlighted int
+	listed      []Choice
+	popup       spec.ReadWriter
+	sinceTyped  time.Duration
+	typed       string
+}
+
+// Choices returns every configured Choice.
+func (d *dropDown) Choices() []Choice {
+	return d.choices
+}
+
+// Highlighted returns the index of the highlighted entry in the open list,
+// or -1 if none is highlighted.
+func (d *dropDown) Highlighted() int {
+	return d.highlighted
+}
+
+// IsOpen returns true while the list of choices is shown.
+func (d *dropDown) IsOpen() bool {
+	return d.popup != nil && d.popup.Visible()
+}
+
+// Listed returns the choices that are shown in the open list, which a
+// ComboBox filters by its text.
+func (d *dropDown) Listed() []Choice {
+	return d.listed
+}
+
+// addPopup adds the hidden overlay that lists the choices to owner.
+func (d *dropDown) addPopup(owner spec.ReadWriter) {
+	d.highlighted = -1
+	d.popup = VBox(
+		opts.BgColor(0xfefefeff),
+		opts.IsOverlay(true),
+		opts.Key(PopupKey),
+		opts.Padding(1),
+		opts.Shadow(0, 2, 6, 0, 0x00000040),
+		opts.SpecName("Popup"),
+		opts.StrokeColor(0x666666ff),
+		opts.StrokeSize(1),
+		opts.Visible(false),
+	)
+	opts.Child(d.popup)(owner)
+}
+
+// close hides the list and forgets any typed characters.
+func (d *dropDown) close() {
+	d.popup.SetVisible(false)
+	d.highlighted = -1
+	d.typed = ""
+}
+
+// highlight highlights the entry at index, or none if index is out of
+// range.
+func (d *dropDown) highlight(index int) {
+	if index < 0 || index >= len(d.listed) {
+		index = -1
+	}
+	d.highlighted = index
+	for i, item := range d.popup.Children() {
+		if i == index {
+			item.SetBgColor(views.DefaultAccentColor)
+			item.SetFontColor(views.DefaultMarkColor)
+		} else {
+			item.SetBgColor(0)
+			item.SetFontColor(0)
+		}
+	}
+}
+
+// indexAt returns the index of the listed entry under the provided global
+// coordinate, or -1 if there is none.
+func (d *dropDown) indexAt(globalX, globalY float64) int {
+	if !d.IsOpen() {
+		return -1
+	}
+	for index, item := range d.popup.Children() {
+		if spec.ContainsCoordinate(item, globalX, globalY) {
+			return index
+		}
+	}
+	return -1
+}
+
+// isTyping returns true if characters were typed within the
+// TypeAheadTimeout, as measured by the frames entered since.
+func (d *dropDown) isTyping() bool {
+	return d.typed != "" && d.sinceTyped <= TypeAheadTimeout
+}
+
+// moveHighlight highlights the entry that is offset positions from the
+// highlighted one, stopping at either end.
+func (d *dropDown) moveHighlight(offset int) {
+	count := len(d.listed)
+	if count == 0 {
+		return
+	}
+	index := d.highlighted + offset
+	if d.highlighted == -1 && offset < 0 {
+		index = count - 1
+	}
+	d.highlight(int(math.Max(0, math.Min(float64(count-1), float64(index)))))
+}
+
+// open shows the provided choices, highlights the one at highlighted and
+// forgets any typed characters.
+func (d *dropDown) open(listed []Choice, highlighted int) {
+	d.listed = listed
+	d.typed = ""
+	items := make([]spec.ReadWriter, 0, len(listed))
+	for _, choice := range listed {
+		item := Label(
+			opts.FlexWidth(1),
+			opts.Padding(5),
+			opts.SpecName("Choice"),
+			opts.Text(choice.Label),
+		)
+		item.SetParent(d.popup)
+		items = append(items, item)
+	}
+	d.popup.SetChildren(items)
+	d.popup.SetVisible(true)
+	d.highlight(highlighted)
+}
+
+// typeAhead adds char to the typed characters and returns the index of the
+// first listed choice from current whose Label starts with them, or -1 if
+// there is none. Typing the same character repeatedly moves through the
+// choices that start with it.
+func (d *dropDown) typeAhead(char string, current int) int {
+	if !d.isTyping() {
+		d.typed = ""
+	}
+	d.typed += strings.ToLower(char)
+	d.sinceTyped = 0
+
+	prefix, start := d.typed, current
+	first, size := utf8.DecodeRuneInString(d.typed)
+	if strings.Count(d.typed, string(first))*size == len(d.typed) {
+		prefix, start = string(first), current+1
+	}
+	count := len(d.listed)
+	for i := 0; i < count; i++ {
+		index := ((start+i)%count + count) % count
+		if strings.HasPrefix(strings.ToLower(d.listed[index].Label), prefix) {
+			return index
+		}
+	}
+	return -1
+}
+
+// dropDownProvider is satisfied by Select and ComboBox instances, so that
+// the choice Options work with both.
+type dropDownProvider interface {
+	dropDownList() *dropDown
+}
+
+func (d *dropDown) dropDownList() *dropDown {
+	return d
+}
+
+// chevronRect returns the bounds of the chevron at the end of a Select or a
+// ComboBox, in the same coordinate space as X and Y.
+func chevronRect(r spec.Reader) (x, y, width, height float64) {
+	width = chevronWidth(r)
+	height = math.Round(width / 2)
+	x = r.X() + r.Width() - r.PaddingRight() - width
+	y = r.Y() + r.PaddingTop() + (r.Height()-r.VerticalPadding()-height)/2
+	return x, y, width, height
+}
+
+// chevronWidth returns the width of the chevron, which scales with the
+// FontSize and is separated from the text by the same amount.
+func chevronWidth(r spec.Reader) float64 {
+	return math.Round(r.FontSize() * 0.5)
+}
+
+// SelectSpec is a Label that shows the selected Choice, and opens a list of
+// the choices when it is clicked.
+type SelectSpec struct {
+	LabelSpec
+	dropDown
+
+	isEnterHandled bool
+	prompt         string
+	selected       string
+}
+
+// ChevronRect returns the bounds of the chevron after the text, in the
+// same coordinate space as X and Y.
+func (s *SelectSpec) ChevronRect() (x, y, width, height float64) {
+	return chevronRect(s)
+}
+
+// Close hides the list of choices.
+func (s *SelectSpec) Close() {
+	if s.IsOpen() {
+		s.close()
+		s.Invalidate()
+	}
+}
+
+func (s *SelectSpec) Measure(surface spec.Surface) {
+	// Fit the longest Label, so that the width does not change with the
+	// selection.
+	width, height := 0.0, 0.0
+	for _, choice := range s.choices {
+		s.measure(surface, choice.Label)
+		width = math.Max(width, s.ContentWidth())
+		height = math.Max(height, s.ContentHeight())
+	}
+	s.measure(surface, s.Text())
+	s.SetContentWidth(math.Max(width, s.ContentWidth()) + chevronWidth(s)*2)
+	s.SetContentHeight(math.Max(height, s.ContentHeight()))
+}
+
+// Open shows the list of choices, with the selected Choice highlighted.
+func (s *SelectSpec) Open() {
+	if !s.IsOpen() {
+		s.open(s.choices, s.selectedIndex())
+		s.Invalidate()
+	}
+}
+
+// Reconcile keeps the list open, with the same entry highlighted, if it was
+// open in the previous tree.
+func (s *SelectSpec) Reconcile(previous spec.ReadWriter) {
+	if previous, ok := previous.(*SelectSpec); ok {
+		if previous.IsOpen() {
+			s.open(s.choices, previous.highlighted)
+		}
+		s.isEnterHandled = previous.isEnterHandled
+		s.sinceTyped = previous.sinceTyped
+		s.typed = previous.typed
+	}
+}
+
+// Select selects the Choice with the provided value and emits Changed with
+// the value if the selection has changed.
+func (s *SelectSpec) Select(value string) {
+	if s.selected == value {
+		return
+	}
+	s.selected = value
+	s.updateText()
+	s.Invalidate()
+	s.Emit(events.New(events.Changed, s, value))
+}
+
+// Value returns the value of the selected Choice, or an empty string if
+// none is selected.
+func (s *SelectSpec) Value() interface{} {
+	return s.selected
+}
+
+// isFormField returns true, so that a Form collects the selected value.
+func (s *SelectSpec) isFormField() bool {
+	return true
+}
+
+// choose selects the listed Choice at index and closes the list.
+func (s *SelectSpec) choose(index int) {
+	if index >= 0 && index < len(s.listed) {
+		s.Select(s.listed[index].Value)
+	}
+	s.Close()
+}
+
+// selectedIndex returns the index of the selected Choice, or -1.
+func (s *SelectSpec) selectedIndex() int {
+	for index, choice := range s.choices {
+		if choice.Value == s.selected {
+			return index
+		}
+	}
+	return -1
+}
+
+// updateText shows the Label of the selected Choice, or the prompt if none
+// is selected.
+func (s *SelectSpec) updateText() {
+	if index := s.selectedIndex(); index >= 0 {
+		s.SetText(s.choices[index].Label)
+		return
+	}
+	s.SetText(s.prompt)
+}
+
+// Select is a control that shows the selected Choice and opens a list of
+// the choices above all other content when it is clicked, or when Down, Up
+// or Space is pressed. Typing the start of a Label moves to that Choice.
+// The list is closed by choosing a Choice, pressing Escape or clicking
+// outside of it. Select emits Changed with the value of the selected
+// Choice, which is also its Value for a Form. The configured Text is shown
+// until a Choice is selected.
+var Select = func(options ...spec.Option) spec.ReadWriter {
+	instance := &SelectSpec{}
+
+	var clickedHandler = func(e events.Event) {
+		payload, ok := e.Payload().(*input.MouseEventPayload)
+		if !ok || e.Target() != instance || isDisabled(instance) {
+			return
+		}
+		if instance.IsOpen() && spec.ContainsCoordinate(instance.popup, payload.X, payload.Y) {
+			if index := instance.indexAt(payload.X, payload.Y); index >= 0 {
+				instance.choose(index)
+			}
+			return
+		}
+		if instance.IsOpen() {
+			instance.Close()
+		} else {
+			instance.Open()
+		}
+	}
+
+	var movedHandler = func(e events.Event) {
+		payload, ok := e.Payload().(*input.MouseEventPayload)
+		if !ok || e.Target() != instance {
+			return
+		}
+		if index := instance.indexAt(payload.X, payload.Y); index >= 0 && index != instance.highlighted {
+			instance.highlight(index)
+			instance.Invalidate()
+		}
+	}
+
+	var keyPressedHandler = func(e events.Event) {
+		if e.Target() != instance || isDisabled(instance) {
+			return
+		}
+		key := e.Payload()
+		if key == input.KeySpace && instance.isTyping() {
+			// Space is part of the typed characters.
+			return
+		}
+		if !instance.IsOpen() {
+			switch key {
+			case input.KeyDown, input.KeyUp, input.KeySpace:
+				instance.Open()
+			}
+			return
+		}
+		switch key {
+		case input.KeyDown:
+			instance.moveHighlight(1)
+		case input.KeyUp:
+			instance.moveHighlight(-1)
+		case input.KeyHome:
+			instance.highlight(0)
+		case input.KeyEnd:
+			instance.highlight(len(instance.listed) - 1)
+		case input.KeyEnter:
+			// Keep the release of Enter from submitting a Form.
+			instance.isEnterHandled = true
+			instance.choose(instance.highlighted)
+		case input.KeySpace:
+			instance.choose(instance.highlighted)
+		case input.KeyEscape:
+			instance.Close()
+		}
+		instance.Invalidate()
+	}
+
+	var enterKeyReleasedHandler = func(e events.Event) {
+		if instance.isEnterHandled {
+			instance.isEnterHandled = false
+			e.Cancel()
+		}
+	}
+
+	var frameEnteredHandler = func(e events.Event) {
+		payload, ok := e.Payload().(events.FramePayload)
+		if ok && instance.typed != "" {
+			instance.sinceTyped += payload.Delta
+		}
+	}
+
+	var charEnteredHandler = func(e events.Event) {
+		char, ok := e.Payload().(string)
+		if !ok || e.Target() != instance || isDisabled(instance) {
+			return
+		}
+		if char == " " && !instance.isTyping() {
+			return
+		}
+		if !instance.IsOpen() {
+			instance.listed = instance.choices
+			if index := instance.typeAhead(char, instance.selectedIndex()); index >= 0 {
+				instance.Select(instance.choices[index].Value)
+			}
+			return
+		}
+		if index := instance.typeAhead(char, instance.highlighted); index >= 0 {
+			instance.highlight(index)
+			instance.Invalidate()
+		}
+	}
+
+	onFocusStates(instance)
+	instance.PushUnsub(instance.On(events.Blurred, func(e events.Event) { instance.Close() }))
+	instance.PushUnsub(instance.On(events.CharEntered, charEnteredHandler))
+	instance.PushUnsub(instance.On(events.Clicked, clickedHandler))
+	instance.PushUnsub(instance.On(events.EnterKeyReleased, enterKeyReleasedHandler))
+	instance.PushUnsub(instance.On(events.FrameEntered, frameEnteredHandler))
+	instance.PushUnsub(instance.On(events.KeyPressed, keyPressedHandler))
+	instance.PushUnsub(instance.On(events.Moved, movedHandler))
+	instance.SetBgColor(0xfefefeff)
+	instance.SetHAlign(spec.AlignLeft)
+	instance.SetIsFocusable(true)
+	instance.SetIsMeasured(true)
+	instance.SetSpecName("Select")
+	instance.SetStrokeSize(1)
+	instance.SetView(views.SelectView)
+	opts.OnState("active", opts.StrokeColor(0x666666ff))(instance)
+	opts.OnState("focused", opts.StrokeColor(0x44d9e6ff))(instance)
+
+	spec.Apply(instance, options...)
+	instance.prompt = instance.Text()
+	instance.updateText()
+	instance.addPopup(instance)
+	return instance
+}
+
+// Choices Option that only works with Select and ComboBox instances. Adds a
+// Choice for each value, which is also its Label.
+func Choices(values ...string) spec.Option {
+	return func(d spec.ReadWriter) {
+		list := d.(dropDownProvider).dropDownList()
+		for _, value := range values {
+			list.choices = append(list.choices, Choice{Label: value, Value: value})
+		}
+	}
+}
+
+// LabeledChoice Option that only works with Select and ComboBox instances.
+// Adds a Choice that draws label and reports value.
+func LabeledChoice(value, label string) spec.Option {
+	return func(d spec.ReadWriter) {
+		list := d.(dropDownProvider).dropDownList()
+		list.choices = append(list.choices, Choice{Label: label, Value: value})
+	}
+}
@@ -0,0 +1,388 @@
+package ctrl_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/waybeams/assert"
+	"github.com/waybeams/waybeams/pkg/ctrl"
+	"github.com/waybeams/waybeams/pkg/env/fake"
+	"github.com/waybeams/waybeams/pkg/events"
+	"github.com/waybeams/waybeams/pkg/input"
+	"github.com/waybeams/waybeams/pkg/layout"
+	"github.com/waybeams/waybeams/pkg/opts"
+	"github.com/waybeams/waybeams/pkg/spec"
+)
+
+// gestures drives a Controller with a fake GestureSource, and lays the tree
+// out again after each gesture like the Scheduler would.
+type gestures struct {
+	controller *input.Controller
+	root       spec.ReadWriter
+	source     *fake.FakeGestureSource
+	surface    spec.Surface
+}
+
+func (g *gestures) click(x, y float64) {
+	g.move(x, y)
+	g.source.MouseCallback(input.MouseButton1, input.Press, 0)
+	g.source.MouseCallback(input.MouseButton1, input.Release, 0)
+	layout.Layout(g.root, g.surface)
+}
+
+func (g *gestures) key(key input.Key) {
+	g.source.KeyCallback(key, 0, input.Press, 0)
+	g.source.KeyCallback(key, 0, input.Release, 0)
+	layout.Layout(g.root, g.surface)
+}
+
+func (g *gestures) move(x, y float64) {
+	g.source.SetCursorPos(x, y)
+	g.controller.Update(g.root)
+}
+
+func (g *gestures) typeText(value string) {
+	for _, char := range value {
+		g.source.CharCallback(char)
+	}
+	layout.Layout(g.root, g.surface)
+}
+
+func newGestures(root spec.ReadWriter) *gestures {
+	source := fake.NewFakeGestureSource()
+	g := &gestures{
+		controller: input.New(source),
+		root:       root,
+		source:     source,
+		surface:    fake.NewSurface(),
+	}
+	layout.Layout(root, g.surface)
+	return g
+}
+
+func TestSelect(t *testing.T) {
+	createSelect := func(options ...spec.Option) *ctrl.SelectSpec {
+		return ctrl.Select(append([]spec.Option{
+			opts.Key("city"),
+			ctrl.Choices("Berlin", "Boston"),
+			ctrl.LabeledChoice("chi", "Chicago"),
+		}, options...)...).(*ctrl.SelectSpec)
+	}
+
+	// createTree places a Button below the Select, where the open list
+	// covers it.
+	createTree := func(options ...spec.Option) (spec.ReadWriter, *ctrl.SelectSpec) {
+		instance := createSelect(options...)
+		root := ctrl.VBox(
+			opts.HAlign(spec.AlignLeft),
+			opts.Width(400),
+			opts.Height(400),
+			opts.Child(instance),
+			opts.Child(ctrl.Button(opts.Key("other"), opts.Text("Other"))),
+		)
+		return root, instance
+	}
+
+	t.Run("Defaults", func(t *testing.T) {
+		instance := createSelect(opts.Text("Choose a city"))
+		assert.Equal(instance.SpecName(), "Select")
+		assert.True(instance.IsFocusable())
+		assert.Equal(len(instance.Choices()), 3)
+		assert.Equal(instance.Choices()[2], ctrl.Choice{Label: "Chicago", Value: "chi"})
+		assert.Equal(instance.Value(), "")
+		assert.Equal(instance.Text(), "Choose a city")
+		assert.False(instance.IsOpen())
+	})
+
+	t.Run("Selected", func(t *testing.T) {
+		instance := createSelect(ctrl.Selected("chi"))
+		assert.Equal(instance.Value(), "chi")
+		assert.Equal(instance.Text(), "Chicago")
+	})
+
+	t.Run("Fits the longest choice and the chevron", func(t *testing.T) {
+		instance := createSelect(ctrl.Selected("Boston"))
+		layout.Layout(instance, fake.NewSurface())
+		longest := ctrl.Label(opts.Text("Chicago"))
+		layout.Layout(longest, fake.NewSurface())
+		// The chevron is half the FontSize, plus a gap of the same width.
+		assert.Equal(instance.Width(), longest.Width()+24)
+		x, _, width, _ := instance.ChevronRect()
+		assert.Equal(width, 12.0)
+		assert.Equal(x, instance.Width()-12)
+	})
+
+	t.Run("Clicking opens a list above other content", func(t *testing.T) {
+		var changes []string
+		root, instance := createTree(opts.On(events.Changed, events.StringPayload(func(value string) {
+			changes = append(changes, value)
+		})))
+		g := newGestures(root)
+		g.click(10, 10)
+		assert.True(instance.IsOpen())
+		assert.Equal(instance.Highlighted(), -1)
+
+		popup := spec.FirstByKey(instance, ctrl.PopupKey)
+		assert.Equal(popup.ChildCount(), 3)
+		assert.Equal(popup.Y(), instance.Height())
+		assert.Equal(popup.Width(), instance.Width())
+		assert.Equal(root.ChildAt(1).Y(), instance.Height(), "The list is not in the layout")
+
+		// The second choice covers the Button.
+		_, y := spec.LocalToGlobal(popup.ChildAt(1), 0, 0)
+		g.move(10, y+5)
+		assert.Equal(instance.Highlighted(), 1)
+		g.click(10, y+5)
+		assert.False(instance.IsOpen())
+		assert.Equal(instance.Value(), "Boston")
+		assert.Equal(instance.Text(), "Boston")
+		assert.Equal(changes, []string{"Boston"})
+	})
+
+	t.Run("Closes when clicking outside or on itself", func(t *testing.T) {
+		root, instance := createTree()
+		g := newGestures(root)
+		g.click(10, 10)
+		assert.True(instance.IsOpen())
+		g.click(10, 10)
+		assert.False(instance.IsOpen())
+
+		g.click(10, 10)
+		g.click(300, 300)
+		assert.False(instance.IsOpen())
+		assert.Equal(instance.Value(), "")
+	})
+
+	t.Run("Keyboard navigation", func(t *testing.T) {
+		submitted := 0
+		instance := createSelect()
+		root := ctrl.Form(
+			opts.HAlign(spec.AlignLeft),
+			opts.Width(400),
+			opts.Height(400),
+			opts.Child(instance),
+			opts.On(events.Submitted, func(e events.Event) {
+				submitted++
+			}),
+		)
+		g := newGestures(root)
+		g.click(10, 10)
+		g.key(input.KeyEscape)
+		assert.False(instance.IsOpen())
+
+		g.key(input.KeyDown)
+		assert.True(instance.IsOpen())
+		g.key(input.KeyDown)
+		g.key(input.KeyDown)
+		assert.Equal(instance.Highlighted(), 1)
+		g.key(input.KeyEnd)
+		g.key(input.KeyDown)
+		assert.Equal(instance.Highlighted(), 2)
+		g.key(input.KeyUp)
+		g.key(input.KeyEnter)
+		assert.False(instance.IsOpen())
+		assert.Equal(instance.Value(), "Boston")
+		assert.Equal(submitted, 0, "Choosing with Enter does not submit the Form")
+
+		g.key(input.KeyEnter)
+		assert.Equal(submitted, 1)
+
+		g.key(input.KeySpace)
+		assert.Equal(instance.Highlighted(), 1)
+		g.key(input.KeyHome)
+		g.key(input.KeySpace)
+		assert.Equal(instance.Value(), "Berlin")
+	})
+
+	t.Run("Type-ahead", func(t *testing.T) {
+		root, instance := createTree()
+		g := newGestures(root)
+		g.click(10, 10)
+		g.key(input.KeyEscape)
+
+		g.typeText("b")
+		assert.Equal(instance.Value(), "Berlin")
+		g.typeText("b")
+		assert.Equal(instance.Value(), "Boston", "Repeated characters cycle")
+		g.typeText("c")
+		assert.Equal(instance.Value(), "Boston", "No choice starts with bbc")
+
+		g.key(input.KeyDown)
+		assert.True(instance.IsOpen())
+		g.typeText("bo")
+		assert.Equal(instance.Highlighted(), 1)
+		assert.Equal(instance.Value(), "Boston", "The open list only moves the highlight")
+		g.typeText("x")
+		assert.Equal(instance.Highlighted(), 1)
+	})
+
+	t.Run("Type-ahead forgets characters after TypeAheadTimeout", func(t *testing.T) {
+		value := ""
+		driver := fake.NewDriver(func() spec.ReadWriter {
+			return ctrl.VBox(opts.Child(createSelect(
+				ctrl.Selected(value),
+				opts.On(events.Changed, func(e events.Event) {
+					value = e.Payload().(string)
+				}),
+			)))
+		})
+		defer driver.Close()
+		driver.Click("city")
+		driver.Press(input.KeyEscape)
+
+		driver.Type("b")
+		assert.Equal(value, "Berlin")
+		driver.Type("c")
+		assert.Equal(value, "Berlin", "No choice starts with bc")
+
+		perFrame := time.Second / time.Duration(driver.Window().FrameRate())
+		driver.AdvanceFrames(int(ctrl.TypeAheadTimeout/perFrame) + 1)
+		driver.Type("c")
+		assert.Equal(value, "chi")
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		root, instance := createTree(opts.IsDisabled(true))
+		g := newGestures(root)
+		g.click(10, 10)
+		assert.False(instance.IsOpen())
+		g.key(input.KeyDown)
+		assert.False(instance.IsOpen())
+	})
+
+	t.Run("Stays open when the tree is re-created", func(t *testing.T) {
+		previous, _ := createTree()
+		g := newGestures(previous)
+		g.click(10, 10)
+		g.key(input.KeyDown)
+
+		root, instance := createTree()
+		spec.Reconcile(previous, root)
+		g.root = root
+		layout.Layout(root, g.surface)
+		g.controller.Update(root)
+		assert.True(instance.IsOpen())
+		assert.Equal(instance.Highlighted(), 0)
+
+		g.key(input.KeyDown)
+		assert.Equal(instance.Highlighted(), 1, "Keys reach the new tree")
+		g.key(input.KeyEnter)
+		assert.Equal(instance.Value(), "Boston")
+	})
+
+	t.Run("Draws the list after the rest of the tree", func(t *testing.T) {
+		root, _ := createTree()
+		g := newGestures(root)
+		g.click(10, 10)
+
+		surface := fake.NewSurface()
+		layout.Draw(root, surface)
+		var texts []string
+		for _, command := range surface.CommandsNamed("Text") {
+			texts = append(texts, command.Args[2].(string))
+		}
+		assert.Equal(texts, []string{"Other", "Berlin", "Boston", "Chicago"})
+	})
+}
+
+func TestComboBox(t *testing.T) {
+	createComboBox := func(options ...spec.Option) *ctrl.ComboBoxSpec {
+		return ctrl.ComboBox(append([]spec.Option{
+			opts.Key("city"),
+			opts.Width(200),
+			ctrl.Choices("Berlin", "Boston"),
+			ctrl.LabeledChoice("chi", "Chicago"),
+		}, options...)...).(*ctrl.ComboBoxSpec)
+	}
+
+	createTree := func(options ...spec.Option) (spec.ReadWriter, *ctrl.ComboBoxSpec) {
+		instance := createComboBox(options...)
+		root := ctrl.VBox(
+			opts.HAlign(spec.AlignLeft),
+			opts.Width(400),
+			opts.Height(400),
+			opts.Child(instance),
+			opts.Child(ctrl.Button(opts.Key("other"), opts.Text("Other"))),
+		)
+		return root, instance
+	}
+
+	t.Run("Defaults", func(t *testing.T) {
+		_, instance := createTree(ctrl.Placeholder("City"))
+		assert.Equal(instance.SpecName(), "ComboBox")
+		assert.True(instance.IsTextInput())
+		assert.Equal(instance.Value(), "")
+		assert.False(instance.IsOpen())
+		assert.NotNil(spec.FirstByKey(instance, ctrl.PlaceholderKey))
+	})
+
+	t.Run("Typing filters the list", func(t *testing.T) {
+		var changes []string
+		root, instance := createTree(opts.On(events.Changed, events.StringPayload(func(value string) {
+			changes = append(changes, value)
+		})))
+		g := newGestures(root)
+		g.click(10, 10)
+		assert.False(instance.IsOpen())
+
+		g.typeText("o")
+		assert.True(instance.IsOpen())
+		assert.Equal(len(instance.Listed()), 2)
+		g.typeText("s")
+		assert.Equal(instance.Listed(), []ctrl.Choice{{Label: "Boston", Value: "Boston"}})
+		g.typeText("x")
+		assert.False(instance.IsOpen())
+		assert.Equal(instance.Value(), "osx", "Any text may be entered")
+		assert.Equal(changes, []string{"o", "os", "osx"})
+	})
+
+	t.Run("Choosing replaces the text", func(t *testing.T) {
+		submitted := 0
+		instance := createComboBox()
+		root := ctrl.Form(
+			opts.HAlign(spec.AlignLeft),
+			opts.Width(400),
+			opts.Height(400),
+			opts.Child(instance),
+			opts.On(events.Submitted, func(e events.Event) {
+				submitted++
+			}),
+		)
+		g := newGestures(root)
+		g.click(10, 10)
+		g.typeText("C")
+		assert.Equal(instance.Highlighted(), -1)
+		g.key(input.KeyDown)
+		g.key(input.KeyEnter)
+		assert.False(instance.IsOpen())
+		assert.Equal(instance.Text(), "Chicago")
+		assert.Equal(instance.Caret(), len("Chicago"))
+		assert.Equal(instance.Value(), "chi")
+		assert.Equal(submitted, 0)
+		g.key(input.KeyEnter)
+		assert.Equal(submitted, 1)
+	})
+
+	t.Run("Clicking a choice or the chevron", func(t *testing.T) {
+		root, instance := createTree()
+		g := newGestures(root)
+		g.click(10, 10)
+		assert.False(instance.IsOpen(), "Clicking the text only focuses")
+
+		x, y, _, _ := instance.ChevronRect()
+		g.click(x+2, y+2)
+		assert.True(instance.IsOpen())
+		popup := spec.FirstByKey(instance, ctrl.PopupKey)
+		_, itemY := spec.LocalToGlobal(popup.ChildAt(1), 0, 0)
+		g.click(10, itemY+5)
+		assert.Equal(instance.Text(), "Boston")
+		assert.False(instance.IsOpen())
+
+		g.click(x+2, y+2)
+		g.key(input.KeyEscape)
+		assert.False(instance.IsOpen())
+		g.click(x+2, y+2)
+		g.click(300, 300)
+		assert.False(instance.IsOpen())
+	})
+}
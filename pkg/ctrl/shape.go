@@ -0,0 +1,86 @@
+package ctrl
+
+import (
+	"github.com/waybeams/waybeams/pkg/spec"
+	"github.com/waybeams/waybeams/pkg/vector"
+	"github.com/waybeams/waybeams/pkg/views"
+)
+
+// ShapeSpec draws a vector Path, which may be built in code or parsed from
+// SVG path data.
+type ShapeSpec struct {
+	spec.Spec
+
+	path      *vector.Path
+	pathError error
+	viewBox   [4]float64
+}
+
+func (s *ShapeSpec) Path() *vector.Path {
+	return s.path
+}
+
+// PathError returns the error (if any) that was encountered while parsing
+// the path data. The Path will contain every command up to the error.
+func (s *ShapeSpec) PathError() error {
+	return s.pathError
+}
+
+// ViewBox returns the region of Path coordinates that is scaled to fit the
+// Shape bounds. If no ViewBox was configured, the Path bounds are used.
+func (s *ShapeSpec) ViewBox() (x, y, width, height float64) {
+	if s.viewBox[2] > 0 && s.viewBox[3] > 0 {
+		return s.viewBox[0], s.viewBox[1], s.viewBox[2], s.viewBox[3]
+	}
+	if s.path == nil {
+		return 0, 0, 0, 0
+	}
+	return s.path.Bounds()
+}
+
+// Measure uses the ViewBox size for any dimension that was not configured.
+func (s *ShapeSpec) Measure(surface spec.Surface) {
+	_, _, width, height := s.ViewBox()
+	if s.Width() == 0 && s.PrefWidth() == 0 {
+		s.SetContentWidth(width)
+	}
+	if s.Height() == 0 && s.PrefHeight() == 0 {
+		s.SetContentHeight(height)
+	}
+}
+
+// Shape is a control that draws a vector Path using the BgColor (or
+// Gradient) as the fill and StrokeColor and StrokeSize as the outline.
+func Shape(options ...spec.Option) *ShapeSpec {
+	shape := &ShapeSpec{}
+	shape.SetSpecName("Shape")
+	shape.SetIsMeasured(true)
+	shape.SetView(views.ShapeView)
+	spec.Apply(shape, options...)
+	return shape
+}
+
+// PathData Option that only works with ShapeSpec instances. The provided SVG
+// path data (e.g., "M0 0 L10 10 Z") is parsed into the Shape Path.
+func PathData(data string) spec.Option {
+	return func(d spec.ReadWriter) {
+		shape := d.(*ShapeSpec)
+		shape.path, shape.pathError = vector.Parse(data)
+	}
+}
+
+// ShapePath Option that only works with ShapeSpec instances.
+func ShapePath(path *vector.Path) spec.Option {
+	return func(d spec.ReadWriter) {
+		d.(*ShapeSpec).path = path
+	}
+}
+
+// ViewBox Option that only works with ShapeSpec instances. It declares the
+// region of Path coordinates that should be scaled to fit the Shape, like the
+// SVG viewBox attribute.
+func ViewBox(x, y, width, height float64) spec.Option {
+	return func(d spec.ReadWriter) {
+		d.(*ShapeSpec).viewBox = [4]float64{x, y, width, height}
+	}
+}
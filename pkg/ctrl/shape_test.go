@@ -0,0 +1,62 @@
+package ctrl_test
+
+import (
+	"testing"
+
+	"github.com/waybeams/assert"
+	"github.com/waybeams/waybeams/pkg/ctrl"
+	"github.com/waybeams/waybeams/pkg/env/fake"
+	"github.com/waybeams/waybeams/pkg/layout"
+	"github.com/waybeams/waybeams/pkg/opts"
+)
+
+func TestShape(t *testing.T) {
+	t.Run("Measures from the view box", func(t *testing.T) {
+		shape := ctrl.Shape(ctrl.PathData("M0 0 L24 0 L24 24 Z"))
+		layout.Layout(shape, fake.NewSurface())
+		assert.Equal(shape.Width(), 24.0)
+		assert.Equal(shape.Height(), 24.0)
+	})
+
+	t.Run("Keeps configured size", func(t *testing.T) {
+		shape := ctrl.Shape(opts.Width(12), opts.Height(12), ctrl.PathData("M0 0 L24 24"))
+		layout.Layout(shape, fake.NewSurface())
+		assert.Equal(shape.Width(), 12.0)
+	})
+
+	t.Run("Scales path to fit bounds", func(t *testing.T) {
+		shape := ctrl.Shape(
+			opts.Width(48),
+			opts.Height(96),
+			opts.BgColor(0xff0000ff),
+			ctrl.ViewBox(0, 0, 24, 24),
+			ctrl.PathData("M0 0 L24 24"),
+		)
+		s := fake.NewSurface()
+		layout.Layout(shape, s)
+		layout.Draw(shape, s)
+
+		commands := s.GetCommands()
+		var moveTo, lineTo []interface{}
+		isFilled := false
+		for _, command := range commands {
+			switch command.Name {
+			case "MoveTo":
+				moveTo = command.Args
+			case "LineTo":
+				lineTo = command.Args
+			case "Fill":
+				isFilled = true
+			}
+		}
+		assert.Equal(moveTo, []interface{}{0.0, 24.0}, "Centered vertically")
+		assert.Equal(lineTo, []interface{}{48.0, 72.0})
+		assert.True(isFilled)
+	})
+
+	t.Run("Exposes parse errors", func(t *testing.T) {
+		shape := ctrl.Shape(ctrl.PathData("M0 0 L"))
+		assert.NotNil(shape.PathError())
+		assert.Equal(len(shape.Path().Commands()), 1)
+	})
+}
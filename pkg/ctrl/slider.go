@@ -0,0 +1,340 @@
+package ctrl
+
+import (
+	"math"
+
+	"github.com/waybeams/waybeams/pkg/events"
+	"github.com/waybeams/waybeams/pkg/input"
+	"github.com/waybeams/waybeams/pkg/opts"
+	"github.com/waybeams/waybeams/pkg/spec"
+	"github.com/waybeams/waybeams/pkg/views"
+)
+
+// TrackThickness is the height of a horizontal Slider track, or the width
+// of a vertical one.
+const TrackThickness = 4.0
+
+// RangeSpec is a control with a numeric value between a minimum and a
+// maximum, like a Slider or a ProgressBar.
+type RangeSpec struct {
+	spec.Spec
+
+	isVertical bool
+	max        float64
+	min        float64
+	step       float64
+	value      float64
+}
+
+// Fraction returns the position of the value between the minimum (0) and
+// the maximum (1).
+func (r *RangeSpec) Fraction() float64 {
+	if r.max <= r.min {
+		return 0
+	}
+	return (r.value - r.min) / (r.max - r.min)
+}
+
+// IsVertical returns true if the minimum is at the bottom and the maximum
+// at the top, rather than left to right.
+func (r *RangeSpec) IsVertical() bool {
+	return r.isVertical
+}
+
+// Max returns the largest value.
+func (r *RangeSpec) Max() float64 {
+	return r.max
+}
+
+// Min returns the smallest value.
+func (r *RangeSpec) Min() float64 {
+	return r.min
+}
+
+// SetValue changes the value, which is limited to the range and rounded to
+// the nearest Step, and emits Changed with the new value if it has
+// changed.
+func (r *RangeSpec) SetValue(value float64) {
+	value = r.constrain(value)
+	if value == r.value {
+		return
+	}
+	r.value = value
+	r.Invalidate()
+	r.Emit(events.New(events.Changed, r, value))
+}
+
+// Step returns the interval between values, or zero if the value is
+// continuous.
+func (r *RangeSpec) Step() float64 {
+	return r.step
+}
+
+// Value returns the value as a float64.
+func (r *RangeSpec) Value() interface{} {
+	return r.value
+}
+
+// constrain limits value to the range and rounds it to the nearest step.
+func (r *RangeSpec) constrain(value float64) float64 {
+	if r.step > 0 {
+		value = r.min + math.Round((value-r.min)/r.step)*r.step
+	}
+	return math.Max(r.min, math.Min(r.max, value))
+}
+
+// innerRect returns the bounds within the padding, in the same coordinate
+// space as X and Y.
+func (r *RangeSpec) innerRect() (x, y, width, height float64) {
+	return r.X() + r.PaddingLeft(), r.Y() + r.PaddingTop(), r.Width() - r.HorizontalPadding(), r.Height() - r.VerticalPadding()
+}
+
+func (r *RangeSpec) rangeSpec() *RangeSpec {
+	return r
+}
+
+// trackRect returns a bar of the provided thickness, centered within the
+// padding along the length of the control.
+func (r *RangeSpec) trackRect(thickness float64) (x, y, width, height float64) {
+	x, y, width, height = r.innerRect()
+	if r.isVertical {
+		return x + (width-thickness)/2, y, thickness, height
+	}
+	return x, y + (height-thickness)/2, width, thickness
+}
+
+// rangeProvider is satisfied by any type that embeds RangeSpec, so that
+// the range Options work with Sliders and ProgressBars.
+type rangeProvider interface {
+	rangeSpec() *RangeSpec
+}
+
+// SliderSpec is a RangeSpec whose value is changed by dragging its thumb,
+// clicking its track or pressing arrow keys.
+type SliderSpec struct {
+	RangeSpec
+
+	grabOffset float64
+	isDragging bool
+}
+
+// FillRect returns the part of the track between the minimum and the
+// thumb, in the same coordinate space as X and Y.
+func (s *SliderSpec) FillRect() (x, y, width, height float64) {
+	x, y, width, height = s.TrackRect()
+	thumbX, thumbY, size, _ := s.ThumbRect()
+	if s.isVertical {
+		center := thumbY + size/2
+		return x, center, width, y + height - center
+	}
+	return x, y, thumbX + size/2 - x, height
+}
+
+// IsDragging returns true while the thumb is being dragged.
+func (s *SliderSpec) IsDragging() bool {
+	return s.isDragging
+}
+
+func (s *SliderSpec) Measure(surface spec.Surface) {
+	size := s.thumbSize()
+	if s.isVertical {
+		s.SetContentWidth(size)
+		s.SetContentHeight(size * 5)
+		return
+	}
+	s.SetContentWidth(size * 5)
+	s.SetContentHeight(size)
+}
+
+// Reconcile carries a drag in progress forward from the previous tree, so
+// that the thumb keeps following the cursor when the tree is re-created.
+func (s *SliderSpec) Reconcile(previous spec.ReadWriter) {
+	if slider, ok := previous.(*SliderSpec); ok {
+		s.grabOffset = slider.grabOffset
+		s.isDragging = slider.isDragging
+	}
+}
+
+// ThumbRect returns the bounds of the thumb, in the same coordinate space
+// as X and Y, as of the last layout.
+func (s *SliderSpec) ThumbRect() (x, y, width, height float64) {
+	x, y, width, height = s.innerRect()
+	size := math.Min(s.thumbSize(), math.Min(width, height))
+	if s.isVertical {
+		travel := height - size
+		return x + (width-size)/2, y + (1-s.Fraction())*travel, size, size
+	}
+	travel := width - size
+	return x + s.Fraction()*travel, y + (height-size)/2, size, size
+}
+
+// TrackRect returns the bounds of the track, in the same coordinate space
+// as X and Y.
+func (s *SliderSpec) TrackRect() (x, y, width, height float64) {
+	return s.trackRect(TrackThickness)
+}
+
+// isFormField returns true, so that a Form collects the value.
+func (s *SliderSpec) isFormField() bool {
+	return true
+}
+
+// increment returns the amount that an arrow key changes the value by,
+// which is a Step, or a hundredth of the range if there is no Step.
+func (s *SliderSpec) increment() float64 {
+	if s.step > 0 {
+		return s.step
+	}
+	return (s.max - s.min) / 100
+}
+
+// press starts a drag at the provided global cursor position. The value
+// jumps to a press on the track, but not to a press on the thumb, which
+// only moves once it is dragged.
+func (s *SliderSpec) press(globalX, globalY float64) {
+	x, y := s.toParent(globalX, globalY)
+	thumbX, thumbY, size, _ := s.ThumbRect()
+	s.grabOffset = 0
+	if x >= thumbX && x <= thumbX+size && y >= thumbY && y <= thumbY+size {
+		if s.isVertical {
+			s.grabOffset = y - (thumbY + size/2)
+		} else {
+			s.grabOffset = x - (thumbX + size/2)
+		}
+	} else {
+		s.SetValue(s.valueAt(x, y))
+	}
+	s.isDragging = true
+}
+
+// thumbSize returns the diameter of the thumb, which scales with the
+// FontSize.
+func (s *SliderSpec) thumbSize() float64 {
+	return math.Round(s.FontSize() * 0.8)
+}
+
+// toParent converts a global cursor position into the coordinate space of
+// X and Y.
+func (s *SliderSpec) toParent(globalX, globalY float64) (x, y float64) {
+	originX, originY := spec.LocalToGlobal(s, 0, 0)
+	return globalX - originX + s.X(), globalY - originY + s.Y()
+}
+
+// valueAt returns the value that places the center of the thumb, less the
+// grab offset, at the provided position.
+func (s *SliderSpec) valueAt(x, y float64) float64 {
+	innerX, innerY, width, height := s.innerRect()
+	size := math.Min(s.thumbSize(), math.Min(width, height))
+	var fraction float64
+	if s.isVertical {
+		travel := height - size
+		if travel <= 0 {
+			return s.value
+		}
+		fraction = 1 - (y-s.grabOffset-innerY-size/2)/travel
+	} else {
+		travel := width - size
+		if travel <= 0 {
+			return s.value
+		}
+		fraction = (x - s.grabOffset - innerX - size/2) / travel
+	}
+	return s.min + fraction*(s.max-s.min)
+}
+
+// Slider is a control that selects a value between a minimum and a
+// maximum, which defaults to 0 to 100 in steps of 1. Slider emits Changed
+// with its new value, which is also its Value for a Form.
+var Slider = func(options ...spec.Option) spec.ReadWriter {
+	instance := &SliderSpec{}
+	instance.max = 100
+	instance.step = 1
+
+	var pressedHandler = func(e events.Event) {
+		payload, ok := e.Payload().(*input.MouseEventPayload)
+		if ok && e.Target() == instance && !isDisabled(instance) {
+			instance.press(payload.X, payload.Y)
+		}
+	}
+
+	var movedHandler = func(e events.Event) {
+		payload, ok := e.Payload().(*input.MouseEventPayload)
+		if ok && instance.isDragging {
+			instance.SetValue(instance.valueAt(instance.toParent(payload.X, payload.Y)))
+		}
+	}
+
+	var releasedHandler = func(e events.Event) {
+		instance.isDragging = false
+	}
+
+	var keyPressedHandler = func(e events.Event) {
+		if isDisabled(instance) {
+			return
+		}
+		switch e.Payload() {
+		case input.KeyRight, input.KeyUp:
+			instance.SetValue(instance.value + instance.increment())
+		case input.KeyLeft, input.KeyDown:
+			instance.SetValue(instance.value - instance.increment())
+		case input.KeyPageUp:
+			instance.SetValue(instance.value + instance.increment()*10)
+		case input.KeyPageDown:
+			instance.SetValue(instance.value - instance.increment()*10)
+		case input.KeyHome:
+			instance.SetValue(instance.min)
+		case input.KeyEnd:
+			instance.SetValue(instance.max)
+		}
+	}
+
+	onFocusStates(instance)
+	instance.PushUnsub(instance.On(events.DragEnded, releasedHandler))
+	instance.PushUnsub(instance.On(events.KeyPressed, keyPressedHandler))
+	instance.PushUnsub(instance.On(events.Moved, movedHandler))
+	instance.PushUnsub(instance.On(events.Pressed, pressedHandler))
+	instance.PushUnsub(instance.On(events.Released, releasedHandler))
+	instance.SetIsFocusable(true)
+	instance.SetIsMeasured(true)
+	instance.SetLayoutType(spec.StackLayoutType)
+	instance.SetSpecName("Slider")
+	instance.SetView(views.SliderView)
+	opts.OnState("active", opts.StrokeColor(0))(instance)
+	opts.OnState("focused", opts.StrokeColor(0x44d9e6ff), opts.StrokeSize(1))(instance)
+
+	spec.Apply(instance, options...)
+	instance.value = instance.constrain(instance.value)
+	return instance
+}
+
+// Range Option that only works with Slider and ProgressBar instances.
+func Range(min, max float64) spec.Option {
+	return func(d spec.ReadWriter) {
+		r := d.(rangeProvider).rangeSpec()
+		r.min = min
+		r.max = max
+	}
+}
+
+// Step Option that only works with Slider instances. Values are rounded to
+// the nearest multiple of step from the minimum, and zero allows any value.
+func Step(step float64) spec.Option {
+	return func(d spec.ReadWriter) {
+		d.(rangeProvider).rangeSpec().step = step
+	}
+}
+
+// Value Option that only works with Slider and ProgressBar instances.
+func Value(value float64) spec.Option {
+	return func(d spec.ReadWriter) {
+		d.(rangeProvider).rangeSpec().value = value
+	}
+}
+
+// Vertical Option that only works with Slider and ProgressBar instances.
+// The minimum is at the bottom and the maximum at the top.
+func Vertical() spec.Option {
+	return func(d spec.ReadWriter) {
+		d.(rangeProvider).rangeSpec().isVertical = true
+	}
+}
@@ -0,0 +1,226 @@
+package ctrl_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/waybeams/assert"
+	"github.com/waybeams/waybeams/pkg/ctrl"
+	"github.com/waybeams/waybeams/pkg/env/fake"
+	"github.com/waybeams/waybeams/pkg/events"
+	"github.com/waybeams/waybeams/pkg/input"
+	"github.com/waybeams/waybeams/pkg/layout"
+	"github.com/waybeams/waybeams/pkg/opts"
+	"github.com/waybeams/waybeams/pkg/spec"
+	"github.com/waybeams/waybeams/pkg/views"
+)
+
+func TestSlider(t *testing.T) {
+	// createSlider returns a Slider that is 200 pixels wide, 20 pixels below
+	// the top of its parent, with a 20 pixel thumb that travels 180 pixels.
+	createSlider := func(options ...spec.Option) (spec.ReadWriter, *ctrl.SliderSpec) {
+		slider := ctrl.Slider(append([]spec.Option{opts.FontSize(25), opts.FlexWidth(1)}, options...)...)
+		root := ctrl.VBox(
+			opts.Width(200),
+			opts.Height(100),
+			opts.PaddingTop(20),
+			opts.Child(slider),
+		)
+		layout.Layout(root, fake.NewSurface())
+		return root, slider.(*ctrl.SliderSpec)
+	}
+
+	t.Run("Defaults", func(t *testing.T) {
+		_, slider := createSlider()
+		assert.True(slider.IsFocusable())
+		assert.Equal(slider.Min(), 0.0)
+		assert.Equal(slider.Max(), 100.0)
+		assert.Equal(slider.Step(), 1.0)
+		assert.Equal(slider.Value(), 0.0)
+		assert.Equal(slider.Width(), 200)
+		assert.Equal(slider.Height(), 20)
+	})
+
+	t.Run("Constrains values", func(t *testing.T) {
+		var changes []float64
+		_, slider := createSlider(
+			ctrl.Range(-1, 1),
+			ctrl.Step(0.25),
+			ctrl.Value(0.3),
+			opts.On(events.Changed, func(e events.Event) {
+				changes = append(changes, e.Payload().(float64))
+			}),
+		)
+		assert.Equal(slider.Value(), 0.25)
+		slider.SetValue(0.6)
+		slider.SetValue(0.5)
+		slider.SetValue(5)
+		assert.Equal(slider.Value(), 1.0)
+		slider.SetValue(-5)
+		assert.Equal(changes, []float64{0.5, 1.0, -1.0})
+	})
+
+	t.Run("Thumb and fill follow the value", func(t *testing.T) {
+		_, slider := createSlider(ctrl.Value(50))
+		x, y, width, height := slider.ThumbRect()
+		assert.Equal([]float64{x, y, width, height}, []float64{90, 20, 20, 20})
+		x, y, width, height = slider.TrackRect()
+		assert.Equal([]float64{x, y, width, height}, []float64{0, 28, 200, ctrl.TrackThickness})
+		_, _, width, _ = slider.FillRect()
+		assert.Equal(width, 100.0)
+
+		s := fake.NewSurface()
+		layout.Draw(slider, s)
+		assert.Equal(len(s.CommandsNamed("RoundedRect")), 2)
+		assert.Equal(len(s.CommandsNamed("Ellipse")), 1)
+	})
+
+	t.Run("Vertical", func(t *testing.T) {
+		slider := ctrl.Slider(opts.FontSize(25), ctrl.Vertical(), ctrl.Value(25), opts.Height(120)).(*ctrl.SliderSpec)
+		layout.Layout(slider, fake.NewSurface())
+		assert.Equal(slider.Width(), 20)
+		x, y, _, _ := slider.ThumbRect()
+		assert.Equal([]float64{x, y}, []float64{0, 75})
+		_, y, _, height := slider.FillRect()
+		assert.Equal([]float64{y, height}, []float64{85, 35})
+	})
+
+	t.Run("Clicking the track jumps to the value", func(t *testing.T) {
+		root, slider := createSlider()
+		source := fake.NewFakeGestureSource()
+		controller := input.New(source)
+		source.SetCursorPos(55, 30)
+		controller.Update(root)
+		source.PressButton(input.MouseButton1)
+		assert.Equal(slider.Value(), 25.0)
+		assert.True(slider.IsDragging())
+		source.ReleaseButton(input.MouseButton1)
+		assert.False(slider.IsDragging())
+	})
+
+	t.Run("Dragging the thumb", func(t *testing.T) {
+		root, slider := createSlider(ctrl.Value(50))
+		source := fake.NewFakeGestureSource()
+		controller := input.New(source)
+
+		// Pressing the thumb off center does not move it.
+		source.SetCursorPos(95, 30)
+		controller.Update(root)
+		source.PressButton(input.MouseButton1)
+		assert.Equal(slider.Value(), 50.0)
+
+		source.SetCursorPos(140, 30)
+		controller.Update(root)
+		assert.Equal(slider.Value(), 75.0)
+
+		// The thumb follows the cursor beyond the bounds of the Slider.
+		source.SetCursorPos(400, 90)
+		controller.Update(root)
+		assert.Equal(slider.Value(), 100.0)
+		source.ReleaseButton(input.MouseButton1)
+		assert.False(slider.IsDragging())
+
+		source.SetCursorPos(20, 30)
+		controller.Update(root)
+		assert.Equal(slider.Value(), 100.0)
+	})
+
+	t.Run("Ignores the pointer when disabled", func(t *testing.T) {
+		root, slider := createSlider(opts.IsDisabled(true))
+		source := fake.NewFakeGestureSource()
+		controller := input.New(source)
+		source.SetCursorPos(55, 30)
+		controller.Update(root)
+		source.PressButton(input.MouseButton1)
+		assert.Equal(slider.State(), "disabled")
+		assert.Equal(slider.Value(), 0.0)
+		assert.False(slider.IsDragging())
+	})
+
+	t.Run("Arrow keys", func(t *testing.T) {
+		_, slider := createSlider(ctrl.Value(50))
+		press := func(key input.Key) {
+			slider.Emit(events.New(events.KeyPressed, slider, key))
+		}
+		press(input.KeyRight)
+		press(input.KeyUp)
+		assert.Equal(slider.Value(), 52.0)
+		press(input.KeyDown)
+		assert.Equal(slider.Value(), 51.0)
+		press(input.KeyPageDown)
+		assert.Equal(slider.Value(), 41.0)
+		press(input.KeyEnd)
+		assert.Equal(slider.Value(), 100.0)
+		press(input.KeyHome)
+		assert.Equal(slider.Value(), 0.0)
+
+		_, continuous := createSlider(ctrl.Range(0, 1), ctrl.Step(0))
+		continuous.Emit(events.New(events.KeyPressed, continuous, input.KeyRight))
+		assert.Equal(continuous.Value(), 0.01)
+	})
+
+	t.Run("Provides its value to a Form", func(t *testing.T) {
+		form := ctrl.Form(
+			opts.Child(ctrl.Slider(opts.Key("volume"), ctrl.Value(11))),
+		).(*ctrl.FormSpec)
+		assert.Equal(form.Values()["volume"], 11.0)
+	})
+}
+
+func TestProgressBar(t *testing.T) {
+	t.Run("Fills the value", func(t *testing.T) {
+		bar := ctrl.ProgressBar(opts.FontSize(24), opts.Width(200), ctrl.Value(30)).(*ctrl.ProgressBarSpec)
+		layout.Layout(bar, fake.NewSurface())
+		assert.False(bar.IsFocusable())
+		assert.Equal(bar.Height(), 6)
+		x, y, width, height := bar.FillRect()
+		assert.Equal([]float64{x, y, width, height}, []float64{0, 0, 60, 6})
+
+		s := fake.NewSurface()
+		layout.Draw(bar, s)
+		fills := s.CommandsNamed("SetFillColor")
+		assert.Equal(len(fills), 2)
+		assert.Equal(fills[0].Args[0], views.DefaultTrackColor)
+		assert.Equal(fills[1].Args[0], views.DefaultAccentColor)
+	})
+
+	t.Run("Vertical", func(t *testing.T) {
+		bar := ctrl.ProgressBar(ctrl.Vertical(), ctrl.Range(0, 1), ctrl.Value(0.25), opts.Height(100)).(*ctrl.ProgressBarSpec)
+		layout.Layout(bar, fake.NewSurface())
+		x, y, width, height := bar.FillRect()
+		assert.Equal([]float64{x, y, width, height}, []float64{0, 75, 6, 25})
+	})
+
+	t.Run("Indeterminate bar moves on each frame", func(t *testing.T) {
+		bar := ctrl.ProgressBar(ctrl.Indeterminate(true), opts.Width(300)).(*ctrl.ProgressBarSpec)
+		layout.Layout(bar, fake.NewSurface())
+		assert.True(bar.IsIndeterminate())
+		_, _, width, _ := bar.FillRect()
+		assert.Equal(width, 0.0)
+
+		invalidated := 0
+		bar.On(events.Invalidated, func(e events.Event) {
+			invalidated++
+		})
+		enterFrame := func(bar spec.ReadWriter, delta time.Duration) {
+			bar.Emit(events.New(events.FrameEntered, bar, events.FramePayload{Delta: delta}))
+		}
+		enterFrame(bar, ctrl.IndeterminatePeriod/2)
+		assert.Equal(invalidated, 1)
+		x, _, width, _ := bar.FillRect()
+		assert.Equal([]float64{x, width}, []float64{100, 100})
+
+		// The animation continues when the tree is rendered again.
+		next := ctrl.ProgressBar(ctrl.Indeterminate(true), opts.Width(300)).(*ctrl.ProgressBarSpec)
+		layout.Layout(next, fake.NewSurface())
+		spec.Reconcile(bar, next)
+		enterFrame(next, ctrl.IndeterminatePeriod/4)
+		x, _, width, _ = next.FillRect()
+		assert.Equal([]float64{x, width}, []float64{200, 100})
+
+		next.SetIndeterminate(false)
+		enterFrame(next, ctrl.IndeterminatePeriod/4)
+		_, _, width, _ = next.FillRect()
+		assert.Equal(width, 0.0)
+	})
+}
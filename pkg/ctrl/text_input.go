@@ -1,72 +1,609 @@
 package ctrl
 
 import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
 	"github.com/waybeams/waybeams/pkg/events"
+	"github.com/waybeams/waybeams/pkg/input"
 	"github.com/waybeams/waybeams/pkg/opts"
 	"github.com/waybeams/waybeams/pkg/spec"
+	"github.com/waybeams/waybeams/pkg/text"
 	"github.com/waybeams/waybeams/pkg/views"
 )
 
 const PlaceholderKey = "TextInput.Placeholder"
 const TextKey = "TextInput.Text"
 
+// PasswordBullet is drawn in place of each character of a Password input.
+const PasswordBullet = '•'
+
+// Masks for InputMask, where "9" is a digit, "a" is a letter and "*" is a
+// letter or a digit. All other characters are inserted as they are.
+const (
+	DateMask  = "99/99/9999"
+	PhoneMask = "(999) 999-9999"
+)
+
 type TextInputSpec struct {
 	LabelSpec
 
+	allow       func(cluster string) bool
+	caret       int
+	caretX      float64
+	composition input.Composition
+	cursorX     float64
+	isComposing bool
+	isNumeric   bool
+	isPassword  bool
+	isRevealed  bool
+	mask        string
+	maxLength   int
 	placeholder string
+	preeditEndX float64
+	sanitize    func(value string) string
 }
 
 func (t *TextInputSpec) Placeholder() string {
 	return t.placeholder
 }
 
-// TextInput is a control that allows the user to input text.
-var TextInput = func(options ...spec.Option) spec.ReadWriter {
-	input := &TextInputSpec{}
+// CaretRect returns the bounds of the caret (or of the IME cursor while
+// composing), relative to the input, as of the last Measure.
+func (t *TextInputSpec) CaretRect() (x, y, width, height float64) {
+	return t.TextX() - t.X() + t.cursorX, t.PaddingTop(), 1, t.ContentHeight()
+}
+
+// Composition returns the uncommitted IME text and cursor.
+func (t *TextInputSpec) Composition() input.Composition {
+	return t.composition
+}
+
+// DisplayText returns the text as it is drawn, which is a PasswordBullet for
+// each character of a Password input that has not been revealed, and
+// includes uncommitted IME text at the caret.
+func (t *TextInputSpec) DisplayText() string {
+	if t.isHidden() {
+		return strings.Repeat(string(PasswordBullet), graphemeCount(t.Text()))
+	}
+	if preedit := t.preedit(); preedit != "" {
+		caret := t.Caret()
+		return t.Text()[:caret] + preedit + t.Text()[caret:]
+	}
+	return t.Text()
+}
+
+// IsComposing returns true while an IME composition is in progress.
+func (t *TextInputSpec) IsComposing() bool {
+	return t.isComposing
+}
+
+// PreeditBounds returns the horizontal offset from TextX and the width of
+// the uncommitted IME text, as of the last Measure.
+func (t *TextInputSpec) PreeditBounds() (x, width float64, ok bool) {
+	if t.preedit() == "" {
+		return 0, 0, false
+	}
+	return t.caretX, t.preeditEndX - t.caretX, true
+}
+
+// isFormField returns true, so that a Form collects the text, or the Value
+// of a ComboBox.
+func (t *TextInputSpec) isFormField() bool {
+	return true
+}
+
+func (t *TextInputSpec) isHidden() bool {
+	return t.isPassword && !t.isRevealed
+}
+
+// preedit returns the uncommitted IME text, which is never drawn in a
+// hidden Password input.
+func (t *TextInputSpec) preedit() string {
+	if !t.isComposing || t.isHidden() {
+		return ""
+	}
+	return t.composition.Text
+}
+
+// setComposition stores the state of an IME composition.
+func (t *TextInputSpec) setComposition(isComposing bool, composition input.Composition) {
+	t.isComposing = isComposing
+	t.composition = composition
+	t.Invalidate()
+}
+
+// IsPassword returns true if the text is masked when it is drawn.
+func (t *TextInputSpec) IsPassword() bool {
+	return t.isPassword
+}
+
+// IsRevealed returns true if a Password input is drawing its text.
+func (t *TextInputSpec) IsRevealed() bool {
+	return t.isRevealed
+}
+
+// Mask returns the InputMask, or an empty string.
+func (t *TextInputSpec) Mask() string {
+	return t.mask
+}
+
+// MaxLength returns the maximum number of characters (grapheme clusters),
+// or zero if there is no limit.
+func (t *TextInputSpec) MaxLength() int {
+	return t.maxLength
+}
+
+func (t *TextInputSpec) Measure(s spec.Surface) {
+	display := t.DisplayText()
+	t.measure(s, display)
+
+	// Find the offsets of the caret and the IME text, so that they can be
+	// drawn and the IME candidate window can be placed next to them.
+	face := s.Fonts().FaceNameFor(t.FontFace(), t.FontWeight(), t.FontStyle())
+	advance := func(value string) float64 {
+		if value == "" {
+			return 0
+		}
+		_, _, width, _ := s.TextBounds(face, t.FontSize(), value)
+		return width
+	}
+	caret := t.Caret()
+	if t.isHidden() {
+		caret = graphemeCount(t.Text()[:caret]) * utf8.RuneLen(PasswordBullet)
+	}
+	preedit := t.preedit()
+	cursor := caret + t.composition.Cursor
+	if preedit == "" || cursor > caret+len(preedit) {
+		cursor = caret + len(preedit)
+	}
+	t.caretX = advance(display[:caret])
+	t.cursorX = advance(display[:cursor])
+	t.preeditEndX = advance(display[:caret+len(preedit)])
+}
+
+// SetRevealed shows or hides the text of a Password input.
+func (t *TextInputSpec) SetRevealed(revealed bool) {
+	if t.isRevealed != revealed {
+		t.isRevealed = revealed
+		t.Invalidate()
+	}
+}
+
+// ToggleRevealed shows the text of a hidden Password input, or hides it if
+// it is shown.
+func (t *TextInputSpec) ToggleRevealed() {
+	t.SetRevealed(!t.isRevealed)
+}
+
+// Caret returns the byte offset in Text where characters will be inserted,
+// which is the end of the text unless it has been moved.
+func (t *TextInputSpec) Caret() int {
+	if t.caret < 0 || t.caret > len(t.Text()) {
+		return len(t.Text())
+	}
+	return t.caret
+}
+
+// SetCaret moves the insertion point to the provided byte offset. Offsets
+// that are out of range move the caret to the end of the text.
+func (t *TextInputSpec) SetCaret(index int) {
+	t.caret = index
+}
+
+// Reconcile carries the caret, any IME composition and whether a Password is
+// revealed forward from the previous tree, so that editing continues where
+// it left off.
+func (t *TextInputSpec) Reconcile(previous spec.ReadWriter) {
+	if input, ok := previous.(textInputProvider); ok {
+		previous := input.textInputSpec()
+		t.caret = previous.caret
+		t.composition = previous.composition
+		t.isComposing = previous.isComposing
+		t.isRevealed = previous.isRevealed
+	}
+}
+
+// insert sanitizes value, adds each of its characters that the input
+// accepts at the caret and moves the caret after them.
+func (t *TextInputSpec) insert(value string) {
+	value = t.sanitize(value)
+	if t.mask != "" {
+		t.insertMasked(value)
+		return
+	}
+	current, caret := t.Text(), t.Caret()
+	prefix, suffix := current[:caret], current[caret:]
+	count := graphemeCount(current)
+	boundaries := text.Graphemes(value)
+	for i := 1; i < len(boundaries); i++ {
+		if t.maxLength > 0 && count >= t.maxLength {
+			break
+		}
+		cluster := value[boundaries[i-1]:boundaries[i]]
+		if t.accepts(prefix+cluster+suffix, cluster) {
+			prefix += cluster
+			count++
+		}
+	}
+	if prefix+suffix != current {
+		t.setText(prefix+suffix, len(prefix))
+	}
+}
+
+// accepts returns true if cluster may be inserted to create candidate.
+func (t *TextInputSpec) accepts(candidate, cluster string) bool {
+	if t.allow != nil && !t.allow(cluster) {
+		return false
+	}
+	return !t.isNumeric || isNumeric(candidate)
+}
+
+// insertMasked adds the characters of value that fit the InputMask at the
+// caret, and formats the result with the mask.
+func (t *TextInputSpec) insertMasked(value string) {
+	current, caret := t.Text(), t.Caret()
+	before := maskSlots(t.mask, current[:caret])
+	after := maskSlots(t.mask, current)[len(before):]
+	raw := before
+	for _, r := range value {
+		if t.allow == nil || t.allow(string(r)) {
+			raw = append(raw, r)
+		}
+	}
+	prefix := formatMask(t.mask, raw)
+	formatted := formatMask(t.mask, append(raw, after...))
+	if formatted != current {
+		t.setText(formatted, maskOffset(t.mask, formatted, len(maskSlots(t.mask, prefix))))
+	}
+}
+
+// deleteMasked removes the character that fills the slot before (or after)
+// the caret and formats the result with the InputMask, so that literals
+// are never deleted on their own.
+func (t *TextInputSpec) deleteMasked(isBackward bool) {
+	current, caret := t.Text(), t.Caret()
+	index := len(maskSlots(t.mask, current[:caret]))
+	raw := maskSlots(t.mask, current)
+	if isBackward {
+		index--
+	}
+	if index < 0 || index >= len(raw) {
+		return
+	}
+	raw = append(raw[:index], raw[index+1:]...)
+	formatted := formatMask(t.mask, raw)
+	t.setText(formatted, maskOffset(t.mask, formatted, index))
+}
+
+// moveCaret moves the caret by whole grapheme clusters, so that the caret
+// never splits combining marks, emoji sequences or conjuncts. Movement
+// follows the logical order of the text.
+func (t *TextInputSpec) moveCaret(key input.Key) {
+	if t.isComposing {
+		// Keys edit the IME composition, not the text.
+		return
+	}
+	value, caret := t.Text(), t.Caret()
+	switch key {
+	case input.KeyBackspace, input.KeyDelete:
+		if t.mask != "" {
+			t.deleteMasked(key == input.KeyBackspace)
+			return
+		}
+	}
+	switch key {
+	case input.KeyBackspace:
+		previous := text.PreviousGrapheme(value, caret)
+		if previous < caret {
+			t.setText(value[:previous]+value[caret:], previous)
+		}
+	case input.KeyDelete:
+		next := text.NextGrapheme(value, caret)
+		if next > caret {
+			t.setText(value[:caret]+value[next:], caret)
+		}
+	case input.KeyEnd:
+		t.SetCaret(len(value))
+	case input.KeyHome:
+		t.SetCaret(0)
+	case input.KeyLeft:
+		t.SetCaret(text.PreviousGrapheme(value, caret))
+	case input.KeyRight:
+		t.SetCaret(text.NextGrapheme(value, caret))
+	}
+}
+
+func (t *TextInputSpec) setText(value string, caret int) {
+	t.SetText(value)
+	t.SetCaret(caret)
+	t.Emit(events.New(events.TextChanged, t, value))
+}
+
+// textInputSpec returns the TextInputSpec, which may be embedded in another
+// control (e.g., a ComboBox).
+func (t *TextInputSpec) textInputSpec() *TextInputSpec {
+	return t
+}
+
+// textInputProvider is satisfied by any type that embeds TextInputSpec, so
+// that the text input Options work with TextInputs and ComboBoxes.
+type textInputProvider interface {
+	textInputSpec() *TextInputSpec
+}
+
+// isTextInputTarget returns true if the target of the event is the provided
+// TextInputSpec, or the control that embeds it.
+func isTextInputTarget(e events.Event, instance *TextInputSpec) bool {
+	target, ok := e.Target().(textInputProvider)
+	return ok && target.textInputSpec() == instance
+}
+
+// initTextInput configures a TextInputSpec that inserts entered characters
+// and moves its caret with the keyboard.
+func initTextInput(instance *TextInputSpec, name string) {
+	instance.caret = -1
+	instance.sanitize = text.StripControl
 
 	var charEnteredHandler = func(e events.Event) {
-		ctrl := e.Target().(spec.ReadWriter)
-		updatedText := ctrl.Text() + e.Payload().(string)
-		ctrl.SetText(updatedText)
-		ctrl.Emit(events.New(events.TextChanged, ctrl, updatedText))
-	}
-
-	input.PushUnsub(input.On(events.Blurred, opts.OptionsHandler(opts.SetState("active"))))
-	input.PushUnsub(input.On(events.CharEntered, charEnteredHandler))
-	input.PushUnsub(input.On(events.Focused, opts.OptionsHandler(opts.SetState("focused"))))
-	input.SetBgColor(0xfefefeff)
-	input.SetHAlign(spec.AlignLeft)
-	input.SetIsFocusable(true)
-	input.SetIsMeasured(true)
-	input.SetIsTextInput(true)
-	input.SetLayoutType(spec.StackLayoutType)
-	input.SetSpecName("TextInput")
-	input.SetStrokeSize(1)
-	input.SetView(views.LabelView)
+		if isTextInputTarget(e, instance) {
+			instance.insert(e.Payload().(string))
+		}
+	}
+
+	var keyPressedHandler = func(e events.Event) {
+		key, isKey := e.Payload().(input.Key)
+		if isKey && isTextInputTarget(e, instance) {
+			instance.moveCaret(key)
+		}
+	}
+
+	var compositionHandler = func(e events.Event) {
+		if !isTextInputTarget(e, instance) {
+			return
+		}
+		if e.Name() == events.CompositionEnded {
+			// The committed text arrives as a CharEntered event.
+			instance.setComposition(false, input.Composition{})
+			return
+		}
+		composition, _ := e.Payload().(input.Composition)
+		instance.setComposition(true, composition)
+	}
+
+	instance.PushUnsub(instance.On(events.Blurred, opts.OptionsHandler(opts.SetState("active"))))
+	instance.PushUnsub(instance.On(events.CharEntered, charEnteredHandler))
+	instance.PushUnsub(instance.On(events.CompositionEnded, compositionHandler))
+	instance.PushUnsub(instance.On(events.CompositionStarted, compositionHandler))
+	instance.PushUnsub(instance.On(events.CompositionUpdated, compositionHandler))
+	instance.PushUnsub(instance.On(events.KeyPressed, keyPressedHandler))
+	instance.PushUnsub(instance.On(events.Focused, opts.OptionsHandler(opts.SetState("focused"))))
+	instance.SetBgColor(0xfefefeff)
+	instance.SetHAlign(spec.AlignLeft)
+	instance.SetIsFocusable(true)
+	instance.SetIsMeasured(true)
+	instance.SetIsTextInput(true)
+	instance.SetLayoutType(spec.StackLayoutType)
+	instance.SetSpecName(name)
+	instance.SetStrokeSize(1)
+	instance.SetView(views.TextInputView)
 
 	opts.OnState("active", opts.StrokeColor(0x666666ff))
 	opts.OnState("focused", opts.StrokeColor(0x44d9e6ff))
+}
 
-	spec.Apply(input, options...)
-
-	if input.Text() == "" && input.Placeholder() != "" {
+// addPlaceholder adds a Label child that shows the Placeholder, if the
+// configured text is empty.
+func addPlaceholder(instance *TextInputSpec) {
+	if instance.Text() == "" && instance.Placeholder() != "" {
 		// Create a bag of options and then apply them to the input instance.
 		opts.Child(Label(
 			opts.IsFocusable(false),
 			opts.FontColor(0x666666ff),
 			opts.Key("TextInput.Placeholder"),
-			opts.Text(input.Placeholder()),
+			opts.Text(instance.Placeholder()),
 			opts.IsMeasured(false),
-		))(input)
+		))(instance)
 	}
+}
 
-	return input
+// TextInput is a control that allows the user to input text.
+var TextInput = func(options ...spec.Option) spec.ReadWriter {
+	instance := &TextInputSpec{}
+	initTextInput(instance, "TextInput")
+	spec.Apply(instance, options...)
+	addPlaceholder(instance)
+	return instance
 }
 
-// Placeholder Option that only works with TextInputSpec instances. This text
-// will appear in the text input whenever the Text property is empty.
+// Placeholder Option that only works with TextInput and ComboBox instances.
+// This text will appear in the text input whenever the Text property is
+// empty.
 func Placeholder(text string) spec.Option {
 	return func(d spec.ReadWriter) {
-		d.(*TextInputSpec).placeholder = text
+		d.(textInputProvider).textInputSpec().placeholder = text
+	}
+}
+
+// AllowedChars Option that only works with TextInput and ComboBox instances.
+// Only characters that the provided function returns true for may be
+// entered.
+func AllowedChars(allowed func(r rune) bool) spec.Option {
+	return func(d spec.ReadWriter) {
+		d.(textInputProvider).textInputSpec().allow = func(cluster string) bool {
+			for _, r := range cluster {
+				if !allowed(r) {
+					return false
+				}
+			}
+			return true
+		}
+	}
+}
+
+// AllowedPattern Option that only works with TextInput and ComboBox
+// instances. Only characters (grapheme clusters) that entirely match the
+// provided regular expression may be entered, e.g.,
+// AllowedPattern("[a-f0-9]").
+func AllowedPattern(pattern string) spec.Option {
+	expression := regexp.MustCompile("^(?:" + pattern + ")$")
+	return func(d spec.ReadWriter) {
+		d.(textInputProvider).textInputSpec().allow = expression.MatchString
+	}
+}
+
+// InputMask Option that only works with TextInput and ComboBox instances.
+// Entered characters fill the placeholders in mask ("9" for a digit, "a" for
+// a letter and "*" for either) and the other characters of the mask are
+// inserted around them, e.g., InputMask(PhoneMask).
+func InputMask(mask string) spec.Option {
+	return func(d spec.ReadWriter) {
+		d.(textInputProvider).textInputSpec().mask = mask
+	}
+}
+
+// MaxLength Option that only works with TextInput and ComboBox instances.
+// Limits the number of characters (grapheme clusters) that may be entered.
+func MaxLength(length int) spec.Option {
+	return func(d spec.ReadWriter) {
+		d.(textInputProvider).textInputSpec().maxLength = length
+	}
+}
+
+// NumericOnly Option that only works with TextInput and ComboBox instances.
+// Only digits, a leading minus sign and a single decimal point may be
+// entered.
+func NumericOnly() spec.Option {
+	return func(d spec.ReadWriter) {
+		d.(textInputProvider).textInputSpec().isNumeric = true
+	}
+}
+
+// Password Option that only works with TextInput and ComboBox instances.
+// Each character is drawn as a PasswordBullet until the input is revealed.
+func Password() spec.Option {
+	return func(d spec.ReadWriter) {
+		d.(textInputProvider).textInputSpec().isPassword = true
+	}
+}
+
+// RevealPassword Option that only works with TextInput and ComboBox
+// instances. Shows or hides the text of a Password input.
+func RevealPassword(revealed bool) spec.Option {
+	return func(d spec.ReadWriter) {
+		d.(textInputProvider).textInputSpec().isRevealed = revealed
+	}
+}
+
+// Sanitizer Option that only works with TextInput and ComboBox instances.
+// The provided function cleans entered text before it is inserted and before
+// TextChanged is emitted. The default is text.StripControl.
+func Sanitizer(sanitize func(value string) string) spec.Option {
+	return func(d spec.ReadWriter) {
+		d.(textInputProvider).textInputSpec().sanitize = sanitize
+	}
+}
+
+func graphemeCount(value string) int {
+	return len(text.Graphemes(value)) - 1
+}
+
+// isNumeric returns true if value is made of digits, with an optional
+// leading minus sign and at most one decimal point.
+func isNumeric(value string) bool {
+	hasPoint := false
+	for index, r := range value {
+		switch {
+		case r == '-' && index == 0:
+		case r == '.' && !hasPoint:
+			hasPoint = true
+		case !unicode.IsDigit(r):
+			return false
+		}
+	}
+	return true
+}
+
+func isMaskSlot(r rune) bool {
+	return r == '9' || r == 'a' || r == '*'
+}
+
+func fitsMaskSlot(slot, r rune) bool {
+	switch slot {
+	case '9':
+		return unicode.IsDigit(r)
+	case 'a':
+		return unicode.IsLetter(r)
+	}
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// walkMask calls handler with each character of value that fills a slot
+// in mask, along with the byte offset that follows it.
+func walkMask(mask, value string, handler func(end int, r rune)) {
+	slots := []rune(mask)
+	index := 0
+	for offset, r := range value {
+		// Skip literals that are missing from value.
+		for index < len(slots) && !isMaskSlot(slots[index]) && slots[index] != r {
+			index++
+		}
+		if index >= len(slots) {
+			return
+		}
+		if !isMaskSlot(slots[index]) {
+			index++
+		} else if fitsMaskSlot(slots[index], r) {
+			handler(offset+utf8.RuneLen(r), r)
+			index++
+		}
+	}
+}
+
+// maskSlots returns the characters of value that fill slots in mask.
+func maskSlots(mask, value string) []rune {
+	result := []rune{}
+	walkMask(mask, value, func(end int, r rune) {
+		result = append(result, r)
+	})
+	return result
+}
+
+// maskOffset returns the byte offset in value that follows the provided
+// number of filled slots.
+func maskOffset(mask, value string, slots int) int {
+	result := 0
+	walkMask(mask, value, func(end int, r rune) {
+		if slots > 0 {
+			result = end
+			slots--
+		}
+	})
+	return result
+}
+
+// formatMask places each character of raw that fits into the next slot of
+// mask. Literals are only written once a character follows them.
+func formatMask(mask string, raw []rune) string {
+	var result, literals strings.Builder
+	index := 0
+	for _, slot := range mask {
+		if !isMaskSlot(slot) {
+			literals.WriteRune(slot)
+			continue
+		}
+		for index < len(raw) && !fitsMaskSlot(slot, raw[index]) {
+			index++
+		}
+		if index >= len(raw) {
+			break
+		}
+		result.WriteString(literals.String())
+		literals.Reset()
+		result.WriteRune(raw[index])
+		index++
 	}
+	return result.String()
 }
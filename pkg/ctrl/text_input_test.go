@@ -1,12 +1,18 @@
 package ctrl_test
 
 import (
+	"strings"
+	"testing"
+	"unicode"
+
 	"github.com/waybeams/assert"
 	"github.com/waybeams/waybeams/pkg/ctrl"
+	"github.com/waybeams/waybeams/pkg/env/fake"
 	"github.com/waybeams/waybeams/pkg/events"
+	"github.com/waybeams/waybeams/pkg/input"
+	"github.com/waybeams/waybeams/pkg/layout"
 	"github.com/waybeams/waybeams/pkg/opts"
 	"github.com/waybeams/waybeams/pkg/spec"
-	"testing"
 )
 
 type inputModel struct {
@@ -66,4 +72,262 @@ func TestTextInput(t *testing.T) {
 		instance.Emit(events.New(events.CharEntered, instance, "T"))
 		assert.Equal(model.Text, "abcdQRST")
 	})
+
+	t.Run("Caret", func(t *testing.T) {
+		press := func(instance spec.ReadWriter, keys ...input.Key) {
+			for _, key := range keys {
+				instance.Emit(events.New(events.KeyPressed, instance, key))
+			}
+		}
+
+		t.Run("Defaults to the end", func(t *testing.T) {
+			instance := ctrl.TextInput(opts.Text("abc")).(*ctrl.TextInputSpec)
+			assert.Equal(instance.Caret(), 3)
+		})
+
+		t.Run("Inserts at the caret", func(t *testing.T) {
+			instance := ctrl.TextInput(opts.Text("ac")).(*ctrl.TextInputSpec)
+			press(instance, input.KeyLeft)
+			instance.Emit(events.New(events.CharEntered, instance, "b"))
+			assert.Equal(instance.Text(), "abc")
+			assert.Equal(instance.Caret(), 2)
+		})
+
+		t.Run("Is carried into the next tree", func(t *testing.T) {
+			previous := ctrl.TextInput(opts.Text("ac")).(*ctrl.TextInputSpec)
+			press(previous, input.KeyLeft)
+			instance := ctrl.TextInput(opts.Text("ac")).(*ctrl.TextInputSpec)
+			spec.Reconcile(previous, instance)
+			assert.Equal(instance.Caret(), 1)
+		})
+
+		t.Run("Moves by grapheme", func(t *testing.T) {
+			// "e" with a combining accent, and a family emoji sequence.
+			value := "ae\u0301\U0001F468\u200D\U0001F469\u200D\U0001F467"
+			instance := ctrl.TextInput(opts.Text(value)).(*ctrl.TextInputSpec)
+			press(instance, input.KeyLeft)
+			assert.Equal(instance.Caret(), 4)
+			press(instance, input.KeyLeft)
+			assert.Equal(instance.Caret(), 1)
+			press(instance, input.KeyRight)
+			assert.Equal(instance.Caret(), 4)
+			press(instance, input.KeyHome)
+			assert.Equal(instance.Caret(), 0)
+			press(instance, input.KeyLeft)
+			assert.Equal(instance.Caret(), 0)
+			press(instance, input.KeyEnd)
+			assert.Equal(instance.Caret(), len(value))
+		})
+
+		t.Run("Deletes by grapheme", func(t *testing.T) {
+			var changed []string
+			instance := ctrl.TextInput(
+				opts.Text("ae\u0301b"),
+				opts.On(events.TextChanged, events.StringPayload(func(value string) {
+					changed = append(changed, value)
+				})),
+			).(*ctrl.TextInputSpec)
+			press(instance, input.KeyLeft, input.KeyBackspace)
+			assert.Equal(instance.Text(), "ab")
+			assert.Equal(instance.Caret(), 1)
+			press(instance, input.KeyDelete)
+			assert.Equal(instance.Text(), "a")
+			press(instance, input.KeyDelete)
+			assert.Equal(changed, []string{"ab", "a"})
+		})
+	})
+
+	t.Run("Constraints", func(t *testing.T) {
+		enter := func(instance spec.ReadWriter, values ...string) {
+			for _, value := range values {
+				instance.Emit(events.New(events.CharEntered, instance, value))
+			}
+		}
+
+		t.Run("Strips control characters before TextChanged", func(t *testing.T) {
+			var changed []string
+			instance := ctrl.TextInput(
+				opts.On(events.TextChanged, events.StringPayload(func(value string) {
+					changed = append(changed, value)
+				})),
+			)
+			enter(instance, "a\x00b\nc", "\x1b")
+			assert.Equal(instance.Text(), "abc")
+			assert.Equal(changed, []string{"abc"})
+		})
+
+		t.Run("Sanitizer", func(t *testing.T) {
+			instance := ctrl.TextInput(ctrl.Sanitizer(strings.ToUpper))
+			enter(instance, "abc")
+			assert.Equal(instance.Text(), "ABC")
+		})
+
+		t.Run("MaxLength", func(t *testing.T) {
+			instance := ctrl.TextInput(ctrl.MaxLength(3), opts.Text("a"))
+			enter(instance, "e\u0301cd", "f")
+			assert.Equal(instance.Text(), "ae\u0301c")
+			assert.Equal(instance.(*ctrl.TextInputSpec).MaxLength(), 3)
+		})
+
+		t.Run("AllowedChars", func(t *testing.T) {
+			instance := ctrl.TextInput(ctrl.AllowedChars(unicode.IsUpper))
+			enter(instance, "aBcD", "e", "F")
+			assert.Equal(instance.Text(), "BDF")
+		})
+
+		t.Run("AllowedPattern", func(t *testing.T) {
+			instance := ctrl.TextInput(ctrl.AllowedPattern("[a-f0-9]"))
+			enter(instance, "c0ffee", "g", "42")
+			assert.Equal(instance.Text(), "c0ffee42")
+		})
+
+		t.Run("NumericOnly", func(t *testing.T) {
+			instance := ctrl.TextInput(ctrl.NumericOnly())
+			enter(instance, "-", "1", "a", ".", "5", ".", "-", "2")
+			assert.Equal(instance.Text(), "-1.52")
+
+			instance = ctrl.TextInput(ctrl.NumericOnly())
+			enter(instance, "12-3.4.5")
+			assert.Equal(instance.Text(), "123.45")
+		})
+
+		t.Run("InputMask", func(t *testing.T) {
+			instance := ctrl.TextInput(ctrl.InputMask(ctrl.PhoneMask)).(*ctrl.TextInputSpec)
+			enter(instance, "5", "5")
+			assert.Equal(instance.Text(), "(55")
+			enter(instance, "5", "x", "1")
+			assert.Equal(instance.Text(), "(555) 1")
+			assert.Equal(instance.Caret(), 7)
+			enter(instance, "234567890")
+			assert.Equal(instance.Text(), "(555) 123-4567")
+
+			// Backspace removes digits and never leaves a literal behind.
+			press := func(key input.Key) {
+				instance.Emit(events.New(events.KeyPressed, instance, key))
+			}
+			instance = ctrl.TextInput(ctrl.InputMask(ctrl.PhoneMask), opts.Text("(555) 1")).(*ctrl.TextInputSpec)
+			press(input.KeyBackspace)
+			assert.Equal(instance.Text(), "(555")
+			assert.Equal(instance.Caret(), 4)
+
+			// Characters are inserted at the caret and reformatted.
+			instance.SetCaret(2)
+			enter(instance, "9")
+			assert.Equal(instance.Text(), "(595) 5")
+			assert.Equal(instance.Caret(), 3)
+			press(input.KeyDelete)
+			assert.Equal(instance.Text(), "(595")
+		})
+
+		t.Run("DateMask", func(t *testing.T) {
+			instance := ctrl.TextInput(ctrl.InputMask(ctrl.DateMask))
+			enter(instance, "12/31/1999")
+			assert.Equal(instance.Text(), "12/31/1999")
+
+			instance = ctrl.TextInput(ctrl.InputMask(ctrl.DateMask))
+			enter(instance, "01022003")
+			assert.Equal(instance.Text(), "01/02/2003")
+		})
+
+		t.Run("Password", func(t *testing.T) {
+			instance := ctrl.TextInput(ctrl.Password(), opts.Text("se\u0301cret")).(*ctrl.TextInputSpec)
+			assert.True(instance.IsPassword())
+			assert.Equal(instance.DisplayText(), "••••••")
+			assert.Equal(instance.Text(), "se\u0301cret")
+
+			s := fake.NewSurface()
+			layout.Draw(instance, s)
+			texts := s.CommandsNamed("Text")
+			assert.Equal(texts[len(texts)-1].Args[2], "••••••")
+
+			instance.ToggleRevealed()
+			assert.True(instance.IsRevealed())
+			assert.Equal(instance.DisplayText(), "se\u0301cret")
+
+			next := ctrl.TextInput(ctrl.Password(), opts.Text("se\u0301cret")).(*ctrl.TextInputSpec)
+			spec.Reconcile(instance, next)
+			assert.True(next.IsRevealed(), "Stays revealed in a re-created tree")
+
+			revealed := ctrl.TextInput(ctrl.Password(), ctrl.RevealPassword(true), opts.Text("abc")).(*ctrl.TextInputSpec)
+			assert.Equal(revealed.DisplayText(), "abc")
+		})
+	})
+
+	t.Run("Composition", func(t *testing.T) {
+		compose := func(instance spec.ReadWriter, name string, value string) {
+			composition := input.Composition{Cursor: len(value), Text: value}
+			instance.Emit(events.New(name, instance, composition))
+		}
+
+		t.Run("Draws preedit text at the caret with an underline", func(t *testing.T) {
+			instance := ctrl.TextInput(opts.Text("ab")).(*ctrl.TextInputSpec)
+			instance.SetCaret(1)
+			compose(instance, events.CompositionStarted, "")
+			compose(instance, events.CompositionUpdated, "にほ")
+			assert.True(instance.IsComposing())
+			assert.Equal(instance.Text(), "ab")
+			assert.Equal(instance.DisplayText(), "aにほb")
+
+			s := fake.NewSurface()
+			layout.Layout(instance, s)
+			layout.Draw(instance, s)
+			texts := s.CommandsNamed("Text")
+			assert.Equal(texts[len(texts)-1].Args[2], "aにほb")
+
+			x, width, ok := instance.PreeditBounds()
+			assert.True(ok)
+			assert.True(x > 0)
+			assert.True(width > 0)
+			moves := s.CommandsNamed("MoveTo")
+			lines := s.CommandsNamed("LineTo")
+			assert.Equal(moves[len(moves)-1].Args, []interface{}{instance.TextX() + x, instance.TextY() + 2})
+			assert.Equal(lines[len(lines)-1].Args, []interface{}{instance.TextX() + x + width, instance.TextY() + 2})
+
+			// The IME cursor is at the end of the preedit text.
+			caretX, _, _, _ := instance.CaretRect()
+			assert.Equal(caretX, instance.TextX()-instance.X()+x+width)
+		})
+
+		t.Run("Commits text when the composition ends", func(t *testing.T) {
+			var changed []string
+			instance := ctrl.TextInput(
+				opts.Text("ab"),
+				opts.On(events.TextChanged, events.StringPayload(func(value string) {
+					changed = append(changed, value)
+				})),
+			).(*ctrl.TextInputSpec)
+			instance.SetCaret(1)
+			compose(instance, events.CompositionStarted, "")
+			compose(instance, events.CompositionUpdated, "にほ")
+
+			// Keys edit the composition rather than the text.
+			instance.Emit(events.New(events.KeyPressed, instance, input.KeyBackspace))
+			assert.Equal(instance.Text(), "ab")
+
+			compose(instance, events.CompositionEnded, "日本")
+			instance.Emit(events.New(events.CharEntered, instance, "日本"))
+			assert.False(instance.IsComposing())
+			assert.Equal(instance.Text(), "a日本b")
+			assert.Equal(instance.DisplayText(), "a日本b")
+			assert.Equal(changed, []string{"a日本b"})
+			_, _, ok := instance.PreeditBounds()
+			assert.False(ok)
+		})
+
+		t.Run("Is carried into the next tree", func(t *testing.T) {
+			previous := ctrl.TextInput(opts.Text("ab")).(*ctrl.TextInputSpec)
+			compose(previous, events.CompositionStarted, "")
+			compose(previous, events.CompositionUpdated, "にほ")
+			instance := ctrl.TextInput(opts.Text("ab")).(*ctrl.TextInputSpec)
+			spec.Reconcile(previous, instance)
+			assert.True(instance.IsComposing())
+			assert.Equal(instance.DisplayText(), "abにほ")
+		})
+
+		t.Run("Is not drawn in hidden passwords", func(t *testing.T) {
+			instance := ctrl.TextInput(ctrl.Password(), opts.Text("ab")).(*ctrl.TextInputSpec)
+			compose(instance, events.CompositionUpdated, "にほ")
+			assert.Equal(instance.DisplayText(), "••")
+		})
+	})
 }
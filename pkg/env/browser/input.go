@@ -0,0 +1,270 @@
+package browser
+
+import (
+	"strconv"
+
+	"github.com/gopherjs/gopherjs/js"
+	"github.com/waybeams/waybeams/pkg/events"
+	"github.com/waybeams/waybeams/pkg/input"
+)
+
+// The following functions adapt DOM gesture values into the environment
+// neutral values that are understood by input.Controller.
+
+func cursorToCss(cursor input.Cursor) string {
+	switch cursor {
+	case input.HandCursor:
+		return "pointer"
+	case input.IBeamCursor:
+		return "text"
+	case input.CrosshairCursor:
+		return "crosshair"
+	case input.HResizeCursor:
+		return "ew-resize"
+	case input.VResizeCursor:
+		return "ns-resize"
+	default:
+		return "default"
+	}
+}
+
+func keyFromDom(key string) input.Key {
+	switch key {
+	case "Backspace":
+		return input.KeyBackspace
+	case "Delete":
+		return input.KeyDelete
+	case "ArrowDown":
+		return input.KeyDown
+	case "End":
+		return input.KeyEnd
+	case "Enter":
+		return input.KeyEnter
+	case "Escape":
+		return input.KeyEscape
+	case "Home":
+		return input.KeyHome
+	case "ArrowLeft":
+		return input.KeyLeft
+	case "PageDown":
+		return input.KeyPageDown
+	case "PageUp":
+		return input.KeyPageUp
+	case "ArrowRight":
+		return input.KeyRight
+	case " ":
+		return input.KeySpace
+	case "Tab":
+		return input.KeyTab
+	case "ArrowUp":
+		return input.KeyUp
+	default:
+		return input.KeyUnknown
+	}
+}
+
+func modsFromDom(e *js.Object) input.ModifierKey {
+	var result input.ModifierKey
+	if e.Get("shiftKey").Bool() {
+		result |= input.ModShift
+	}
+	if e.Get("ctrlKey").Bool() {
+		result |= input.ModControl
+	}
+	if e.Get("altKey").Bool() {
+		result |= input.ModAlt
+	}
+	if e.Get("metaKey").Bool() {
+		result |= input.ModSuper
+	}
+	return result
+}
+
+func mouseButtonFromDom(button int) input.MouseButton {
+	switch button {
+	case 0:
+		return input.MouseButton1
+	case 2:
+		return input.MouseButton2
+	case 1:
+		return input.MouseButton3
+	default:
+		return input.MouseButtonUnknown
+	}
+}
+
+// isComposingFromDom returns true for keyboard events that belong to an IME
+// composition, which are handled by the composition events instead.
+func isComposingFromDom(e *js.Object) bool {
+	return e.Get("isComposing").Bool() || e.Get("keyCode").Int() == 229
+}
+
+func pixels(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64) + "px"
+}
+
+// addListener subscribes the provided handler to the named DOM event on the
+// browser window and returns an Unsubscriber that will remove it.
+func (w *window) addListener(eventName string, handler func(e *js.Object)) events.Unsubscriber {
+	return addListenerTo(w.browserWindow, eventName, handler)
+}
+
+// addListenerTo subscribes the provided handler to the named DOM event on
+// target and returns an Unsubscriber that will remove it.
+func addListenerTo(target *js.Object, eventName string, handler func(e *js.Object)) events.Unsubscriber {
+	listener := js.MakeFunc(func(this *js.Object, args []*js.Object) interface{} {
+		handler(args[0])
+		return nil
+	})
+	target.Call("addEventListener", eventName, listener)
+	return func() bool {
+		target.Call("removeEventListener", eventName, listener)
+		return true
+	}
+}
+
+func (w *window) initInput() {
+	w.addListener("mousemove", func(e *js.Object) {
+		w.cursorX = e.Get("clientX").Float()
+		w.cursorY = e.Get("clientY").Float()
+	})
+	w.initTextInput()
+	w.input = input.New(w)
+}
+
+// initTextInput creates the hidden input element that has focus while a
+// TextInput is focused, so that the browser IME composes text for it and
+// places its candidate window at the caret.
+func (w *window) initTextInput() {
+	document := w.browserWindow.Get("document")
+	element := document.Call("createElement", "input")
+	element.Set("type", "text")
+	element.Call("setAttribute", "aria-hidden", "true")
+	element.Call("setAttribute", "autocapitalize", "off")
+	element.Call("setAttribute", "autocomplete", "off")
+	element.Call("setAttribute", "spellcheck", "false")
+	style := element.Get("style")
+	style.Set("border", "0")
+	style.Set("left", "0px")
+	style.Set("opacity", "0")
+	style.Set("padding", "0")
+	style.Set("pointerEvents", "none")
+	style.Set("position", "fixed")
+	style.Set("top", "0px")
+	style.Set("width", "1px")
+	document.Get("body").Call("appendChild", element)
+
+	// Committed characters are delivered by the keypress listener on the
+	// window, so the element only holds text during a composition.
+	addListenerTo(element, "input", func(e *js.Object) {
+		if !w.isComposing {
+			element.Set("value", "")
+		}
+	})
+	w.textInput = element
+}
+
+func (w *window) GetCursorPos() (x, y float64) {
+	return w.cursorX, w.cursorY
+}
+
+func (w *window) SetCursorByName(name input.Cursor) {
+	body := w.browserWindow.Get("document").Get("body")
+	body.Get("style").Set("cursor", cursorToCss(name))
+}
+
+func (w *window) SetCharCallback(callback input.CharCallback) events.Unsubscriber {
+	return w.addListener("keypress", func(e *js.Object) {
+		// Only single character keys are printable, named keys (e.g.,
+		// "Enter") are delivered through the KeyCallback.
+		key := []rune(e.Get("key").String())
+		if len(key) == 1 && !isComposingFromDom(e) {
+			callback(key[0])
+		}
+	})
+}
+
+func (w *window) SetKeyCallback(callback input.KeyCallback) events.Unsubscriber {
+	keyHandler := func(action input.Action) func(e *js.Object) {
+		return func(e *js.Object) {
+			if isComposingFromDom(e) {
+				return
+			}
+			current := action
+			if current == input.Press && e.Get("repeat").Bool() {
+				current = input.Repeat
+			}
+			key := keyFromDom(e.Get("key").String())
+			callback(key, e.Get("keyCode").Int(), current, modsFromDom(e))
+		}
+	}
+	unsubDown := w.addListener("keydown", keyHandler(input.Press))
+	unsubUp := w.addListener("keyup", keyHandler(input.Release))
+	return func() bool {
+		return unsubDown() && unsubUp()
+	}
+}
+
+func (w *window) SetMouseButtonCallback(callback input.MouseButtonCallback) events.Unsubscriber {
+	buttonHandler := func(action input.Action) func(e *js.Object) {
+		return func(e *js.Object) {
+			button := mouseButtonFromDom(e.Get("button").Int())
+			callback(button, action, modsFromDom(e))
+		}
+	}
+	unsubDown := w.addListener("mousedown", buttonHandler(input.Press))
+	unsubUp := w.addListener("mouseup", buttonHandler(input.Release))
+	return func() bool {
+		return unsubDown() && unsubUp()
+	}
+}
+
+func (w *window) SetCompositionCallback(callback input.CompositionCallback) events.Unsubscriber {
+	element := w.textInput
+	composition := func(e *js.Object) input.Composition {
+		var text string
+		if data := e.Get("data"); data != nil && data != js.Undefined {
+			text = data.String()
+		}
+		return input.Composition{Cursor: len(text), Text: text}
+	}
+	unsubStart := addListenerTo(element, "compositionstart", func(e *js.Object) {
+		w.isComposing = true
+		callback(input.CompositionStart, input.Composition{})
+	})
+	unsubUpdate := addListenerTo(element, "compositionupdate", func(e *js.Object) {
+		callback(input.CompositionUpdate, composition(e))
+	})
+	unsubEnd := addListenerTo(element, "compositionend", func(e *js.Object) {
+		w.isComposing = false
+		element.Set("value", "")
+		callback(input.CompositionEnd, composition(e))
+	})
+	return func() bool {
+		return unsubStart() && unsubUpdate() && unsubEnd()
+	}
+}
+
+// SetTextInputRect moves the hidden input element over the caret, which is
+// where browsers place the IME candidate window.
+func (w *window) SetTextInputRect(x, y, width, height float64) {
+	style := w.textInput.Get("style")
+	style.Set("left", pixels(x))
+	style.Set("top", pixels(y))
+	style.Set("height", pixels(height))
+}
+
+func (w *window) StartTextInput() {
+	element := w.textInput
+	// Focus is moved after the mousedown that focused the TextInput has
+	// finished, because its default action would blur the element.
+	w.browserWindow.Call("setTimeout", func() {
+		element.Call("focus", map[string]interface{}{"preventScroll": true})
+	}, 0)
+}
+
+func (w *window) StopTextInput() {
+	w.isComposing = false
+	w.textInput.Set("value", "")
+	w.textInput.Call("blur")
+}
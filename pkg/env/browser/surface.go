@@ -1,12 +1,18 @@
 package browser
 
 import (
+	"encoding/base64"
+	"math"
 	"strconv"
 
 	"github.com/gopherjs/gopherjs/js"
 
 	jsCanvas "github.com/oskca/gopherjs-canvas"
+	"github.com/waybeams/waybeams/pkg/font"
 	"github.com/waybeams/waybeams/pkg/helpers"
+	"github.com/waybeams/waybeams/pkg/spec"
+	"github.com/waybeams/waybeams/pkg/text"
+	roboto "github.com/waybeams/waybeams/third_party/fonts/Roboto"
 )
 
 type ExternalCanvas interface {
@@ -17,18 +23,35 @@ type ExternalCanvas interface {
 const Clockwise = false
 const Anticlockwise = true
 
+// shadowShift moves the shape that casts a box gradient shadow far outside of
+// the canvas, so that only the shadow itself is drawn.
+const shadowShift = 100000
+
+// boxGradient is a NanoVG box gradient that is waiting for the next Fill.
+type boxGradient struct {
+	x, y, width, height, radius, feather float64
+	innerColor, outerColor               uint
+}
+
 type Surface struct {
 	context *jsCanvas.Context2D
 	canvas  ExternalCanvas
 
-	flags  []SurfaceOption
-	width  float64
-	height float64
+	fillRule string
+	flags    []SurfaceOption
+	images   map[string]*js.Object
+	loaded   map[string]bool
+	registry *font.Registry
+	shaper   text.Shaper
+	width    float64
+	height   float64
 
 	lastFontSize    int
 	lastFontFace    string
 	lastStrokeWidth int
 	lastStrokeColor uint
+
+	boxGradient *boxGradient
 }
 
 func (s *Surface) Init() {
@@ -70,14 +93,157 @@ func (s *Surface) CreateFont(name, path string) {
 	panic("Not implemented")
 }
 
+func (s *Surface) addImage(name, source string) {
+	if s.images == nil {
+		s.images = map[string]*js.Object{}
+	}
+	image := js.Global.Get("Image").New()
+	image.Set("src", source)
+	s.images[name] = image
+}
+
+// CreateImage starts loading the image at the provided URL. ImageSize will
+// return false until the browser has finished loading it.
+func (s *Surface) CreateImage(name, path string) error {
+	if s.images[name] == nil {
+		s.addImage(name, path)
+	}
+	return nil
+}
+
+// CreateImageFromBytes loads the provided encoded image data as a data URL.
+func (s *Surface) CreateImageFromBytes(name string, data []byte) error {
+	if s.images[name] != nil {
+		return nil
+	}
+	_, _, format, err := helpers.ImageConfig(data)
+	if err != nil {
+		return err
+	}
+	s.addImage(name, "data:image/"+format+";base64,"+base64.StdEncoding.EncodeToString(data))
+	return nil
+}
+
+func (s *Surface) DrawImage(name string, x, y, width, height float64) {
+	if image := s.images[name]; image != nil && image.Get("complete").Bool() {
+		s.context.Call("drawImage", image, x, y, width, height)
+	}
+}
+
+func (s *Surface) DrawImageRegion(name string, srcX, srcY, srcWidth, srcHeight, x, y, width, height float64) {
+	if image := s.images[name]; image != nil && image.Get("complete").Bool() {
+		s.context.Call("drawImage", image, srcX, srcY, srcWidth, srcHeight, x, y, width, height)
+	}
+}
+
+func (s *Surface) ImageSize(name string) (width, height float64, ok bool) {
+	image := s.images[name]
+	if image == nil || !image.Get("complete").Bool() {
+		return 0, 0, false
+	}
+	return image.Get("naturalWidth").Float(), image.Get("naturalHeight").Float(), true
+}
+
 func (s *Surface) MoveTo(x float64, y float64) {
 	s.context.MoveTo(x, y)
 }
 
+func (s *Surface) LineTo(x float64, y float64) {
+	s.context.LineTo(x, y)
+}
+
+func (s *Surface) BezierTo(c1x, c1y, c2x, c2y, x, y float64) {
+	s.context.BezierCurveTo(c1x, c1y, c2x, c2y, x, y)
+}
+
+func (s *Surface) QuadTo(cx, cy, x, y float64) {
+	s.context.QuadraticCurveTo(cx, cy, x, y)
+}
+
+func (s *Surface) ClosePath() {
+	s.context.ClosePath()
+}
+
+func (s *Surface) Ellipse(cx, cy, rx, ry float64) {
+	s.context.MoveTo(cx+rx, cy)
+	s.context.Call("ellipse", cx, cy, rx, ry, 0, 0, 2*math.Pi)
+	s.context.ClosePath()
+}
+
+// PathWinding approximates NanoVG sub-path winding, which canvas does not
+// support, by filling the current path with the "evenodd" rule once any hole
+// has been declared.
+func (s *Surface) PathWinding(winding spec.Winding) {
+	if winding == spec.HoleWinding {
+		s.fillRule = "evenodd"
+	}
+}
+
+func (s *Surface) SetGlobalAlpha(alpha float64) {
+	s.context.GlobalAlpha = alpha
+}
+
 func (s *Surface) SetFillColor(color uint) {
+	s.boxGradient = nil
 	s.context.FillStyle = helpers.UintToHexString(color)
 }
 
+// SetFillBoxGradient defers to the next Fill, because canvas has no
+// equivalent of the NanoVG box gradient and draws it as a blurred shadow.
+func (s *Surface) SetFillBoxGradient(x, y, width, height, radius, feather float64, innerColor, outerColor uint) {
+	s.boxGradient = &boxGradient{x, y, width, height, radius, feather, innerColor, outerColor}
+}
+
+// fillBoxGradient clips to the current path and draws the shadow of the
+// rounded gradient box, with the box itself shifted out of view. An inner
+// color that is more opaque than the outer one shades the box (as for a
+// drop shadow), otherwise everything around the box is shaded (as for an
+// inset shadow).
+func (s *Surface) fillBoxGradient(gradient *boxGradient) {
+	_, _, _, innerAlpha := helpers.HexIntToRgba(gradient.innerColor)
+	_, _, _, outerAlpha := helpers.HexIntToRgba(gradient.outerColor)
+	isInset := innerAlpha < outerAlpha
+	color := gradient.innerColor
+	rule := "nonzero"
+	if isInset {
+		color = gradient.outerColor
+		rule = "evenodd"
+	}
+
+	s.context.Save()
+	s.context.Call("clip", s.fillRule)
+	s.context.BeginPath()
+	if isInset {
+		margin := gradient.width + gradient.height + gradient.feather*2
+		s.context.Rect(gradient.x-shadowShift-margin, gradient.y-margin, gradient.width+margin*2, gradient.height+margin*2)
+	}
+	s.RoundedRect(gradient.x-shadowShift, gradient.y, gradient.width, gradient.height, gradient.radius)
+	s.context.Set("shadowColor", helpers.UintToCssRgba(color))
+	s.context.Set("shadowBlur", gradient.feather/2)
+	s.context.Set("shadowOffsetX", shadowShift)
+	s.context.Set("shadowOffsetY", 0)
+	s.context.Set("fillStyle", helpers.UintToCssRgba(color|0xff))
+	s.context.Call("fill", rule)
+	s.context.Restore()
+}
+
+func (s *Surface) SetFillLinearGradient(startX, startY, endX, endY float64, startColor, endColor uint) {
+	gradient := s.context.Call("createLinearGradient", startX, startY, endX, endY)
+	s.setFillGradient(gradient, startColor, endColor)
+}
+
+func (s *Surface) SetFillRadialGradient(centerX, centerY, innerRadius, outerRadius float64, innerColor, outerColor uint) {
+	gradient := s.context.Call("createRadialGradient", centerX, centerY, innerRadius, centerX, centerY, outerRadius)
+	s.setFillGradient(gradient, innerColor, outerColor)
+}
+
+func (s *Surface) setFillGradient(gradient *js.Object, startColor, endColor uint) {
+	s.boxGradient = nil
+	gradient.Call("addColorStop", 0, helpers.UintToCssRgba(startColor))
+	gradient.Call("addColorStop", 1, helpers.UintToCssRgba(endColor))
+	s.context.Set("fillStyle", gradient)
+}
+
 func (s *Surface) SetStrokeColor(color uint) {
 	s.context.StrokeStyle = helpers.UintToHexString(color)
 }
@@ -95,6 +261,7 @@ func (s *Surface) Arc(xc float64, yc float64, radius float64, angle1 float64, an
 }
 
 func (s *Surface) BeginPath() {
+	s.fillRule = "nonzero"
 	s.context.BeginPath()
 }
 
@@ -103,7 +270,11 @@ func (s *Surface) DebugDumpPathCache() {
 }
 
 func (s *Surface) Fill() {
-	s.context.Fill()
+	if s.boxGradient != nil {
+		s.fillBoxGradient(s.boxGradient)
+		return
+	}
+	s.context.Call("fill", s.fillRule)
 }
 
 func (s *Surface) Rect(x, y, width, height float64) {
@@ -111,12 +282,75 @@ func (s *Surface) Rect(x, y, width, height float64) {
 }
 
 func (s *Surface) RoundedRect(x, y, width, height, radius float64) {
-	// s.context.RoundedRect(x, y, width, height, radius)
+	helpers.RoundedRectVaryingPath(s, x, y, width, height, radius, radius, radius, radius)
+}
+
+func (s *Surface) RoundedRectVarying(x, y, width, height, topLeft, topRight, bottomRight, bottomLeft float64) {
+	helpers.RoundedRectVaryingPath(s, x, y, width, height, topLeft, topRight, bottomRight, bottomLeft)
+}
+
+// AddFont registers the font at the provided URL as the regular weight and
+// normal style of the named family.
+func (s *Surface) AddFont(name string, path string) error {
+	s.Fonts().AddURL(name, font.Regular, font.Normal, path)
+	return nil
 }
 
-func (s *Surface) AddFont(name string, path string) {
-	// TODO(lbayes): Load font if path is URL?
-	panic("Not Implemented")
+// Fonts returns the font Registry for this surface, which includes the
+// embedded Roboto family unless another Registry was provided.
+func (s *Surface) Fonts() *font.Registry {
+	if s.registry == nil {
+		s.registry = roboto.NewRegistry()
+	}
+	return s.registry
+}
+
+// loadFonts adds any newly registered faces to document.fonts with their
+// weight and style descriptors, so that CSS font matching can find them.
+func (s *Surface) loadFonts() {
+	if s.loaded == nil {
+		s.loaded = map[string]bool{}
+	}
+	for _, face := range s.Fonts().Faces() {
+		if s.loaded[face.Name] {
+			continue
+		}
+		var source interface{} = "url(" + face.URL + ")"
+		if face.Data != nil {
+			source = js.NewArrayBuffer(face.Data)
+		}
+		descriptors := map[string]interface{}{
+			"style":  cssFontStyle(face.Style),
+			"weight": strconv.Itoa(face.Weight),
+		}
+		fontFace := js.Global.Get("FontFace").New(face.Family, source, descriptors)
+		fontFace.Call("load")
+		js.Global.Get("document").Get("fonts").Call("add", fontFace)
+		s.loaded[face.Name] = true
+	}
+}
+
+func cssFontStyle(style font.Style) string {
+	if style == font.Italic {
+		return "italic"
+	}
+	return "normal"
+}
+
+// cssFont returns the canvas font string for a face name, which includes the
+// fallback families of the face.
+func (s *Surface) cssFont(name string, size int) string {
+	face := &font.Face{Family: name, Style: font.Normal, Weight: font.Regular}
+	for _, candidate := range s.Fonts().Faces() {
+		if candidate.Name == name {
+			face = candidate
+		}
+	}
+	families := strconv.Quote(face.Family)
+	for _, fallback := range s.Fonts().Fallbacks(face.Family) {
+		families += ", " + strconv.Quote(fallback)
+	}
+	return cssFontStyle(face.Style) + " " + strconv.Itoa(face.Weight) + " " + strconv.Itoa(size) + "px " + families + ", sans-serif"
 }
 
 func (s *Surface) SetFontSize(size float64) {
@@ -155,11 +389,39 @@ func (s *Surface) TextBounds(face string, size float64, text string) (x, y, w, h
 func (s *Surface) Text(x float64, y float64, text string) {
 	// maxWidth required for canvas filltext, but not nanovgo?
 	maxWidth := 10000000.0
-	s.context.Font = strconv.Itoa(s.lastFontSize) + "px \"Open Sans\", sans-serif"
+	s.loadFonts()
+	s.context.Font = s.cssFont(s.lastFontFace, s.lastFontSize)
 	// TODO(lbayes): Add validation that ensures required calls have been made before calling this function (e.g., SetFontFace)
 	s.context.FillText(text, x, y, maxWidth)
 }
 
+// Shaper returns a text Shaper that sizes text by em, like CSS.
+func (s *Surface) Shaper() text.Shaper {
+	if s.shaper == nil {
+		s.shaper = text.NewShaper(s.Fonts())
+	}
+	return s.shaper
+}
+
+// DrawGlyphs draws each run of the shaped line in its direction. The canvas
+// shapes the characters of each run itself, while the runs are positioned in
+// the visual order that the Shaper resolved.
+func (s *Surface) DrawGlyphs(x, y float64, line text.Line) {
+	maxWidth := 10000000.0
+	s.loadFonts()
+	s.context.Font = s.cssFont(s.lastFontFace, s.lastFontSize)
+	s.context.Set("textAlign", "left")
+	for _, run := range line.Runs {
+		direction := "ltr"
+		if run.Direction == text.RightToLeft {
+			direction = "rtl"
+		}
+		s.context.Set("direction", direction)
+		s.context.FillText(run.Text, x+run.X, y, maxWidth)
+	}
+	s.context.Set("direction", "inherit")
+}
+
 // NewCanvasFromJsObject will wrap the provided GopherJs element with the
 // Canvas wrapper provided by oska.
 func NewCanvasFromJsObject(element *js.Object) *jsCanvas.Canvas {
@@ -173,7 +435,7 @@ func NewSurface(canvas ExternalCanvas, options ...SurfaceOption) *Surface {
 		panic("Surface(Canvas(...)) is required")
 	}
 
-	s := &Surface{canvas: canvas}
+	s := &Surface{canvas: canvas, fillRule: "nonzero"}
 
 	for _, option := range options {
 		option(s)
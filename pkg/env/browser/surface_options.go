@@ -1,9 +1,13 @@
 package browser
 
+import "github.com/waybeams/waybeams/pkg/font"
+
 type SurfaceOption func(s *Surface)
 
-func AddFont(name, path string) SurfaceOption {
+// Fonts configures the font Registry that is used to resolve and load font
+// faces.
+func Fonts(registry *font.Registry) SurfaceOption {
 	return func(s *Surface) {
-		s.AddFont(name, path)
+		s.registry = registry
 	}
 }
@@ -4,6 +4,7 @@ import (
 	"github.com/gopherjs/gopherjs/js"
 	dom "github.com/oskca/gopherjs-dom"
 	"github.com/waybeams/waybeams/pkg/events"
+	"github.com/waybeams/waybeams/pkg/input"
 	"github.com/waybeams/waybeams/pkg/spec"
 )
 
@@ -17,9 +18,14 @@ type window struct {
 
 	browserWindow        *js.Object
 	wrappedBrowserWindow *dom.Win
+	cursorX              float64
+	cursorY              float64
 	frameRate            int
 	height               float64
+	input                *input.Controller
+	isComposing          bool
 	pixelRatio           float64
+	textInput            *js.Object
 	title                string
 	titleChanged         bool
 	width                float64
@@ -42,12 +48,9 @@ func (w *window) FrameRate() int {
 	return w.frameRate
 }
 
-func (w *window) GetCursorPos() (x, y float64) {
-	return 0.0, 0.0
-}
-
 func (w *window) Init() {
 	w.wrappedBrowserWindow = dom.WrapWindow(w.browserWindow)
+	w.initInput()
 }
 
 func (w *window) OnResize(handler events.EventHandler) events.Unsubscriber {
@@ -100,7 +103,7 @@ func (w *window) Title() string {
 }
 
 func (w *window) UpdateInput(root spec.ReadWriter) {
-	//panic("canvas.Window UpdateInput not implemented")
+	w.input.Update(root)
 }
 
 func NewWindow(options ...WindowOption) *window {
@@ -0,0 +1,134 @@
+package fake
+
+import (
+	"strings"
+	"time"
+
+	"github.com/waybeams/waybeams/pkg/clock"
+	"github.com/waybeams/waybeams/pkg/input"
+	"github.com/waybeams/waybeams/pkg/scheduler"
+	"github.com/waybeams/waybeams/pkg/spec"
+)
+
+const DefaultDriverWidth = 800
+const DefaultDriverHeight = 600
+
+// Driver runs a Scheduler against the fake Window, fake Surface and a fake
+// Clock so that tests can script user gestures and make assertions about
+// the resulting Spec tree without a native environment.
+type Driver struct {
+	clock     clock.Fake
+	scheduler *scheduler.Scheduler
+	surface   *Fake
+	window    *FakeWindow
+}
+
+// AdvanceFrames moves the fake clock forward by one frame duration and
+// executes a Scheduler frame, n times.
+func (d *Driver) AdvanceFrames(n int) {
+	perFrame := time.Second / time.Duration(d.window.FrameRate())
+	for i := 0; i < n; i++ {
+		d.clock.Add(perFrame)
+		d.scheduler.Step()
+	}
+}
+
+// Clock returns the fake clock that the Scheduler is running against.
+func (d *Driver) Clock() clock.Fake {
+	return d.clock
+}
+
+// Click hovers over the matching Spec and then presses and releases the
+// primary mouse button.
+func (d *Driver) Click(selectorOrKey string) {
+	d.Hover(selectorOrKey)
+	gestures := d.window.Gestures()
+	gestures.PressButton(input.MouseButton1)
+	gestures.ReleaseButton(input.MouseButton1)
+	d.AdvanceFrames(1)
+}
+
+// Close closes the Scheduler, Window and Surface.
+func (d *Driver) Close() {
+	d.scheduler.Close()
+}
+
+// Find returns the Spec in the current tree that matches the provided
+// selector. Selectors that begin with "/" are compared with spec.Path, all
+// others are treated as a Key.
+func (d *Driver) Find(selectorOrKey string) spec.ReadWriter {
+	root := d.Root()
+	if strings.HasPrefix(selectorOrKey, "/") {
+		return spec.FirstByPath(root, selectorOrKey)
+	}
+	return spec.FirstByKey(root, selectorOrKey)
+}
+
+// Hover moves the cursor to the center of the matching Spec and runs a frame
+// so that Entered, Exited and Moved events are delivered.
+func (d *Driver) Hover(selectorOrKey string) {
+	target := d.Find(selectorOrKey)
+	if target == nil {
+		panic("fake.Driver unable to find Spec for: " + selectorOrKey)
+	}
+	x, y := spec.LocalToGlobal(target, target.Width()/2, target.Height()/2)
+	d.window.Gestures().SetCursorPos(x, y)
+	d.AdvanceFrames(1)
+}
+
+// Press sends a press and release of the provided key to the focused Spec.
+func (d *Driver) Press(key input.Key) {
+	gestures := d.window.Gestures()
+	gestures.Key(key, input.Press)
+	gestures.Key(key, input.Release)
+	d.AdvanceFrames(1)
+}
+
+// Scheduler returns the Scheduler that is being driven.
+func (d *Driver) Scheduler() *scheduler.Scheduler {
+	return d.scheduler
+}
+
+// Root returns the Spec tree that was most recently rendered.
+func (d *Driver) Root() spec.ReadWriter {
+	return d.scheduler.Root()
+}
+
+// Surface returns the fake Surface that frames are drawn into.
+func (d *Driver) Surface() *Fake {
+	return d.surface
+}
+
+// Type sends each character of the provided text to the focused Spec.
+func (d *Driver) Type(text string) {
+	gestures := d.window.Gestures()
+	for _, char := range text {
+		gestures.Char(char)
+	}
+	d.AdvanceFrames(1)
+}
+
+// Window returns the fake Window that the Scheduler is running against.
+func (d *Driver) Window() *FakeWindow {
+	return d.window
+}
+
+// NewDriver creates a Scheduler for the provided factory and renders the
+// first frame.
+func NewDriver(factory spec.Factory) *Driver {
+	win := NewWindow()
+	win.SetWidth(DefaultDriverWidth)
+	win.SetHeight(DefaultDriverHeight)
+	surface := NewSurface()
+	fakeClock := clock.NewFake()
+
+	d := &Driver{
+		clock:     fakeClock,
+		scheduler: scheduler.New(win, surface, factory, fakeClock),
+		surface:   surface,
+		window:    win,
+	}
+
+	d.scheduler.Step()
+	return d
+}
@@ -0,0 +1,120 @@
+package fake_test
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/waybeams/assert"
+	"github.com/waybeams/waybeams/pkg/ctrl"
+	"github.com/waybeams/waybeams/pkg/env/fake"
+	"github.com/waybeams/waybeams/pkg/events"
+	"github.com/waybeams/waybeams/pkg/input"
+	"github.com/waybeams/waybeams/pkg/opts"
+	"github.com/waybeams/waybeams/pkg/spec"
+)
+
+type counterModel struct {
+	count     int
+	submitted string
+	text      string
+}
+
+func (c *counterModel) Increment() {
+	c.count++
+}
+
+func (c *counterModel) UpdateText(text string) {
+	c.text = text
+}
+
+func createCounter(model *counterModel) spec.Factory {
+	return func() spec.ReadWriter {
+		return ctrl.VBox(
+			opts.Key("root"),
+			opts.Child(ctrl.Label(
+				opts.Key("count"),
+				opts.Text(strconv.Itoa(model.count)),
+			)),
+			opts.Child(ctrl.Button(
+				opts.Key("increment"),
+				opts.Text("Increment"),
+				opts.OnClick(events.EmptyHandler(model.Increment)),
+			)),
+			opts.Child(ctrl.Form(
+				opts.On(events.Submitted, func(e events.Event) {
+					model.submitted = model.text
+				}),
+				opts.Child(ctrl.TextInput(
+					opts.Key("input"),
+					opts.Width(200),
+					opts.Text(model.text),
+					opts.On(events.TextChanged, events.StringPayload(model.UpdateText)),
+				)),
+			)),
+		)
+	}
+}
+
+func TestDriver(t *testing.T) {
+	t.Run("Renders first frame", func(t *testing.T) {
+		driver := fake.NewDriver(createCounter(&counterModel{}))
+		defer driver.Close()
+
+		assert.NotNil(driver.Root())
+		assert.Equal(driver.Root().Key(), "root")
+		assert.Equal(driver.Root().Width(), fake.DefaultDriverWidth)
+	})
+
+	t.Run("Click", func(t *testing.T) {
+		model := &counterModel{}
+		driver := fake.NewDriver(createCounter(model))
+		defer driver.Close()
+
+		driver.Click("increment")
+		driver.Click("increment")
+		assert.Equal(model.count, 2)
+		assert.Equal(driver.Find("count").Text(), "2")
+	})
+
+	t.Run("Click by path", func(t *testing.T) {
+		model := &counterModel{}
+		driver := fake.NewDriver(createCounter(model))
+		defer driver.Close()
+
+		driver.Click("/root/increment")
+		assert.Equal(model.count, 1)
+	})
+
+	t.Run("Hover", func(t *testing.T) {
+		driver := fake.NewDriver(createCounter(&counterModel{}))
+		defer driver.Close()
+
+		driver.Hover("increment")
+		assert.Equal(driver.Window().Gestures().CursorName, input.HandCursor)
+	})
+
+	t.Run("Type and Press", func(t *testing.T) {
+		model := &counterModel{}
+		driver := fake.NewDriver(createCounter(model))
+		defer driver.Close()
+
+		driver.Click("input")
+		driver.Type("abcd")
+		assert.Equal(model.text, "abcd")
+		assert.Equal(driver.Find("input").Text(), "abcd")
+
+		driver.Press(input.KeyEnter)
+		assert.Equal(model.submitted, "abcd")
+	})
+
+	t.Run("AdvanceFrames moves the clock", func(t *testing.T) {
+		driver := fake.NewDriver(createCounter(&counterModel{}))
+		defer driver.Close()
+
+		before := driver.Clock().Now()
+		driver.AdvanceFrames(12)
+		perFrame := time.Second / fake.DefaultFrameRate
+		assert.Equal(driver.Clock().Since(before), 12*perFrame)
+	})
+}
@@ -0,0 +1,126 @@
+package fake
+
+import (
+	"math"
+
+	"github.com/waybeams/waybeams/pkg/font"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+
+	xfont "golang.org/x/image/font"
+)
+
+// textMetrics measures text from the parsed TrueType data of registered font
+// faces. Measurements follow the scaling and rounding rules of fontstash (the
+// text renderer used by NanoVG), so that the results match the nano Surface.
+type textMetrics struct {
+	buffer sfnt.Buffer
+	parsed map[string]*sfnt.Font
+}
+
+// parse returns the parsed font for the provided face, or nil if the face
+// has no data or cannot be parsed.
+func (m *textMetrics) parse(face *font.Face) *sfnt.Font {
+	if m.parsed == nil {
+		m.parsed = map[string]*sfnt.Font{}
+	}
+	f, ok := m.parsed[face.Name]
+	if !ok {
+		if face.Data != nil {
+			f, _ = sfnt.Parse(face.Data)
+		}
+		if f != nil {
+			if ascent, descent, _ := m.verticalMetrics(f); ascent+descent <= 0 {
+				f = nil
+			}
+		}
+		// Store failures too, so that invalid data is only parsed once.
+		m.parsed[face.Name] = f
+	}
+	return f
+}
+
+// Bounds returns the same values as the nano Surface TextBounds for the
+// provided face (and its fallbacks). The final value is false if the face
+// could not be parsed.
+func (m *textMetrics) Bounds(registry *font.Registry, face *font.Face, size float64, text string) (x, y, w, h float64, ok bool) {
+	primary := m.parse(face)
+	if primary == nil {
+		return 0, 0, 0, 0, false
+	}
+	fonts := []*sfnt.Font{primary}
+	for _, fallback := range registry.FallbackFaces(face) {
+		if f := m.parse(fallback); f != nil {
+			fonts = append(fonts, f)
+		}
+	}
+
+	// fontstash stores sizes in tenths of a pixel.
+	size = float64(int(size*10)) / 10
+
+	ascent, descent, lineHeight := m.verticalMetrics(primary)
+	h = size * lineHeight / (ascent + descent)
+
+	var previous sfnt.GlyphIndex
+	var previousFont *sfnt.Font
+	for _, r := range text {
+		f, index := m.glyph(fonts, r)
+		ppem := unitsPerEm(f)
+		ascent, descent, _ := m.verticalMetrics(f)
+		scale := size / (ascent + descent)
+
+		if previousFont == f {
+			kern, err := f.Kern(&m.buffer, previous, index, ppem, xfont.HintingNone)
+			if err == nil {
+				w += float64(int(toFloat(kern)*scale + 0.5))
+			}
+		}
+		bounds, advance, err := f.GlyphBounds(&m.buffer, index, ppem, xfont.HintingNone)
+		if err != nil {
+			previousFont = nil
+			continue
+		}
+		// Glyphs are rasterized into whole pixel boxes with a one pixel
+		// border, and drawn at whole pixel offsets.
+		x = math.Min(x, float64(int(w+math.Floor(toFloat(bounds.Min.X)*scale)-1)))
+		y = math.Min(y, float64(int(math.Floor(toFloat(bounds.Min.Y)*scale)-1)))
+		// Advances are stored in tenths of a pixel, and rounded when applied.
+		w += float64(int(float64(int16(toFloat(advance)*scale*10))/10 + 0.5))
+
+		previous = index
+		previousFont = f
+	}
+	return x, y, w, h, true
+}
+
+// glyph returns the first font that includes the provided rune, along with
+// the index of the glyph. If no font includes the rune, the primary font and
+// its missing glyph are returned.
+func (m *textMetrics) glyph(fonts []*sfnt.Font, r rune) (*sfnt.Font, sfnt.GlyphIndex) {
+	for _, f := range fonts {
+		index, err := f.GlyphIndex(&m.buffer, r)
+		if err == nil && index != 0 {
+			return f, index
+		}
+	}
+	return fonts[0], 0
+}
+
+// verticalMetrics returns the ascent, descent (as a positive value) and line
+// height of the provided font, in font units.
+func (m *textMetrics) verticalMetrics(f *sfnt.Font) (ascent, descent, lineHeight float64) {
+	metrics, err := f.Metrics(&m.buffer, unitsPerEm(f), xfont.HintingNone)
+	if err != nil {
+		return 0, 0, 0
+	}
+	return toFloat(metrics.Ascent), toFloat(metrics.Descent), toFloat(metrics.Height)
+}
+
+// unitsPerEm returns a ppem that causes sfnt to report values in font units.
+func unitsPerEm(f *sfnt.Font) fixed.Int26_6 {
+	return fixed.I(int(f.UnitsPerEm()))
+}
+
+func toFloat(value fixed.Int26_6) float64 {
+	return float64(value) / 64
+}
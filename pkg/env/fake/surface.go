@@ -1,7 +1,14 @@
 package fake
 
 import (
+	"io/ioutil"
 	"math"
+
+	"github.com/waybeams/waybeams/pkg/font"
+	"github.com/waybeams/waybeams/pkg/helpers"
+	"github.com/waybeams/waybeams/pkg/spec"
+	"github.com/waybeams/waybeams/pkg/text"
+	roboto "github.com/waybeams/waybeams/third_party/fonts/Roboto"
 )
 
 // Command stores method name and arguments for a given call.
@@ -15,13 +22,27 @@ type Command struct {
 // will simply record that they were called and with what arguments.
 type Fake struct {
 	commands []Command
+	fonts    *font.Registry
+	images   map[string][2]float64
+	metrics  *textMetrics
+	shaper   text.Shaper
 	width    float64
 	height   float64
 }
 
-func (s *Fake) AddFont(name, path string) {
+func (s *Fake) AddFont(name, path string) error {
 	args := []interface{}{name, path}
 	s.commands = append(s.commands, Command{Name: "AddFont", Args: args})
+	return s.Fonts().Add(name, font.Regular, font.Normal, path)
+}
+
+// Fonts returns the font Registry for this surface, which includes the
+// embedded Roboto family.
+func (s *Fake) Fonts() *font.Registry {
+	if s.fonts == nil {
+		s.fonts = roboto.NewRegistry()
+	}
+	return s.fonts
 }
 
 func (s *Fake) Init() {
@@ -46,12 +67,90 @@ func (s *Fake) GetCommands() []Command {
 	return s.commands
 }
 
+// CommandNames returns the name of each command, in the order that they were
+// made.
+func (s *Fake) CommandNames() []string {
+	names := []string{}
+	for _, command := range s.commands {
+		names = append(names, command.Name)
+	}
+	return names
+}
+
+// CommandsNamed returns the commands with the provided name, in the order
+// that they were made.
+func (s *Fake) CommandsNamed(name string) []Command {
+	result := []Command{}
+	for _, command := range s.commands {
+		if command.Name == name {
+			result = append(result, command)
+		}
+	}
+	return result
+}
+
 // CreateFont creates and caches the font atlas.
 func (s *Fake) CreateFont(name, path string) {
 	args := []interface{}{name, path}
 	s.commands = append(s.commands, Command{Name: "CreateFont", Args: args})
 }
 
+// CreateImage reads the header of the image at path so that ImageSize will
+// report the intrinsic size, and records the call.
+func (s *Fake) CreateImage(name, path string) error {
+	if _, ok := s.images[name]; ok {
+		return nil
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	args := []interface{}{name, path}
+	s.commands = append(s.commands, Command{Name: "CreateImage", Args: args})
+	return s.addImage(name, data)
+}
+
+// CreateImageFromBytes reads the header of the provided image data so that
+// ImageSize will report the intrinsic size, and records the call.
+func (s *Fake) CreateImageFromBytes(name string, data []byte) error {
+	if _, ok := s.images[name]; ok {
+		return nil
+	}
+	args := []interface{}{name}
+	s.commands = append(s.commands, Command{Name: "CreateImageFromBytes", Args: args})
+	return s.addImage(name, data)
+}
+
+func (s *Fake) addImage(name string, data []byte) error {
+	width, height, _, err := helpers.ImageConfig(data)
+	if err != nil {
+		return err
+	}
+	if s.images == nil {
+		s.images = map[string][2]float64{}
+	}
+	s.images[name] = [2]float64{float64(width), float64(height)}
+	return nil
+}
+
+// DrawImage stores the named image and destination box.
+func (s *Fake) DrawImage(name string, x, y, width, height float64) {
+	args := []interface{}{name, x, y, width, height}
+	s.commands = append(s.commands, Command{Name: "DrawImage", Args: args})
+}
+
+// DrawImageRegion stores the named image, source region and destination box.
+func (s *Fake) DrawImageRegion(name string, srcX, srcY, srcWidth, srcHeight, x, y, width, height float64) {
+	args := []interface{}{name, srcX, srcY, srcWidth, srcHeight, x, y, width, height}
+	s.commands = append(s.commands, Command{Name: "DrawImageRegion", Args: args})
+}
+
+// ImageSize returns the intrinsic size of a previously created image.
+func (s *Fake) ImageSize(name string) (width, height float64, ok bool) {
+	size, ok := s.images[name]
+	return size[0], size[1], ok
+}
+
 // SetFillColor stores the provided Hex RGBA fill color (e.g., 0xffcc00ff).
 func (s *Fake) SetFillColor(color uint) {
 	args := []interface{}{color}
@@ -69,6 +168,65 @@ func (s *Fake) MoveTo(x float64, y float64) {
 	s.commands = append(s.commands, Command{Name: "MoveTo", Args: args})
 }
 
+func (s *Fake) LineTo(x float64, y float64) {
+	args := []interface{}{x, y}
+	s.commands = append(s.commands, Command{Name: "LineTo", Args: args})
+}
+
+func (s *Fake) BezierTo(c1x, c1y, c2x, c2y, x, y float64) {
+	args := []interface{}{c1x, c1y, c2x, c2y, x, y}
+	s.commands = append(s.commands, Command{Name: "BezierTo", Args: args})
+}
+
+func (s *Fake) QuadTo(cx, cy, x, y float64) {
+	args := []interface{}{cx, cy, x, y}
+	s.commands = append(s.commands, Command{Name: "QuadTo", Args: args})
+}
+
+func (s *Fake) ClosePath() {
+	s.commands = append(s.commands, Command{Name: "ClosePath"})
+}
+
+func (s *Fake) Ellipse(cx, cy, rx, ry float64) {
+	args := []interface{}{cx, cy, rx, ry}
+	s.commands = append(s.commands, Command{Name: "Ellipse", Args: args})
+}
+
+func (s *Fake) PathWinding(winding spec.Winding) {
+	args := []interface{}{winding}
+	s.commands = append(s.commands, Command{Name: "PathWinding", Args: args})
+}
+
+// RoundedRectVarying stores a rectangle with a radius for each corner.
+func (s *Fake) RoundedRectVarying(x, y, width, height, topLeft, topRight, bottomRight, bottomLeft float64) {
+	args := []interface{}{x, y, width, height, topLeft, topRight, bottomRight, bottomLeft}
+	s.commands = append(s.commands, Command{Name: "RoundedRectVarying", Args: args})
+}
+
+// SetFillBoxGradient stores the provided box gradient configuration.
+func (s *Fake) SetFillBoxGradient(x, y, width, height, radius, feather float64, innerColor, outerColor uint) {
+	args := []interface{}{x, y, width, height, radius, feather, innerColor, outerColor}
+	s.commands = append(s.commands, Command{Name: "SetFillBoxGradient", Args: args})
+}
+
+// SetFillLinearGradient stores the provided linear gradient configuration.
+func (s *Fake) SetFillLinearGradient(startX, startY, endX, endY float64, startColor, endColor uint) {
+	args := []interface{}{startX, startY, endX, endY, startColor, endColor}
+	s.commands = append(s.commands, Command{Name: "SetFillLinearGradient", Args: args})
+}
+
+// SetFillRadialGradient stores the provided radial gradient configuration.
+func (s *Fake) SetFillRadialGradient(centerX, centerY, innerRadius, outerRadius float64, innerColor, outerColor uint) {
+	args := []interface{}{centerX, centerY, innerRadius, outerRadius, innerColor, outerColor}
+	s.commands = append(s.commands, Command{Name: "SetFillRadialGradient", Args: args})
+}
+
+// SetGlobalAlpha stores the provided opacity (0.0 - 1.0).
+func (s *Fake) SetGlobalAlpha(alpha float64) {
+	args := []interface{}{alpha}
+	s.commands = append(s.commands, Command{Name: "SetGlobalAlpha", Args: args})
+}
+
 // SetStrokeWidth sets the stroke width
 func (s *Fake) SetStrokeWidth(width float64) {
 	args := []interface{}{width}
@@ -151,6 +309,13 @@ func (s *Fake) Height() float64 {
 func (s *Fake) TextBounds(face string, size float64, text string) (x, y, w, h float64) {
 	args := []interface{}{face, size, text}
 	s.commands = append(s.commands, Command{Name: "Text", Args: args})
+	if s.metrics != nil {
+		if resolved, ok := s.Fonts().Named(face); ok {
+			if x, y, w, h, ok := s.metrics.Bounds(s.Fonts(), resolved, size, text); ok {
+				return x, y, w, h
+			}
+		}
+	}
 	// NOTE(lbayes): The following text metrics are fake magic values that obviously
 	// will not kern or layout properly, but are sufficient to get boxes in the test
 	// environment.
@@ -161,7 +326,27 @@ func (s *Fake) TextBounds(face string, size float64, text string) (x, y, w, h fl
 	return x, y, w, h
 }
 
-// NewSurface returns a new fake Surface.
-func NewSurface() *Fake {
-	return &Fake{}
+// Shaper returns a text Shaper that sizes text the way the nano Surface does.
+func (s *Fake) Shaper() text.Shaper {
+	if s.shaper == nil {
+		s.shaper = text.NewShaper(s.Fonts(), text.PixelHeight())
+	}
+	return s.shaper
+}
+
+// DrawGlyphs stores the position and the shaped line.
+func (s *Fake) DrawGlyphs(x, y float64, line text.Line) {
+	args := []interface{}{x, y, line}
+	s.commands = append(s.commands, Command{Name: "DrawGlyphs", Args: args})
+}
+
+// NewSurface returns a new fake Surface. Unless the FontMetrics option is
+// provided, text is measured with approximate values.
+func NewSurface(options ...Option) *Fake {
+	s := &Fake{}
+
+	for _, option := range options {
+		option(s)
+	}
+	return s
 }
@@ -0,0 +1,24 @@
+package fake
+
+import (
+	"github.com/waybeams/waybeams/pkg/font"
+)
+
+type Option func(s *Fake)
+
+// FontMetrics configures the Surface to measure text with the advance widths,
+// kerning and vertical metrics of the registered font faces, rather than
+// with approximate values. Layouts measured this way match the nano Surface.
+func FontMetrics() Option {
+	return func(s *Fake) {
+		s.metrics = &textMetrics{}
+	}
+}
+
+// Fonts configures the font Registry that is used to resolve and measure
+// font faces.
+func Fonts(registry *font.Registry) Option {
+	return func(s *Fake) {
+		s.fonts = registry
+	}
+}
@@ -5,6 +5,8 @@ import (
 
 	"github.com/waybeams/assert"
 	"github.com/waybeams/waybeams/pkg/env/fake"
+	"github.com/waybeams/waybeams/pkg/fakes"
+	"github.com/waybeams/waybeams/pkg/font"
 )
 
 func TestFakeSurface(t *testing.T) {
@@ -37,4 +39,93 @@ func TestFakeSurface(t *testing.T) {
 		assert.Equal(cmds[1].Name, "Height")
 		assert.Equal(len(cmds[1].Args), 0)
 	})
+
+	t.Run("Images", func(t *testing.T) {
+		t.Run("Reports size from bytes", func(t *testing.T) {
+			s := fake.NewSurface()
+			err := s.CreateImageFromBytes("icon", fakes.PNG(12, 8))
+			assert.Nil(err)
+			w, h, ok := s.ImageSize("icon")
+			assert.True(ok)
+			assert.Equal(w, 12.0)
+			assert.Equal(h, 8.0)
+		})
+
+		t.Run("Caches by name", func(t *testing.T) {
+			s := fake.NewSurface()
+			s.CreateImageFromBytes("icon", fakes.PNG(12, 8))
+			s.CreateImageFromBytes("icon", fakes.PNG(24, 24))
+			w, _, _ := s.ImageSize("icon")
+			assert.Equal(w, 12.0)
+			assert.Equal(len(s.GetCommands()), 1)
+		})
+
+		t.Run("Returns errors", func(t *testing.T) {
+			s := fake.NewSurface()
+			assert.NotNil(s.CreateImage("missing", "/does/not/exist.png"))
+			assert.NotNil(s.CreateImageFromBytes("invalid", []byte("abcd")))
+			_, _, ok := s.ImageSize("invalid")
+			assert.False(ok)
+		})
+	})
+
+	t.Run("TextBounds", func(t *testing.T) {
+		t.Run("Approximates by default", func(t *testing.T) {
+			s := fake.NewSurface()
+			x, y, w, h := s.TextBounds("Roboto", 24, "Hello World")
+			assert.Equal(x, -0.5)
+			assert.Equal(y, -24.0)
+			assert.Equal(w, 111.0)
+			assert.Equal(h, 24.0)
+		})
+
+		t.Run("Measures with font metrics", func(t *testing.T) {
+			s := fake.NewSurface(fake.FontMetrics())
+			x, y, w, h := s.TextBounds("Roboto", 24, "Hello World")
+			assert.Equal(x, 0.0)
+			assert.Equal(y, -17.0)
+			assert.Equal(w, 107.0)
+			assert.Equal(h, 24.0)
+		})
+
+		t.Run("Records commands", func(t *testing.T) {
+			s := fake.NewSurface(fake.FontMetrics())
+			s.TextBounds("Roboto", 24, "abc")
+			cmds := s.GetCommands()
+			assert.Equal(len(cmds), 1)
+			assert.Equal(cmds[0].Name, "Text")
+			assert.Equal(cmds[0].Args[0], "Roboto")
+			assert.Equal(cmds[0].Args[2], "abc")
+		})
+
+		t.Run("Applies kerning", func(t *testing.T) {
+			s := fake.NewSurface(fake.FontMetrics())
+			_, _, a, _ := s.TextBounds("Roboto", 48, "A")
+			_, _, v, _ := s.TextBounds("Roboto", 48, "V")
+			_, _, av, _ := s.TextBounds("Roboto", 48, "AV")
+			assert.True(av < a+v)
+		})
+
+		t.Run("Measures each weight", func(t *testing.T) {
+			s := fake.NewSurface(fake.FontMetrics())
+			_, _, light, _ := s.TextBounds("Roboto-300", 24, "Hello World")
+			_, _, bold, _ := s.TextBounds("Roboto-700", 24, "Hello World")
+			assert.True(light < bold)
+		})
+
+		t.Run("Resolves unknown faces", func(t *testing.T) {
+			s := fake.NewSurface(fake.FontMetrics())
+			_, _, expected, _ := s.TextBounds("Roboto", 24, "Hello World")
+			_, _, w, _ := s.TextBounds("Helvetica", 24, "Hello World")
+			assert.Equal(w, expected)
+		})
+
+		t.Run("Approximates faces without data", func(t *testing.T) {
+			registry := font.NewRegistry()
+			registry.AddURL("Remote", font.Regular, font.Normal, "/fonts/remote.ttf")
+			s := fake.NewSurface(fake.Fonts(registry), fake.FontMetrics())
+			_, _, w, _ := s.TextBounds("Remote", 24, "Hello World")
+			assert.Equal(w, 111.0)
+		})
+	})
 }
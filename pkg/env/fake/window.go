@@ -1,9 +1,8 @@
 package fake
 
 import (
-	"github.com/go-gl/glfw/v3.2/glfw"
-	g "github.com/waybeams/waybeams/pkg/env/glfw"
 	"github.com/waybeams/waybeams/pkg/events"
+	"github.com/waybeams/waybeams/pkg/input"
 	"github.com/waybeams/waybeams/pkg/spec"
 )
 
@@ -14,6 +13,8 @@ type FakeWindow struct {
 	height     float64
 	pixelRatio float64
 	frameRate  int
+	gestures   *FakeGestureSource
+	input      *input.Controller
 }
 
 func (f *FakeWindow) Init() {
@@ -64,21 +65,37 @@ func (f *FakeWindow) ShouldClose() bool {
 	return false
 }
 
+// Gestures returns the scriptable GestureSource that feeds this window's
+// input controller.
+func (f *FakeWindow) Gestures() *FakeGestureSource {
+	return f.gestures
+}
+
 func (f *FakeWindow) UpdateInput(root spec.ReadWriter) {
+	f.input.Update(root)
 }
 
 func NewWindow() *FakeWindow {
-	return &FakeWindow{}
+	gestures := NewFakeGestureSource()
+	return &FakeWindow{
+		gestures: gestures,
+		input:    input.New(gestures),
+	}
 }
 
-// FakeGestureSource is a minimal struct that is used for testing Gestures.
+// FakeGestureSource is a minimal input.GestureSource that is used for
+// testing Gestures.
 type FakeGestureSource struct {
-	xpos          float64
-	ypos          float64
-	CursorName    glfw.StandardCursor
-	CharCallback  spec.CharCallback
-	KeyCallback   g.KeyCallback
-	MouseCallback g.MouseButtonCallback
+	xpos                float64
+	ypos                float64
+	CursorName          input.Cursor
+	CharCallback        input.CharCallback
+	CompositionCallback input.CompositionCallback
+	IsTextInputActive   bool
+	KeyCallback         input.KeyCallback
+	MouseCallback       input.MouseButtonCallback
+	// TextInputRect is the last rectangle provided to SetTextInputRect.
+	TextInputRect [4]float64
 }
 
 func (f *FakeGestureSource) SetCursorPos(xpos, ypos float64) {
@@ -90,11 +107,11 @@ func (f *FakeGestureSource) GetCursorPos() (xpos, ypos float64) {
 	return f.xpos, f.ypos
 }
 
-func (f *FakeGestureSource) SetCursorByName(name glfw.StandardCursor) {
+func (f *FakeGestureSource) SetCursorByName(name input.Cursor) {
 	f.CursorName = name
 }
 
-func (f *FakeGestureSource) SetKeyCallback(callback g.KeyCallback) events.Unsubscriber {
+func (f *FakeGestureSource) SetKeyCallback(callback input.KeyCallback) events.Unsubscriber {
 	f.KeyCallback = callback
 	return func() bool {
 		f.KeyCallback = nil
@@ -102,7 +119,7 @@ func (f *FakeGestureSource) SetKeyCallback(callback g.KeyCallback) events.Unsubs
 	}
 }
 
-func (f *FakeGestureSource) SetCharCallback(callback spec.CharCallback) events.Unsubscriber {
+func (f *FakeGestureSource) SetCharCallback(callback input.CharCallback) events.Unsubscriber {
 	f.CharCallback = callback
 	return func() bool {
 		f.CharCallback = nil
@@ -110,7 +127,27 @@ func (f *FakeGestureSource) SetCharCallback(callback spec.CharCallback) events.U
 	}
 }
 
-func (f *FakeGestureSource) SetMouseButtonCallback(callback g.MouseButtonCallback) events.Unsubscriber {
+func (f *FakeGestureSource) SetCompositionCallback(callback input.CompositionCallback) events.Unsubscriber {
+	f.CompositionCallback = callback
+	return func() bool {
+		f.CompositionCallback = nil
+		return true
+	}
+}
+
+func (f *FakeGestureSource) SetTextInputRect(x, y, width, height float64) {
+	f.TextInputRect = [4]float64{x, y, width, height}
+}
+
+func (f *FakeGestureSource) StartTextInput() {
+	f.IsTextInputActive = true
+}
+
+func (f *FakeGestureSource) StopTextInput() {
+	f.IsTextInputActive = false
+}
+
+func (f *FakeGestureSource) SetMouseButtonCallback(callback input.MouseButtonCallback) events.Unsubscriber {
 	f.MouseCallback = callback
 	return func() bool {
 		f.MouseCallback = nil
@@ -118,6 +155,43 @@ func (f *FakeGestureSource) SetMouseButtonCallback(callback g.MouseButtonCallbac
 	}
 }
 
+// PressButton sends a mouse button press to the subscribed callback.
+func (f *FakeGestureSource) PressButton(button input.MouseButton) {
+	if f.MouseCallback != nil {
+		f.MouseCallback(button, input.Press, 0)
+	}
+}
+
+// ReleaseButton sends a mouse button release to the subscribed callback.
+func (f *FakeGestureSource) ReleaseButton(button input.MouseButton) {
+	if f.MouseCallback != nil {
+		f.MouseCallback(button, input.Release, 0)
+	}
+}
+
+// Char sends a single committed character to the subscribed callback.
+func (f *FakeGestureSource) Char(char rune) {
+	if f.CharCallback != nil {
+		f.CharCallback(char)
+	}
+}
+
+// Compose sends an IME composition step with the provided preedit (or
+// committed) text to the subscribed callback, with the IME cursor at the
+// end of the text.
+func (f *FakeGestureSource) Compose(action input.CompositionAction, text string) {
+	if f.CompositionCallback != nil {
+		f.CompositionCallback(action, input.Composition{Cursor: len(text), Text: text})
+	}
+}
+
+// Key sends the provided key and action to the subscribed callback.
+func (f *FakeGestureSource) Key(key input.Key, action input.Action) {
+	if f.KeyCallback != nil {
+		f.KeyCallback(key, 0, action, 0)
+	}
+}
+
 func NewFakeGestureSource() *FakeGestureSource {
 	return &FakeGestureSource{}
 }
@@ -2,144 +2,101 @@ package glfw
 
 import (
 	"github.com/go-gl/glfw/v3.2/glfw"
-	"github.com/waybeams/waybeams/pkg/events"
-	"github.com/waybeams/waybeams/pkg/spec"
+	"github.com/waybeams/waybeams/pkg/input"
 )
 
-type MouseEventPayload struct {
-	Button   glfw.MouseButton
-	Action   glfw.Action
-	Modifier glfw.ModifierKey
-}
-
-type Input struct {
-	lastMoveTarget spec.ReadWriter
-	source         GestureSource
-	lastXpos       float64
-	lastYpos       float64
-	lastRoot       spec.ReadWriter
-	lastFocused    spec.ReadWriter
-}
-
-// Update should be called on every frame and will collect any pending
-// changes from the configured glfw.Window object and then bubble as events
-// into the appropriate nodes of the tree.
-func (g *Input) Update(root spec.ReadWriter) {
-	g.lastRoot = root
-
-	xpos, ypos := g.source.GetCursorPos()
-	if g.lastXpos == xpos && g.lastYpos == ypos {
-		return
+// The following functions adapt glfw gesture values into the environment
+// neutral values that are understood by input.Controller.
+
+func actionFromGlfw(action glfw.Action) input.Action {
+	switch action {
+	case glfw.Press:
+		return input.Press
+	case glfw.Repeat:
+		return input.Repeat
+	default:
+		return input.Release
 	}
-	g.lastXpos = xpos
-	g.lastYpos = ypos
-
-	target := spec.CoordToControl(root, xpos, ypos)
-	lastTarget := g.lastMoveTarget
-
-	if lastTarget != target {
-		if lastTarget != nil {
-			g.bubbleOn(lastTarget, events.New(events.Exited, lastTarget, nil))
-		}
-
-		if target.IsFocusable() {
-			cursorName := glfw.HandCursor
-			if target.IsText() || target.IsTextInput() {
-				cursorName = glfw.IBeamCursor
-			}
-			g.source.SetCursorByName(cursorName)
-
-			g.bubbleOn(target, events.New(events.Entered, target, nil))
-		} else {
-			g.source.SetCursorByName(glfw.ArrowCursor)
-		}
-	}
-
-	if target != nil {
-		g.bubbleOn(target, events.New(events.Moved, target, nil))
-	}
-	g.lastMoveTarget = target
 }
 
-func (g *Input) onMouseButtonHandler(button glfw.MouseButton, action glfw.Action, mod glfw.ModifierKey) {
-	if g.lastRoot == nil {
-		return
-	}
-
-	lastMoveTarget := g.lastMoveTarget
-	if button == glfw.MouseButton1 && lastMoveTarget != nil && lastMoveTarget.IsFocusable() {
-		payload := &MouseEventPayload{
-			Button:   button,
-			Action:   action,
-			Modifier: mod,
-		}
-
-		if action == glfw.Press {
-			g.focusSpec(lastMoveTarget)
-			g.bubbleOn(lastMoveTarget, events.New(events.Pressed, lastMoveTarget, payload))
-		} else if action == glfw.Release {
-			g.bubbleOn(lastMoveTarget, events.New(events.Released, lastMoveTarget, payload))
-			g.bubbleOn(lastMoveTarget, events.New(events.Clicked, lastMoveTarget, payload))
-		}
-	} else {
-		g.focusSpec(nil)
+func cursorToGlfw(cursor input.Cursor) glfw.StandardCursor {
+	switch cursor {
+	case input.HandCursor:
+		return glfw.HandCursor
+	case input.IBeamCursor:
+		return glfw.IBeamCursor
+	case input.CrosshairCursor:
+		return glfw.CrosshairCursor
+	case input.HResizeCursor:
+		return glfw.HResizeCursor
+	case input.VResizeCursor:
+		return glfw.VResizeCursor
+	default:
+		return glfw.ArrowCursor
 	}
 }
 
-func (g *Input) focusSpec(s spec.ReadWriter) {
-	var lastFocused spec.ReadWriter
-
-	if s != nil {
-		lastFocused = s.FocusedSpec()
-	}
-
-	if lastFocused != nil && lastFocused != s {
-		lastFocused.SetFocusedSpec(nil)
-		g.bubbleOn(lastFocused, events.New(events.Blurred, lastFocused, s))
-		g.lastFocused = nil
-	}
-	if s != nil {
-		s.SetFocusedSpec(s)
-		g.bubbleOn(s, events.New(events.Focused, s, lastFocused))
-		g.lastFocused = s
+func keyFromGlfw(key glfw.Key) input.Key {
+	switch key {
+	case glfw.KeyBackspace:
+		return input.KeyBackspace
+	case glfw.KeyDelete:
+		return input.KeyDelete
+	case glfw.KeyDown:
+		return input.KeyDown
+	case glfw.KeyEnd:
+		return input.KeyEnd
+	case glfw.KeyEnter, glfw.KeyKPEnter:
+		return input.KeyEnter
+	case glfw.KeyEscape:
+		return input.KeyEscape
+	case glfw.KeyHome:
+		return input.KeyHome
+	case glfw.KeyLeft:
+		return input.KeyLeft
+	case glfw.KeyPageDown:
+		return input.KeyPageDown
+	case glfw.KeyPageUp:
+		return input.KeyPageUp
+	case glfw.KeyRight:
+		return input.KeyRight
+	case glfw.KeySpace:
+		return input.KeySpace
+	case glfw.KeyTab:
+		return input.KeyTab
+	case glfw.KeyUp:
+		return input.KeyUp
+	default:
+		return input.KeyUnknown
 	}
-
 }
 
-func (g *Input) onCharHandler(char rune) {
-	if g.lastRoot == nil {
-		return
+func modsFromGlfw(mods glfw.ModifierKey) input.ModifierKey {
+	var result input.ModifierKey
+	if mods&glfw.ModShift != 0 {
+		result |= input.ModShift
 	}
-	focused := g.lastFocused
-	if focused != nil && focused.IsTextInput() {
-		g.bubbleOn(focused, events.New(events.CharEntered, focused, string(char)))
+	if mods&glfw.ModControl != 0 {
+		result |= input.ModControl
 	}
-}
-
-func (g *Input) onKeyHandler(key glfw.Key, scancode int, action glfw.Action, mods glfw.ModifierKey) {
-	if g.lastRoot == nil {
-		return
+	if mods&glfw.ModAlt != 0 {
+		result |= input.ModAlt
 	}
-	focused := g.lastFocused
-	if focused != nil && focused.IsTextInput() {
-		g.bubbleOn(focused, events.New(events.KeyEntered, focused, key))
-		if key == glfw.KeyEnter && action == glfw.Release {
-			g.bubbleOn(focused, events.New(events.EnterKeyReleased, focused, key))
-		}
+	if mods&glfw.ModSuper != 0 {
+		result |= input.ModSuper
 	}
+	return result
 }
 
-func (g *Input) bubbleOn(s spec.ReadWriter, event events.Event) {
-	s.Bubble(event)
-	// Also Emit an Invalidated event on the root node, but include the node
-	// that triggered it.
-	g.lastRoot.Emit(events.New(events.Invalidated, s, nil))
-}
-
-func NewInput(win GestureSource) *Input {
-	instance := &Input{source: win}
-	win.SetCharCallback(instance.onCharHandler)
-	win.SetKeyCallback(instance.onKeyHandler)
-	win.SetMouseButtonCallback(instance.onMouseButtonHandler)
-	return instance
+func mouseButtonFromGlfw(button glfw.MouseButton) input.MouseButton {
+	switch button {
+	case glfw.MouseButton1:
+		return input.MouseButton1
+	case glfw.MouseButton2:
+		return input.MouseButton2
+	case glfw.MouseButton3:
+		return input.MouseButton3
+	default:
+		return input.MouseButtonUnknown
+	}
 }
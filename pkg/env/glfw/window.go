@@ -4,6 +4,7 @@ import (
 	"github.com/go-gl/gl/v2.1/gl"
 	"github.com/go-gl/glfw/v3.2/glfw"
 	"github.com/waybeams/waybeams/pkg/events"
+	"github.com/waybeams/waybeams/pkg/input"
 	"github.com/waybeams/waybeams/pkg/spec"
 )
 
@@ -13,17 +14,6 @@ const DefaultTitle = "Default Title"
 const DefaultWidth = 800
 const ResizedEvent = "GlfwWindowResized"
 
-type KeyCallback func(key glfw.Key, scancode int, action glfw.Action, mods glfw.ModifierKey)
-type MouseButtonCallback func(button glfw.MouseButton, action glfw.Action, mod glfw.ModifierKey)
-
-type GestureSource interface {
-	GetCursorPos() (xpos, ypos float64)
-	SetCursorByName(name glfw.StandardCursor)
-	SetCharCallback(callback spec.CharCallback) events.Unsubscriber
-	SetKeyCallback(callback KeyCallback) events.Unsubscriber
-	SetMouseButtonCallback(callback MouseButtonCallback) events.Unsubscriber
-}
-
 type Option func(win *window)
 
 type WindowHint struct {
@@ -37,7 +27,7 @@ type window struct {
 	frameRate    int
 	height       float64
 	hints        []WindowHint
-	input        *Input
+	input        spec.InputController
 	nativeWindow *glfw.Window
 	pixelRatio   float64
 	title        string
@@ -178,7 +168,7 @@ func (win *window) initGl() {
 }
 
 func (win *window) initInput() {
-	win.input = NewInput(win)
+	win.input = input.New(win)
 }
 
 func (win *window) Init() {
@@ -219,18 +209,18 @@ func (win *window) GetCursorPos() (x, y float64) {
 	return win.nativeWindow.GetCursorPos()
 }
 
-func (win *window) SetCursorByName(shape glfw.StandardCursor) {
-	win.nativeWindow.SetCursor(glfw.CreateStandardCursor(shape))
+func (win *window) SetCursorByName(name input.Cursor) {
+	win.nativeWindow.SetCursor(glfw.CreateStandardCursor(cursorToGlfw(name)))
 }
 
-func (win *window) SetKeyCallback(callback KeyCallback) events.Unsubscriber {
+func (win *window) SetKeyCallback(callback input.KeyCallback) events.Unsubscriber {
 	win.nativeWindow.SetKeyCallback(func(
 		w *glfw.Window,
 		key glfw.Key,
 		scancode int,
 		action glfw.Action,
 		mods glfw.ModifierKey) {
-		callback(key, scancode, action, mods)
+		callback(keyFromGlfw(key), scancode, actionFromGlfw(action), modsFromGlfw(mods))
 	})
 	return func() bool {
 		if win.nativeWindow != nil {
@@ -241,7 +231,7 @@ func (win *window) SetKeyCallback(callback KeyCallback) events.Unsubscriber {
 	}
 }
 
-func (win *window) SetCharCallback(callback spec.CharCallback) events.Unsubscriber {
+func (win *window) SetCharCallback(callback input.CharCallback) events.Unsubscriber {
 	win.nativeWindow.SetCharCallback(func(w *glfw.Window, r rune) {
 		callback(r)
 	})
@@ -254,13 +244,13 @@ func (win *window) SetCharCallback(callback spec.CharCallback) events.Unsubscrib
 	}
 }
 
-func (win *window) SetMouseButtonCallback(callback MouseButtonCallback) events.Unsubscriber {
+func (win *window) SetMouseButtonCallback(callback input.MouseButtonCallback) events.Unsubscriber {
 	win.nativeWindow.SetMouseButtonCallback(func(
 		w *glfw.Window,
 		button glfw.MouseButton,
 		action glfw.Action,
 		mod glfw.ModifierKey) {
-		callback(button, action, mod)
+		callback(mouseButtonFromGlfw(button), actionFromGlfw(action), modsFromGlfw(mod))
 	})
 	return func() bool {
 		if win.nativeWindow != nil {
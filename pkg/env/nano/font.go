@@ -1,6 +1,8 @@
 package nano
 
 import (
+	"errors"
+
 	"github.com/waybeams/waybeams/pkg/spec"
 
 	fsm "github.com/shibukawa/nanovgo/fontstashmini"
@@ -9,6 +11,8 @@ import (
 const nvgInitFontImageSize = 512
 
 type Font struct {
+	data    []byte
+	err     error
 	name    string
 	path    string
 	created bool
@@ -31,20 +35,35 @@ func (f *Font) IsCreated() bool {
 	return f.created
 }
 
+// Err returns the error (if any) that was encountered while loading the
+// font. A Font that failed to load reports zero metrics.
+func (f *Font) Err() error {
+	f.getStash()
+	return f.err
+}
+
 func (f *Font) getStash() *fsm.FontStash {
-	if f.stash == nil {
-		f.stash = fsm.New(nvgInitFontImageSize, nvgInitFontImageSize)
-		result := f.stash.AddFont(f.name, f.path)
+	if f.stash == nil && f.err == nil {
+		stash := fsm.New(nvgInitFontImageSize, nvgInitFontImageSize)
+		var result int
+		if f.data != nil {
+			result = stash.AddFontFromMemory(f.name, f.data, 0)
+		} else {
+			result = stash.AddFont(f.name, f.path)
+		}
 		if result == -1 {
-			msg := "Unable to add font, likely bad path: " + f.path
-			panic(msg)
+			f.err = errors.New("nano: unable to load font " + f.name + ", likely bad path: " + f.path)
+			return nil
 		}
+		f.stash = stash
 	}
 	return f.stash
 }
 
 func (f *Font) SetSize(size float64) {
-	f.getStash().SetSize(float32(size))
+	if stash := f.getStash(); stash != nil {
+		stash.SetSize(float32(size))
+	}
 }
 
 func (f *Font) SetAlign(align spec.Alignment) {
@@ -64,17 +83,25 @@ func (f *Font) SetAlign(align spec.Alignment) {
 		fsa = fsm.ALIGN_MIDDLE
 
 	}
-	f.getStash().SetAlign(fsa)
+	if stash := f.getStash(); stash != nil {
+		stash.SetAlign(fsa)
+	}
 }
 
 func (f *Font) VerticalMetrics() (ascender, descender, lineHeight float64) {
 	stash := f.getStash()
+	if stash == nil {
+		return 0, 0, 0
+	}
 	a, d, l := stash.VerticalMetrics()
 	return float64(a), float64(d), float64(l)
 }
 
 func (f *Font) Bounds(value string) (width float64, bounds []float64) {
 	stash := f.getStash()
+	if stash == nil {
+		return 0, []float64{0, 0, 0, 0}
+	}
 	w, b := stash.TextBounds(0, 0, value)
 
 	return float64(w), []float64{float64(b[0]), float64(b[1]), float64(b[2]), float64(b[3])}
@@ -86,3 +113,11 @@ func NewFont(name string, path string) *Font {
 		path: path,
 	}
 }
+
+// NewFontFromBytes returns a Font that loads from the provided font data.
+func NewFontFromBytes(name string, data []byte) *Font {
+	return &Font{
+		data: data,
+		name: name,
+	}
+}
@@ -40,4 +40,12 @@ func TestFont(t *testing.T) {
 		assert.Equal(bounds[2], 24)
 		assert.Equal(bounds[3], 2)
 	})
+
+	t.Run("Reports an error instead of panicking for bad paths", func(t *testing.T) {
+		instance := nano.NewFont("abcd", "foo.ttf")
+		instance.SetSize(18)
+		w, _ := instance.Bounds("abcd")
+		assert.Equal(w, 0)
+		assert.NotNil(instance.Err())
+	})
 }
@@ -0,0 +1,60 @@
+package nano
+
+import (
+	"io/ioutil"
+
+	"github.com/shibukawa/nanovgo"
+	"github.com/waybeams/waybeams/pkg/helpers"
+)
+
+// Image holds encoded image data until the first time it is drawn, when it
+// is uploaded to the NanoVG context.
+type Image struct {
+	name   string
+	data   []byte
+	handle int
+	width  float64
+	height float64
+}
+
+func (i *Image) Name() string {
+	return i.name
+}
+
+func (i *Image) Size() (width, height float64) {
+	return i.width, i.height
+}
+
+func (i *Image) IsCreated() bool {
+	return i.handle > 0
+}
+
+func (i *Image) handleFor(context *nanovgo.Context) int {
+	if !i.IsCreated() {
+		i.handle = context.CreateImageFromMemory(0, i.data)
+		// The encoded data is no longer needed once uploaded.
+		if i.handle > 0 {
+			i.data = nil
+		}
+	}
+	return i.handle
+}
+
+// NewImage returns an Image for the provided encoded PNG, JPEG or GIF data,
+// or an error if the data cannot be decoded.
+func NewImage(name string, data []byte) (*Image, error) {
+	width, height, _, err := helpers.ImageConfig(data)
+	if err != nil {
+		return nil, err
+	}
+	return &Image{name: name, data: data, width: float64(width), height: float64(height)}, nil
+}
+
+// NewImageFromFile returns an Image for the file at the provided path.
+func NewImageFromFile(name, path string) (*Image, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewImage(name, data)
+}
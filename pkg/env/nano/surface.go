@@ -2,17 +2,25 @@ package nano
 
 import (
 	"github.com/shibukawa/nanovgo"
+	"github.com/waybeams/waybeams/pkg/font"
 	"github.com/waybeams/waybeams/pkg/helpers"
+	"github.com/waybeams/waybeams/pkg/spec"
+	"github.com/waybeams/waybeams/pkg/text"
+	roboto "github.com/waybeams/waybeams/third_party/fonts/Roboto"
 )
 
 const fakePixelRatio = float32(1.0)
 
 type Surface struct {
-	context *nanovgo.Context
-	flags   []nanovgo.CreateFlags
-	width   float64
-	height  float64
-	fonts   map[string]*Font
+	context   *nanovgo.Context
+	fallbacks map[[2]string]bool
+	flags     []nanovgo.CreateFlags
+	width     float64
+	height    float64
+	fonts     map[string]*Font
+	images    map[string]*Image
+	registry  *font.Registry
+	shaper    text.Shaper
 }
 
 func (s *Surface) Init() {
@@ -26,6 +34,11 @@ func (s *Surface) Init() {
 
 func (s *Surface) Close() {
 	if s.context != nil {
+		for _, image := range s.images {
+			if image.IsCreated() {
+				s.context.DeleteImage(image.handle)
+			}
+		}
 		s.context.Delete()
 	}
 }
@@ -46,24 +59,59 @@ func (s *Surface) getFonts() map[string]*Font {
 	return s.fonts
 }
 
-func (s *Surface) AddFont(name string, path string) {
-	fonts := s.getFonts()
-	if fonts[name] == nil {
-		fonts[name] = NewFont(name, path)
+// AddFont registers the font file at path as the regular weight and normal
+// style of the named family.
+func (s *Surface) AddFont(name string, path string) error {
+	return s.Fonts().Add(name, font.Regular, font.Normal, path)
+}
+
+// Fonts returns the font Registry for this surface, which includes the
+// embedded Roboto family unless another Registry was provided.
+func (s *Surface) Fonts() *font.Registry {
+	if s.registry == nil {
+		s.registry = roboto.NewRegistry()
 	}
+	return s.registry
 }
 
+// CreateFonts uploads any newly registered faces to the NanoVG context and
+// links each face to its fallbacks, so that missing glyphs are drawn from
+// the fallback faces.
 func (s *Surface) CreateFonts() {
-	for _, font := range s.getFonts() {
-		if !font.IsCreated() {
-			s.CreateFont(font.Name(), font.Path())
-			font.OnCreated()
+	faces := s.Fonts().Faces()
+	for _, face := range faces {
+		f := s.Font(face.Name)
+		if f != nil && !f.IsCreated() && f.Err() == nil {
+			s.context.CreateFontFromMemory(face.Name, face.Data, 0)
+			f.OnCreated()
+		}
+	}
+	if s.fallbacks == nil {
+		s.fallbacks = map[[2]string]bool{}
+	}
+	for _, face := range faces {
+		for _, fallback := range s.Fonts().FallbackFaces(face) {
+			key := [2]string{face.Name, fallback.Name}
+			if !s.fallbacks[key] {
+				s.context.AddFallbackFont(face.Name, fallback.Name)
+				s.fallbacks[key] = true
+			}
 		}
 	}
 }
 
+// Font returns the measurement Font for the provided face name, or nil if no
+// face with that name has been registered.
 func (s *Surface) Font(name string) *Font {
-	return s.getFonts()[name]
+	fonts := s.getFonts()
+	if fonts[name] == nil {
+		for _, face := range s.Fonts().Faces() {
+			if face.Name == name && face.Data != nil {
+				fonts[name] = NewFontFromBytes(name, face.Data)
+			}
+		}
+	}
+	return fonts[name]
 }
 
 func (s *Surface) SetWidth(width float64) {
@@ -82,6 +130,76 @@ func (s *Surface) Height() float64 {
 	return s.height
 }
 
+func (s *Surface) addImage(image *Image) {
+	if s.images == nil {
+		s.images = map[string]*Image{}
+	}
+	s.images[image.Name()] = image
+}
+
+func (s *Surface) CreateImage(name, path string) error {
+	if s.images[name] != nil {
+		return nil
+	}
+	image, err := NewImageFromFile(name, path)
+	if err != nil {
+		return err
+	}
+	s.addImage(image)
+	return nil
+}
+
+func (s *Surface) CreateImageFromBytes(name string, data []byte) error {
+	if s.images[name] != nil {
+		return nil
+	}
+	image, err := NewImage(name, data)
+	if err != nil {
+		return err
+	}
+	s.addImage(image)
+	return nil
+}
+
+func (s *Surface) DrawImage(name string, x, y, width, height float64) {
+	image := s.images[name]
+	if image == nil {
+		return
+	}
+	imageWidth, imageHeight := image.Size()
+	s.DrawImageRegion(name, 0, 0, imageWidth, imageHeight, x, y, width, height)
+}
+
+// DrawImageRegion fills the destination box with an image pattern that is
+// scaled and offset so that only the source region is visible.
+func (s *Surface) DrawImageRegion(name string, srcX, srcY, srcWidth, srcHeight, x, y, width, height float64) {
+	image := s.images[name]
+	if image == nil || srcWidth <= 0 || srcHeight <= 0 {
+		return
+	}
+	handle := image.handleFor(s.context)
+	imageWidth, imageHeight := image.Size()
+	scaleX := width / srcWidth
+	scaleY := height / srcHeight
+	paint := s.context.ImagePattern(
+		float32(x-srcX*scaleX), float32(y-srcY*scaleY),
+		float32(imageWidth*scaleX), float32(imageHeight*scaleY),
+		0, handle, 1)
+	s.context.BeginPath()
+	s.context.Rect(float32(x), float32(y), float32(width), float32(height))
+	s.context.SetFillPaint(paint)
+	s.context.Fill()
+}
+
+func (s *Surface) ImageSize(name string) (width, height float64, ok bool) {
+	image := s.images[name]
+	if image == nil {
+		return 0, 0, false
+	}
+	width, height = image.Size()
+	return width, height, true
+}
+
 func (s *Surface) CreateFont(name, path string) {
 	s.context.CreateFont(name, path)
 }
@@ -90,11 +208,61 @@ func (s *Surface) MoveTo(x float64, y float64) {
 	s.context.MoveTo(float32(x), float32(y))
 }
 
+func (s *Surface) LineTo(x float64, y float64) {
+	s.context.LineTo(float32(x), float32(y))
+}
+
+func (s *Surface) BezierTo(c1x, c1y, c2x, c2y, x, y float64) {
+	s.context.BezierTo(float32(c1x), float32(c1y), float32(c2x), float32(c2y), float32(x), float32(y))
+}
+
+func (s *Surface) QuadTo(cx, cy, x, y float64) {
+	s.context.QuadTo(float32(cx), float32(cy), float32(x), float32(y))
+}
+
+func (s *Surface) ClosePath() {
+	s.context.ClosePath()
+}
+
+func (s *Surface) Ellipse(cx, cy, rx, ry float64) {
+	s.context.Ellipse(float32(cx), float32(cy), float32(rx), float32(ry))
+}
+
+func (s *Surface) PathWinding(winding spec.Winding) {
+	if winding == spec.HoleWinding {
+		s.context.PathWinding(nanovgo.Hole)
+		return
+	}
+	s.context.PathWinding(nanovgo.Solid)
+}
+
+func (s *Surface) SetGlobalAlpha(alpha float64) {
+	s.context.SetGlobalAlpha(float32(alpha))
+}
+
 func (s *Surface) SetFillColor(color uint) {
 	r, g, b, a := helpers.HexIntToRgbaFloat32(color)
 	s.context.SetFillColor(nanovgo.Color{r, g, b, a})
 }
 
+func (s *Surface) SetFillBoxGradient(x, y, width, height, radius, feather float64, innerColor, outerColor uint) {
+	paint := s.context.BoxGradient(float32(x), float32(y), float32(width), float32(height),
+		float32(radius), float32(feather), toColor(innerColor), toColor(outerColor))
+	s.context.SetFillPaint(paint)
+}
+
+func (s *Surface) SetFillLinearGradient(startX, startY, endX, endY float64, startColor, endColor uint) {
+	paint := s.context.LinearGradient(float32(startX), float32(startY), float32(endX), float32(endY),
+		toColor(startColor), toColor(endColor))
+	s.context.SetFillPaint(paint)
+}
+
+func (s *Surface) SetFillRadialGradient(centerX, centerY, innerRadius, outerRadius float64, innerColor, outerColor uint) {
+	paint := s.context.RadialGradient(float32(centerX), float32(centerY), float32(innerRadius), float32(outerRadius),
+		toColor(innerColor), toColor(outerColor))
+	s.context.SetFillPaint(paint)
+}
+
 func (s *Surface) SetStrokeColor(color uint) {
 	r, g, b, a := helpers.HexIntToRgbaFloat32(color)
 	s.context.SetStrokeColor(nanovgo.Color{r, g, b, a})
@@ -133,6 +301,14 @@ func (s *Surface) RoundedRect(x, y, width, height, radius float64) {
 	s.context.RoundedRect(float32(x), float32(y), float32(width), float32(height), float32(radius))
 }
 
+func (s *Surface) RoundedRectVarying(x, y, width, height, topLeft, topRight, bottomRight, bottomLeft float64) {
+	if topLeft == 0 && topRight == 0 && bottomRight == 0 && bottomLeft == 0 {
+		s.Rect(x, y, width, height)
+		return
+	}
+	helpers.RoundedRectVaryingPath(s, x, y, width, height, topLeft, topRight, bottomRight, bottomLeft)
+}
+
 func (s *Surface) SetFontSize(size float64) {
 	s.context.SetFontSize(float32(size))
 }
@@ -146,8 +322,17 @@ func (s *Surface) Text(x float64, y float64, text string) {
 	s.context.Text(float32(x), float32(y), text)
 }
 
+// TextBounds measures text with the named face. If the face is unknown, the
+// registry fallback for that family is used, and if nothing can be
+// resolved, zero bounds are returned.
 func (s *Surface) TextBounds(face string, size float64, text string) (x, y, w, h float64) {
 	f := s.Font(face)
+	if f == nil {
+		f = s.Font(s.Fonts().FaceNameFor(face, font.Regular, font.Normal))
+	}
+	if f == nil {
+		return 0, 0, 0, 0
+	}
 	f.SetSize(size)
 
 	_, _, h = f.VerticalMetrics()
@@ -155,6 +340,33 @@ func (s *Surface) TextBounds(face string, size float64, text string) (x, y, w, h
 	return bounds[0], bounds[1], mW, h
 }
 
+// Shaper returns a text Shaper that sizes text the way fontstash does, so
+// that shaped text matches the size of text drawn with Text.
+func (s *Surface) Shaper() text.Shaper {
+	if s.shaper == nil {
+		s.shaper = text.NewShaper(s.Fonts(), text.PixelHeight())
+	}
+	return s.shaper
+}
+
+// DrawGlyphs fills the outline of each glyph in the shaped line, because
+// fontstash can only draw characters, rather than shaped glyphs.
+func (s *Surface) DrawGlyphs(x, y float64, line text.Line) {
+	s.BeginPath()
+	s.Shaper().Draw(glyphDrawer{s}, x, y, line)
+	s.Fill()
+}
+
+// glyphDrawer marks the holes in glyph outlines, because NanoVG enforces the
+// winding of each sub-path.
+type glyphDrawer struct {
+	*Surface
+}
+
+func (d glyphDrawer) Hole() {
+	d.PathWinding(spec.HoleWinding)
+}
+
 func (s *Surface) Flags() nanovgo.CreateFlags {
 	var result int
 	for _, flag := range s.flags {
@@ -163,6 +375,11 @@ func (s *Surface) Flags() nanovgo.CreateFlags {
 	return nanovgo.CreateFlags(result)
 }
 
+func toColor(color uint) nanovgo.Color {
+	r, g, b, a := helpers.HexIntToRgbaFloat32(color)
+	return nanovgo.Color{R: r, G: g, B: b, A: a}
+}
+
 func NewSurface(options ...Option) *Surface {
 	s := &Surface{}
 
@@ -172,11 +389,9 @@ func NewSurface(options ...Option) *Surface {
 	return s
 }
 
+// NewWithRoboto returns a new Surface.
+//
+// Deprecated: NewSurface includes the embedded Roboto family by default.
 func NewWithRoboto(options ...Option) *Surface {
-	s := NewSurface(options...)
-	s.AddFont("Roboto", "../../third_party/fonts/Roboto/Roboto-Regular.ttf")
-	// s.AddFont("Roboto-Light", "../../third_party/fonts/Roboto/Roboto-Light.ttf")
-	// s.AddFont("Roboto-Bold", "../../third_party/fonts/Roboto/Roboto-Bold.ttf")
-	// s.AddFont("Roboto-Black", "../../third_party/fonts/Roboto/Roboto-Black.ttf")
-	return s
+	return NewSurface(options...)
 }
@@ -1,6 +1,9 @@
 package nano
 
-import "github.com/shibukawa/nanovgo"
+import (
+	"github.com/shibukawa/nanovgo"
+	"github.com/waybeams/waybeams/pkg/font"
+)
 
 type Option func(s *Surface)
 
@@ -34,8 +37,11 @@ func Debug() Option {
 	}
 }
 
-func AddFont(name, path string) Option {
+// Fonts configures the font Registry that is used to resolve and load font
+// faces. Registering fonts on a Registry (rather than on the Surface) allows
+// font loading errors to be handled before the Surface is created.
+func Fonts(registry *font.Registry) Option {
 	return func(s *Surface) {
-		s.AddFont(name, path)
+		s.registry = registry
 	}
 }
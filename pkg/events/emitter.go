@@ -1,10 +1,14 @@
 package events
 
+import (
+	"sync"
+	"sync/atomic"
+)
+
 var lastID int64
 
 func newHandlerID() int64 {
-	lastID = lastID + 1
-	return lastID
+	return atomic.AddInt64(&lastID, 1)
 }
 
 type Event interface {
@@ -112,11 +116,18 @@ type Emitter interface {
 	RemoveAllHandlersFor(eventName string) bool
 }
 
+// EmitterBase guards its handler collection so that handlers may be added,
+// removed and emitted from more than one goroutine. Handlers themselves are
+// called without holding the lock, so they may subscribe or unsubscribe.
 type EmitterBase struct {
 	handlers []*registeredHandler
+	mutex    sync.RWMutex
 }
 
 func (e *EmitterBase) RemoveAllHandlersFor(eventName string) bool {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
 	var found = false
 	var remaining []*registeredHandler
 	for _, entry := range e.handlers {
@@ -130,6 +141,9 @@ func (e *EmitterBase) RemoveAllHandlersFor(eventName string) bool {
 }
 
 func (e *EmitterBase) RemoveAllHandlers() bool {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
 	found := len(e.handlers) > 0
 	e.handlers = nil
 	return found
@@ -149,20 +163,34 @@ func (e *EmitterBase) On(eventName string, handler EventHandler) Unsubscriber {
 		handler:   handler,
 	}
 
+	e.mutex.Lock()
 	e.handlers = append(e.handlers, rHandler)
+	e.mutex.Unlock()
+
 	return func() bool {
+		e.mutex.Lock()
+		defer e.mutex.Unlock()
+
 		for index, entry := range e.handlers {
 			if entry.id == id {
-				e.handlers = append(e.handlers[:index], e.handlers[index+1:]...)
+				// Copy rather than splice in place so that an Emit that is
+				// currently iterating over the previous slice is unaffected.
+				remaining := make([]*registeredHandler, 0, len(e.handlers)-1)
+				remaining = append(remaining, e.handlers[:index]...)
+				e.handlers = append(remaining, e.handlers[index+1:]...)
+				return true
 			}
-			return true
 		}
 		return false
 	}
 }
 
 func (e *EmitterBase) Emit(event Event) {
-	for _, entry := range e.handlers {
+	e.mutex.RLock()
+	handlers := e.handlers
+	e.mutex.RUnlock()
+
+	for _, entry := range handlers {
 		if event.IsCancelled() {
 			return
 		}
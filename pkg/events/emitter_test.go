@@ -1,9 +1,12 @@
 package events_test
 
 import (
+	"sync"
+	"sync/atomic"
+	"testing"
+
 	"github.com/waybeams/assert"
 	"github.com/waybeams/waybeams/pkg/events"
-	"testing"
 )
 
 func TestDispatcher(t *testing.T) {
@@ -92,4 +95,36 @@ func TestDispatcher(t *testing.T) {
 		assert.Equal(received.Name(), "bar")
 		assert.Equal(received.Target(), instance)
 	})
+	t.Run("Unsubscriber removes handlers that are not first", func(t *testing.T) {
+		firstCount := 0
+		secondCount := 0
+		instance := events.NewEmitter()
+		instance.On("fake-event", func(e events.Event) { firstCount++ })
+		unsub := instance.On("fake-event", func(e events.Event) { secondCount++ })
+
+		assert.True(unsub())
+		assert.False(unsub(), "Second call finds nothing to remove")
+		instance.Emit(events.New("fake-event", nil, nil))
+		assert.Equal(firstCount, 1)
+		assert.Equal(secondCount, 0)
+	})
+
+	t.Run("Concurrent subscribe and emit", func(t *testing.T) {
+		var count int64
+		instance := events.NewEmitter()
+		wg := sync.WaitGroup{}
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				unsub := instance.On("fake-event", func(e events.Event) {
+					atomic.AddInt64(&count, 1)
+				})
+				instance.Emit(events.New("fake-event", nil, nil))
+				unsub()
+			}()
+		}
+		wg.Wait()
+		assert.True(atomic.LoadInt64(&count) >= 10)
+	})
 }
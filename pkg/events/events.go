@@ -1,7 +1,12 @@
 package events
 
+import "time"
+
 // Gesture Notifications (past tense)
 const CharEntered = "CharEntered"
+const CompositionEnded = "CompositionEnded"
+const CompositionStarted = "CompositionStarted"
+const CompositionUpdated = "CompositionUpdated"
 const EnterKeyReleased = "EnterKeyReleased"
 const KeyEntered = "KeyEntered"
 const KeyPressed = "KeyPressed"
@@ -33,16 +38,24 @@ const MovePrevious = "MovePrevious"
 const MoveRight = "MoveRight"
 const MoveUp = "MoveUp"
 
+// Model Notifications (past tense)
+const Changed = "Changed"
+
 // Spec Lifecycle
 const Configured = "Configured"
 const Created = "Created"
 const DrawCompleted = "DrawCompleted"
 const Invalidated = "Invalidated"
 const LayoutCompleted = "LayoutCompleted"
+const Mounted = "Mounted"
+const Unmounted = "Unmounted"
 
 var AllEvents = []string{
 	// Gesture Notifications
 	CharEntered,
+	CompositionEnded,
+	CompositionStarted,
+	CompositionUpdated,
 	EnterKeyReleased,
 	Moved,
 	Pressed,
@@ -74,10 +87,23 @@ var AllEvents = []string{
 	MoveRight,
 	MoveUp,
 
+	// Model Notifications
+	Changed,
+
 	// Spec Lifecycle
 	Configured,
 	Created,
 	DrawCompleted,
 	Invalidated,
 	LayoutCompleted,
+	Mounted,
+	Unmounted,
+}
+
+// FramePayload is provided with each FrameEntered event.
+type FramePayload struct {
+	// Delta is the clock time that has passed since the previous frame.
+	Delta time.Duration
+	// Frame is the number of frames the Scheduler has executed, starting at 1.
+	Frame int
 }
@@ -0,0 +1,16 @@
+package fakes
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+)
+
+// PNG returns an encoded, transparent PNG image of the provided size.
+func PNG(width, height int) []byte {
+	buffer := &bytes.Buffer{}
+	if err := png.Encode(buffer, image.NewRGBA(image.Rect(0, 0, width, height))); err != nil {
+		panic(err)
+	}
+	return buffer.Bytes()
+}
@@ -0,0 +1,309 @@
+// Package font provides a Registry of font faces that resolves a family,
+// weight and style (e.g., "Roboto", Light, Italic) to the best available face
+// using the CSS font matching rules, along with fallback chains for glyphs
+// that are missing from a face.
+package font
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// Style is the slant of a font face. The zero value means the style should be
+// inherited.
+type Style int
+
+const (
+	Normal = Style(iota + 1)
+	Italic
+)
+
+// Common weights, which match the CSS font-weight values.
+const (
+	Thin    = 100
+	Light   = 300
+	Regular = 400
+	Medium  = 500
+	Bold    = 700
+	Black   = 900
+)
+
+// ErrInvalidFont is returned when font data is not a TrueType, OpenType or
+// WOFF font.
+var ErrInvalidFont = errors.New("font: data is not a TrueType or OpenType font")
+
+var fontSignatures = [][]byte{
+	{0x00, 0x01, 0x00, 0x00},
+	[]byte("OTTO"),
+	[]byte("true"),
+	[]byte("ttcf"),
+	[]byte("wOFF"),
+	[]byte("wOF2"),
+}
+
+func isFontData(data []byte) bool {
+	for _, signature := range fontSignatures {
+		if bytes.HasPrefix(data, signature) {
+			return true
+		}
+	}
+	return false
+}
+
+// Face is a single registered font file.
+type Face struct {
+	// Data holds the font file contents, unless the Face was added by URL.
+	Data   []byte
+	Family string
+	// Name uniquely identifies the Face to drawing backends.
+	Name   string
+	Style  Style
+	URL    string
+	Weight int
+}
+
+// FaceName returns the unique name for the provided family, weight and
+// style. Regular, normal faces use the family name, so that faces added with
+// AddFont(name, path) are found by name.
+func FaceName(family string, weight int, style Style) string {
+	name := family
+	if weight != Regular {
+		name += "-" + strconv.Itoa(weight)
+	}
+	if style == Italic {
+		name += "-Italic"
+	}
+	return name
+}
+
+// Registry holds the available font faces.
+type Registry struct {
+	faces         []*Face
+	fallbacks     map[string][]string
+	mutex         sync.RWMutex
+	defaultFamily string
+}
+
+func normalize(weight int, style Style) (int, Style) {
+	if weight <= 0 {
+		weight = Regular
+	}
+	if style != Italic {
+		style = Normal
+	}
+	return weight, style
+}
+
+func (r *Registry) add(face *Face) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	for index, existing := range r.faces {
+		if existing.Name == face.Name {
+			r.faces[index] = face
+			return
+		}
+	}
+	if r.defaultFamily == "" {
+		r.defaultFamily = face.Family
+	}
+	r.faces = append(r.faces, face)
+}
+
+// Add reads the font file at path and registers it for the provided family,
+// weight and style.
+func (r *Registry) Add(family string, weight int, style Style, path string) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return r.AddBytes(family, weight, style, data)
+}
+
+// AddBytes registers the provided font data (e.g., from go:embed) for the
+// provided family, weight and style.
+func (r *Registry) AddBytes(family string, weight int, style Style, data []byte) error {
+	if !isFontData(data) {
+		return ErrInvalidFont
+	}
+	weight, style = normalize(weight, style)
+	r.add(&Face{
+		Data:   data,
+		Family: family,
+		Name:   FaceName(family, weight, style),
+		Style:  style,
+		Weight: weight,
+	})
+	return nil
+}
+
+// AddURL registers a font that is loaded by URL. This is only supported by
+// the browser Surface.
+func (r *Registry) AddURL(family string, weight int, style Style, url string) {
+	weight, style = normalize(weight, style)
+	r.add(&Face{
+		Family: family,
+		Name:   FaceName(family, weight, style),
+		Style:  style,
+		URL:    url,
+		Weight: weight,
+	})
+}
+
+// Faces returns every registered Face.
+func (r *Registry) Faces() []*Face {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	result := make([]*Face, len(r.faces))
+	copy(result, r.faces)
+	return result
+}
+
+// Named returns the Face with the provided Name. If there is no such Face,
+// the regular face that Resolve returns for the name as a family is used.
+func (r *Registry) Named(name string) (*Face, bool) {
+	r.mutex.RLock()
+	for _, face := range r.faces {
+		if face.Name == name {
+			r.mutex.RUnlock()
+			return face, true
+		}
+	}
+	r.mutex.RUnlock()
+	return r.Resolve(name, Regular, Normal)
+}
+
+// SetFallbacks configures the families that are used, in order, for glyphs
+// that are missing from faces of the provided family.
+func (r *Registry) SetFallbacks(family string, fallbacks ...string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.fallbacks == nil {
+		r.fallbacks = map[string][]string{}
+	}
+	r.fallbacks[family] = fallbacks
+}
+
+// Fallbacks returns the fallback families for the provided family.
+func (r *Registry) Fallbacks(family string) []string {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	return r.fallbacks[family]
+}
+
+// FallbackFaces returns the best matching Face from each fallback family of
+// the provided Face.
+func (r *Registry) FallbackFaces(face *Face) []*Face {
+	result := []*Face{}
+	for _, family := range r.Fallbacks(face.Family) {
+		if fallback := r.match(family, face.Weight, face.Style); fallback != nil {
+			result = append(result, fallback)
+		}
+	}
+	return result
+}
+
+// Resolve returns the Face that best matches the provided family, weight and
+// style. If the family has no faces, its fallbacks and then the first
+// registered family are tried.
+func (r *Registry) Resolve(family string, weight int, style Style) (*Face, bool) {
+	weight, style = normalize(weight, style)
+	if face := r.match(family, weight, style); face != nil {
+		return face, true
+	}
+	for _, fallback := range r.Fallbacks(family) {
+		if face := r.match(fallback, weight, style); face != nil {
+			return face, true
+		}
+	}
+	r.mutex.RLock()
+	defaultFamily := r.defaultFamily
+	r.mutex.RUnlock()
+	if face := r.match(defaultFamily, weight, style); face != nil {
+		return face, true
+	}
+	return nil, false
+}
+
+// FaceNameFor returns the Name of the resolved Face, or the family itself if
+// nothing could be resolved.
+func (r *Registry) FaceNameFor(family string, weight int, style Style) string {
+	if face, ok := r.Resolve(family, weight, style); ok {
+		return face.Name
+	}
+	return family
+}
+
+// match implements the CSS font matching algorithm for a single family:
+// faces with the requested style are preferred over others, then weights are
+// chosen by distance in the direction that CSS prescribes.
+func (r *Registry) match(family string, weight int, style Style) *Face {
+	r.mutex.RLock()
+	candidates := []*Face{}
+	for _, face := range r.faces {
+		if face.Family == family {
+			candidates = append(candidates, face)
+		}
+	}
+	r.mutex.RUnlock()
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	styled := []*Face{}
+	for _, face := range candidates {
+		if face.Style == style {
+			styled = append(styled, face)
+		}
+	}
+	if len(styled) > 0 {
+		candidates = styled
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return weightRank(weight, candidates[i].Weight) < weightRank(weight, candidates[j].Weight)
+	})
+	return candidates[0]
+}
+
+// weightRank orders a candidate weight for a desired weight, lower is
+// better. For 400-500, weights up to 500 are checked first, then lighter
+// weights, then heavier. Below 400 lighter weights are checked first, and
+// above 500 heavier weights are checked first.
+func weightRank(desired, candidate int) int {
+	const penalty = 10000
+	distance := candidate - desired
+	if distance < 0 {
+		distance = -distance
+	}
+	switch {
+	case candidate == desired:
+		return 0
+	case desired >= Regular && desired <= Medium:
+		if candidate > desired && candidate <= Medium {
+			return distance
+		}
+		if candidate < desired {
+			return penalty + distance
+		}
+		return 2*penalty + distance
+	case desired < Regular:
+		if candidate < desired {
+			return distance
+		}
+		return penalty + distance
+	default:
+		if candidate > desired {
+			return distance
+		}
+		return penalty + distance
+	}
+}
+
+// NewRegistry returns an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{}
+}
@@ -0,0 +1,134 @@
+package font_test
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/waybeams/assert"
+	"github.com/waybeams/waybeams/pkg/font"
+)
+
+const robotoPath = "../../third_party/fonts/Roboto/Roboto-Regular.ttf"
+
+func fontData(t *testing.T) []byte {
+	data, err := ioutil.ReadFile(robotoPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestRegistry(t *testing.T) {
+	t.Run("Add reads the file", func(t *testing.T) {
+		r := font.NewRegistry()
+		assert.Nil(r.Add("Roboto", font.Regular, font.Normal, robotoPath))
+		faces := r.Faces()
+		assert.Equal(len(faces), 1)
+		assert.Equal(faces[0].Name, "Roboto")
+		assert.True(len(faces[0].Data) > 0)
+	})
+
+	t.Run("Add returns an error for bad paths", func(t *testing.T) {
+		r := font.NewRegistry()
+		assert.NotNil(r.Add("Roboto", font.Regular, font.Normal, "/does/not/exist.ttf"))
+		assert.Equal(len(r.Faces()), 0)
+	})
+
+	t.Run("AddBytes rejects invalid data", func(t *testing.T) {
+		r := font.NewRegistry()
+		assert.Equal(r.AddBytes("Roboto", font.Regular, font.Normal, []byte("abcd")), font.ErrInvalidFont)
+	})
+
+	t.Run("Face names", func(t *testing.T) {
+		assert.Equal(font.FaceName("Roboto", font.Regular, font.Normal), "Roboto")
+		assert.Equal(font.FaceName("Roboto", font.Light, font.Normal), "Roboto-300")
+		assert.Equal(font.FaceName("Roboto", font.Bold, font.Italic), "Roboto-700-Italic")
+	})
+
+	t.Run("Resolve", func(t *testing.T) {
+		data := fontData(t)
+		r := font.NewRegistry()
+		for _, weight := range []int{font.Thin, font.Light, font.Regular, font.Bold} {
+			r.AddBytes("Roboto", weight, font.Normal, data)
+		}
+		r.AddBytes("Roboto", font.Regular, font.Italic, data)
+
+		resolve := func(family string, weight int, style font.Style) string {
+			face, ok := r.Resolve(family, weight, style)
+			assert.True(ok)
+			return face.Name
+		}
+
+		t.Run("Exact match", func(t *testing.T) {
+			assert.Equal(resolve("Roboto", font.Light, font.Normal), "Roboto-300")
+		})
+
+		t.Run("Zero values are regular and normal", func(t *testing.T) {
+			assert.Equal(resolve("Roboto", 0, 0), "Roboto")
+		})
+
+		t.Run("Light weights prefer lighter faces", func(t *testing.T) {
+			assert.Equal(resolve("Roboto", 200, font.Normal), "Roboto-100")
+		})
+
+		t.Run("Heavy weights prefer heavier faces", func(t *testing.T) {
+			assert.Equal(resolve("Roboto", 600, font.Normal), "Roboto-700")
+			assert.Equal(resolve("Roboto", font.Black, font.Normal), "Roboto-700")
+		})
+
+		t.Run("Medium falls back to lighter before heavier", func(t *testing.T) {
+			assert.Equal(resolve("Roboto", font.Medium, font.Normal), "Roboto")
+		})
+
+		t.Run("Prefers the requested style", func(t *testing.T) {
+			assert.Equal(resolve("Roboto", font.Bold, font.Italic), "Roboto-Italic")
+		})
+
+		t.Run("Unknown families use the first family", func(t *testing.T) {
+			assert.Equal(resolve("Unknown", font.Regular, font.Normal), "Roboto")
+			assert.Equal(r.FaceNameFor("Unknown", font.Light, font.Normal), "Roboto-300")
+		})
+
+		t.Run("Named finds faces by name", func(t *testing.T) {
+			face, ok := r.Named("Roboto-100")
+			assert.True(ok)
+			assert.Equal(face.Weight, font.Thin)
+			face, _ = r.Named("Unknown")
+			assert.Equal(face.Name, "Roboto")
+		})
+
+		t.Run("Empty registry returns the family", func(t *testing.T) {
+			_, ok := font.NewRegistry().Resolve("Roboto", font.Regular, font.Normal)
+			assert.False(ok)
+			assert.Equal(font.NewRegistry().FaceNameFor("Roboto", font.Regular, font.Normal), "Roboto")
+		})
+	})
+
+	t.Run("Fallbacks", func(t *testing.T) {
+		data := fontData(t)
+		r := font.NewRegistry()
+		r.AddBytes("Roboto", font.Regular, font.Normal, data)
+		r.AddBytes("Noto Emoji", font.Regular, font.Normal, data)
+		r.AddBytes("Noto Emoji", font.Bold, font.Normal, data)
+		r.SetFallbacks("Roboto", "Missing", "Noto Emoji")
+		r.SetFallbacks("Icons", "Noto Emoji")
+
+		assert.Equal(r.Fallbacks("Roboto"), []string{"Missing", "Noto Emoji"})
+
+		roboto, _ := r.Resolve("Roboto", font.Bold, font.Normal)
+		fallbacks := r.FallbackFaces(roboto)
+		assert.Equal(len(fallbacks), 1)
+		assert.Equal(fallbacks[0].Name, "Noto Emoji")
+
+		face, _ := r.Resolve("Icons", font.Bold, font.Normal)
+		assert.Equal(face.Name, "Noto Emoji-700", "Unregistered families resolve through fallbacks")
+	})
+
+	t.Run("AddURL", func(t *testing.T) {
+		r := font.NewRegistry()
+		r.AddURL("Roboto", font.Light, font.Italic, "/fonts/roboto-light-italic.woff2")
+		face, ok := r.Resolve("Roboto", font.Light, font.Italic)
+		assert.True(ok)
+		assert.Equal(face.URL, "/fonts/roboto-light-italic.woff2")
+	})
+}
@@ -74,3 +74,29 @@ func HexIntToRgbFloat64(value uint) (r, g, b float64) {
 	red, green, blue := HexIntToRgb(value)
 	return UintColorToFloat64(red), UintColorToFloat64(green), UintColorToFloat64(blue)
 }
+
+// LerpColor interpolates each channel of two 8 character hex colors by the
+// provided progress (0.0 - 1.0).
+func LerpColor(from, to uint, progress float64) uint {
+	fr, fg, fb, fa := HexIntToRgba(from)
+	tr, tg, tb, ta := HexIntToRgba(to)
+	lerp := func(a, b uint) uint {
+		value := float64(a) + (float64(b)-float64(a))*progress
+		if value < 0 {
+			return 0
+		}
+		if value > 255 {
+			return 255
+		}
+		return uint(value + 0.5)
+	}
+	return lerp(fr, tr)<<24 | lerp(fg, tg)<<16 | lerp(fb, tb)<<8 | lerp(fa, ta)
+}
+
+// UintToCssRgba returns the provided 8 character hex color as a CSS rgba()
+// string.
+func UintToCssRgba(value uint) string {
+	r, g, b, a := HexIntToRgba(value)
+	alpha := strconv.FormatFloat(UintColorToFloat64(a), 'f', -1, 64)
+	return "rgba(" + strconv.Itoa(int(r)) + "," + strconv.Itoa(int(g)) + "," + strconv.Itoa(int(b)) + "," + alpha + ")"
+}
@@ -119,4 +119,23 @@ func TestColors(t *testing.T) {
 			}
 		})
 	})
+
+	t.Run("LerpColor", func(t *testing.T) {
+		result := LerpColor(0x000000ff, 0xff0000ff, 0.5)
+		if result != 0x800000ff {
+			t.Errorf("Expected %x to equal 800000ff", result)
+		}
+		if LerpColor(0x11223344, 0xaabbccdd, 1) != 0xaabbccdd {
+			t.Error("Expected LerpColor to reach the target color")
+		}
+	})
+
+	t.Run("UintToCssRgba", func(t *testing.T) {
+		if value := UintToCssRgba(0xff660080); value != "rgba(255,102,0,0.5019607843137255)" {
+			t.Errorf("Unexpected CSS color %s", value)
+		}
+		if value := UintToCssRgba(0x000000ff); value != "rgba(0,0,0,1)" {
+			t.Errorf("Unexpected CSS color %s", value)
+		}
+	})
 }
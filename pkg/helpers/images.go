@@ -0,0 +1,20 @@
+package helpers
+
+import (
+	"bytes"
+	"image"
+	// Register the decoders that ImageConfig supports.
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+)
+
+// ImageConfig reads only the header of the provided PNG, JPEG or GIF data
+// and returns the intrinsic size and format name (e.g., "png").
+func ImageConfig(data []byte) (width, height int, format string, err error) {
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return 0, 0, "", err
+	}
+	return config.Width, config.Height, format, nil
+}
@@ -0,0 +1,46 @@
+package helpers
+
+import "math"
+
+// kappa90 is the length of bezier control points that approximate a quarter
+// circle.
+const kappa90 = 0.5522847493
+
+// PathBuilder receives the path segments that are generated by helpers like
+// RoundedRectVaryingPath, which allows each Surface to share geometry while
+// calling into its own drawing context.
+type PathBuilder interface {
+	MoveTo(x, y float64)
+	LineTo(x, y float64)
+	BezierTo(c1x, c1y, c2x, c2y, x, y float64)
+	ClosePath()
+}
+
+// RoundedRectVaryingPath sends a rectangle with a different radius at each
+// corner to the provided PathBuilder. Radii are clamped to half of the
+// width and height.
+func RoundedRectVaryingPath(p PathBuilder, x, y, w, h, topLeft, topRight, bottomRight, bottomLeft float64) {
+	halfW := math.Abs(w) * 0.5
+	halfH := math.Abs(h) * 0.5
+	signW := math.Copysign(1, w)
+	signH := math.Copysign(1, h)
+	radii := func(radius float64) (float64, float64) {
+		return math.Min(radius, halfW) * signW, math.Min(radius, halfH) * signH
+	}
+	rxBL, ryBL := radii(bottomLeft)
+	rxBR, ryBR := radii(bottomRight)
+	rxTR, ryTR := radii(topRight)
+	rxTL, ryTL := radii(topLeft)
+	k := 1 - kappa90
+
+	p.MoveTo(x, y+ryTL)
+	p.LineTo(x, y+h-ryBL)
+	p.BezierTo(x, y+h-ryBL*k, x+rxBL*k, y+h, x+rxBL, y+h)
+	p.LineTo(x+w-rxBR, y+h)
+	p.BezierTo(x+w-rxBR*k, y+h, x+w, y+h-ryBR*k, x+w, y+h-ryBR)
+	p.LineTo(x+w, y+ryTR)
+	p.BezierTo(x+w, y+ryTR*k, x+w-rxTR*k, y, x+w-rxTR, y)
+	p.LineTo(x+rxTL, y)
+	p.BezierTo(x+rxTL*k, y, x, y+ryTL*k, x, y+ryTL)
+	p.ClosePath()
+}
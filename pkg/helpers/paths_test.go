@@ -0,0 +1,61 @@
+package helpers
+
+import (
+	"fmt"
+	"testing"
+)
+
+type fakePath struct {
+	commands []string
+}
+
+func (p *fakePath) MoveTo(x, y float64) {
+	p.commands = append(p.commands, fmt.Sprintf("MoveTo(%v, %v)", x, y))
+}
+
+func (p *fakePath) LineTo(x, y float64) {
+	p.commands = append(p.commands, fmt.Sprintf("LineTo(%v, %v)", x, y))
+}
+
+func (p *fakePath) BezierTo(c1x, c1y, c2x, c2y, x, y float64) {
+	p.commands = append(p.commands, fmt.Sprintf("BezierTo(%v, %v)", x, y))
+}
+
+func (p *fakePath) ClosePath() {
+	p.commands = append(p.commands, "ClosePath")
+}
+
+func TestPaths(t *testing.T) {
+	t.Run("RoundedRectVaryingPath", func(t *testing.T) {
+		t.Run("Visits each corner", func(t *testing.T) {
+			p := &fakePath{}
+			RoundedRectVaryingPath(p, 0, 0, 100, 50, 1, 2, 3, 4)
+			expected := []string{
+				"MoveTo(0, 1)",
+				"LineTo(0, 46)",
+				"BezierTo(4, 50)",
+				"LineTo(97, 50)",
+				"BezierTo(100, 47)",
+				"LineTo(100, 2)",
+				"BezierTo(98, 0)",
+				"LineTo(1, 0)",
+				"BezierTo(0, 1)",
+				"ClosePath",
+			}
+			if fmt.Sprint(p.commands) != fmt.Sprint(expected) {
+				t.Errorf("Unexpected path: %v", p.commands)
+			}
+		})
+
+		t.Run("Clamps radii to half the size", func(t *testing.T) {
+			p := &fakePath{}
+			RoundedRectVaryingPath(p, 0, 0, 20, 10, 100, 100, 100, 100)
+			if p.commands[0] != "MoveTo(0, 5)" {
+				t.Errorf("Expected radius to be clamped, got %v", p.commands[0])
+			}
+			if p.commands[3] != "LineTo(10, 10)" {
+				t.Errorf("Expected radius to be clamped, got %v", p.commands[3])
+			}
+		})
+	})
+}
@@ -0,0 +1,120 @@
+package i18n
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+)
+
+// pluralCategories maps CLDR plural category names onto plural.Form values.
+var pluralCategories = map[string]plural.Form{
+	"zero":  plural.Zero,
+	"one":   plural.One,
+	"two":   plural.Two,
+	"few":   plural.Few,
+	"many":  plural.Many,
+	"other": plural.Other,
+}
+
+// Catalog holds the translated messages for a single locale.
+type Catalog struct {
+	locale   language.Tag
+	messages map[string][]string
+	// pluralForms is the gettext Plural-Forms expression. When it is nil,
+	// messages are indexed by plural.Form and selected with the CLDR rules
+	// for the Catalog locale.
+	pluralForms expression
+}
+
+// Add stores a message that does not vary by count.
+func (c *Catalog) Add(key, message string) {
+	c.messages[key] = []string{message}
+}
+
+// AddPlural stores a message with one entry for each CLDR plural category
+// ("zero", "one", "two", "few", "many" and "other") that the locale uses.
+func (c *Catalog) AddPlural(key string, forms map[string]string) error {
+	messages := make([]string, int(plural.Many)+1)
+	for category, message := range forms {
+		form, ok := pluralCategories[category]
+		if !ok {
+			return fmt.Errorf("i18n: unknown plural category %q for %q", category, key)
+		}
+		messages[form] = message
+	}
+	c.messages[key] = messages
+	return nil
+}
+
+// Locale returns the canonical name of the Catalog locale.
+func (c *Catalog) Locale() string {
+	return c.locale.String()
+}
+
+// lookup returns the message for key, choosing the plural form for count
+// when there is more than one.
+func (c *Catalog) lookup(key string, count operands, hasCount bool) (string, bool) {
+	forms, ok := c.messages[key]
+	if !ok || len(forms) == 0 {
+		return "", false
+	}
+	if len(forms) == 1 {
+		return forms[0], true
+	}
+	if !hasCount {
+		count = operands{}
+	}
+
+	if c.pluralForms != nil {
+		index := c.pluralForms(count.i)
+		if index < 0 || index >= len(forms) || forms[index] == "" {
+			return "", false
+		}
+		return forms[index], true
+	}
+
+	form := plural.Cardinal.MatchPlural(c.locale, count.i, count.v, count.w, count.f, count.t)
+	if message := forms[form]; message != "" {
+		return message, true
+	}
+	if message := forms[plural.Other]; message != "" {
+		return message, true
+	}
+	return "", false
+}
+
+// NewCatalog returns an empty Catalog for the provided locale (e.g., "en",
+// "pt-BR" or "ar").
+func NewCatalog(locale string) *Catalog {
+	return &Catalog{
+		locale:   language.Make(locale),
+		messages: map[string][]string{},
+	}
+}
+
+// ParseJSON returns a Catalog for locale from a JSON object, where each key
+// maps to a message string or to an object of plural categories.
+func ParseJSON(locale string, data []byte) (*Catalog, error) {
+	entries := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("i18n: invalid JSON catalog: %v", err)
+	}
+	catalog := NewCatalog(locale)
+	for key, entry := range entries {
+		var message string
+		if err := json.Unmarshal(entry, &message); err == nil {
+			catalog.Add(key, message)
+			continue
+		}
+		forms := map[string]string{}
+		if err := json.Unmarshal(entry, &forms); err != nil {
+			return nil, fmt.Errorf("i18n: %q must be a string or an object of plural forms", key)
+		}
+		if err := catalog.AddPlural(key, forms); err != nil {
+			return nil, err
+		}
+	}
+	return catalog, nil
+}
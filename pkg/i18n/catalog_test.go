@@ -0,0 +1,85 @@
+package i18n_test
+
+import (
+	"testing"
+
+	"github.com/waybeams/assert"
+	"github.com/waybeams/waybeams/pkg/i18n"
+)
+
+func TestCatalog(t *testing.T) {
+	localize := func(catalog *i18n.Catalog) *i18n.Localizer {
+		localizer := i18n.NewLocalizer()
+		localizer.AddCatalog(catalog)
+		localizer.SetLocale(catalog.Locale())
+		return localizer
+	}
+
+	t.Run("Parses JSON", func(t *testing.T) {
+		catalog, err := i18n.ParseJSON("en", []byte(`{
+			"greeting": "Hello, {name}!",
+			"items": {"one": "{count} item", "other": "{count} items"}
+		}`))
+		assert.Nil(err)
+		assert.Equal(catalog.Locale(), "en")
+
+		l := localize(catalog)
+		assert.Equal(l.Translate("greeting", i18n.Params{"name": "Ada"}), "Hello, Ada!")
+		assert.Equal(l.Translate("items", i18n.Params{"count": 1}), "1 item")
+		assert.Equal(l.Translate("items", i18n.Params{"count": 0}), "0 items")
+		assert.Equal(l.Translate("items", i18n.Params{"count": 1.5}), "1.5 items")
+	})
+
+	t.Run("Invalid JSON", func(t *testing.T) {
+		_, err := i18n.ParseJSON("en", []byte(`["abcd"]`))
+		assert.Match("invalid JSON catalog", err.Error())
+
+		_, err = i18n.ParseJSON("en", []byte(`{"items": {"single": "abcd"}}`))
+		assert.Match(`unknown plural category "single"`, err.Error())
+
+		_, err = i18n.ParseJSON("en", []byte(`{"items": 23}`))
+		assert.Match("must be a string or an object", err.Error())
+	})
+
+	t.Run("Plural rules follow the locale", func(t *testing.T) {
+		catalog := i18n.NewCatalog("ru")
+		err := catalog.AddPlural("files", map[string]string{
+			"one":   "{0} файл",
+			"few":   "{0} файла",
+			"many":  "{0} файлов",
+			"other": "{0} файла",
+		})
+		assert.Nil(err)
+		l := localize(catalog)
+		assert.Equal(l.Translate("files", 1), "1 файл")
+		assert.Equal(l.Translate("files", 3), "3 файла")
+		assert.Equal(l.Translate("files", 11), "11 файлов")
+		assert.Equal(l.Translate("files", 21), "21 файл")
+		assert.Equal(l.Translate("files", 2.5), "2.5 файла")
+
+		catalog = i18n.NewCatalog("fr")
+		catalog.AddPlural("files", map[string]string{"one": "{0} fichier", "other": "{0} fichiers"})
+		l = localize(catalog)
+		assert.Equal(l.Translate("files", 0), "0 fichier")
+		assert.Equal(l.Translate("files", 2), "2 fichiers")
+	})
+
+	t.Run("Missing plural forms use other", func(t *testing.T) {
+		catalog := i18n.NewCatalog("ar")
+		catalog.AddPlural("days", map[string]string{"one": "يوم واحد", "other": "{count} يوم"})
+		l := localize(catalog)
+		assert.Equal(l.Translate("days", i18n.Params{"count": 1}), "يوم واحد")
+		assert.Equal(l.Translate("days", i18n.Params{"count": 2}), "2 يوم")
+	})
+
+	t.Run("Interpolation", func(t *testing.T) {
+		catalog := i18n.NewCatalog("en")
+		catalog.Add("positional", "{1} before {0}")
+		catalog.Add("escaped", "{{name}} is {name}")
+		catalog.Add("unknown", "{missing} and {3}")
+		l := localize(catalog)
+		assert.Equal(l.Translate("positional", "a", "b"), "b before a")
+		assert.Equal(l.Translate("escaped", i18n.Params{"name": "Ada"}), "{name} is Ada")
+		assert.Equal(l.Translate("unknown", "a"), "{missing} and {3}")
+	})
+}
@@ -0,0 +1,132 @@
+package i18n
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// operands are the CLDR plural operands of a count: the integer digits (i),
+// the number of visible fraction digits with (v) and without (w) trailing
+// zeros, and the visible fraction digits with (f) and without (t) trailing
+// zeros.
+type operands struct {
+	i, v, w, f, t int
+}
+
+// newOperands returns the plural operands of value, or false if value is not
+// a number.
+func newOperands(value interface{}) (operands, bool) {
+	var digits string
+	switch number := value.(type) {
+	case int:
+		digits = strconv.FormatInt(int64(number), 10)
+	case int8:
+		digits = strconv.FormatInt(int64(number), 10)
+	case int16:
+		digits = strconv.FormatInt(int64(number), 10)
+	case int32:
+		digits = strconv.FormatInt(int64(number), 10)
+	case int64:
+		digits = strconv.FormatInt(number, 10)
+	case uint:
+		digits = strconv.FormatUint(uint64(number), 10)
+	case uint8:
+		digits = strconv.FormatUint(uint64(number), 10)
+	case uint16:
+		digits = strconv.FormatUint(uint64(number), 10)
+	case uint32:
+		digits = strconv.FormatUint(uint64(number), 10)
+	case uint64:
+		digits = strconv.FormatUint(number, 10)
+	case float32:
+		digits = strconv.FormatFloat(float64(number), 'f', -1, 32)
+	case float64:
+		digits = strconv.FormatFloat(number, 'f', -1, 64)
+	default:
+		return operands{}, false
+	}
+
+	digits = strings.TrimPrefix(digits, "-")
+	integer, fraction := digits, ""
+	if index := strings.IndexByte(digits, '.'); index >= 0 {
+		integer, fraction = digits[:index], digits[index+1:]
+	}
+	result := operands{v: len(fraction)}
+	result.i, _ = strconv.Atoi(integer)
+	result.f, _ = strconv.Atoi("0" + fraction)
+	trimmed := strings.TrimRight(fraction, "0")
+	result.w = len(trimmed)
+	result.t, _ = strconv.Atoi("0" + trimmed)
+	return result, true
+}
+
+// arguments separates named Params from positional arguments and finds the
+// count that selects a plural form.
+func arguments(args []interface{}) (params Params, positional []interface{}, count operands, hasCount bool) {
+	params = Params{}
+	for _, arg := range args {
+		if named, ok := arg.(Params); ok {
+			for key, value := range named {
+				params[key] = value
+			}
+			continue
+		}
+		positional = append(positional, arg)
+	}
+	if value, ok := params["count"]; ok {
+		count, hasCount = newOperands(value)
+	}
+	for _, arg := range positional {
+		if hasCount {
+			break
+		}
+		count, hasCount = newOperands(arg)
+	}
+	return params, positional, count, hasCount
+}
+
+// interpolate replaces each {name} or {index} placeholder in message with
+// the matching argument. Unknown placeholders are left as they are.
+func interpolate(message string, params Params, positional []interface{}) string {
+	if !strings.ContainsAny(message, "{}") {
+		return message
+	}
+	var result strings.Builder
+	for index := 0; index < len(message); index++ {
+		char := message[index]
+		if (char == '{' || char == '}') && index+1 < len(message) && message[index+1] == char {
+			result.WriteByte(char)
+			index++
+			continue
+		}
+		if char != '{' {
+			result.WriteByte(char)
+			continue
+		}
+		end := strings.IndexByte(message[index:], '}')
+		if end < 0 {
+			result.WriteString(message[index:])
+			break
+		}
+		name := message[index+1 : index+end]
+		if value, ok := argument(name, params, positional); ok {
+			result.WriteString(fmt.Sprint(value))
+		} else {
+			result.WriteString(message[index : index+end+1])
+		}
+		index += end
+	}
+	return result.String()
+}
+
+func argument(name string, params Params, positional []interface{}) (interface{}, bool) {
+	if value, ok := params[name]; ok {
+		return value, true
+	}
+	index, err := strconv.Atoi(name)
+	if err != nil || index < 0 || index >= len(positional) {
+		return nil, false
+	}
+	return positional[index], true
+}
@@ -0,0 +1,58 @@
+// Package i18n provides message catalogs, plural rules and parameter
+// interpolation for user facing text, along with the active locale that
+// opts.T resolves against.
+//
+// Catalogs can be loaded from JSON files, where each key maps to either a
+// string or an object of CLDR plural categories:
+//
+//	{
+//		"greeting": "Hello, {name}!",
+//		"items": {"one": "{count} item", "other": "{count} items"}
+//	}
+//
+// or from gettext .po files, where msgid is the key and the Plural-Forms
+// header selects between msgstr[n] entries.
+//
+// Messages may refer to named Params with {name}, or to positional
+// arguments with {0}, {1}, etc. Use {{ and }} for literal braces. The
+// plural form is selected by Params["count"], or by the first numeric
+// positional argument.
+package i18n
+
+// Params are named values that are interpolated into a message.
+type Params map[string]interface{}
+
+// DefaultLocalizer is the Localizer that opts.T and the package level
+// functions use. Schedulers re-render whenever its locale changes.
+var DefaultLocalizer = NewLocalizer()
+
+// AddCatalog adds the provided Catalog to the DefaultLocalizer.
+func AddCatalog(catalog *Catalog) {
+	DefaultLocalizer.AddCatalog(catalog)
+}
+
+// IsRightToLeft returns true if the DefaultLocalizer locale is written from
+// right to left.
+func IsRightToLeft() bool {
+	return DefaultLocalizer.IsRightToLeft()
+}
+
+// Load adds the catalog file at path to the DefaultLocalizer.
+func Load(path string) error {
+	return DefaultLocalizer.Load(path)
+}
+
+// Locale returns the DefaultLocalizer locale.
+func Locale() string {
+	return DefaultLocalizer.Locale()
+}
+
+// SetLocale changes the DefaultLocalizer locale.
+func SetLocale(locale string) {
+	DefaultLocalizer.SetLocale(locale)
+}
+
+// T translates the provided key with the DefaultLocalizer.
+func T(key string, args ...interface{}) string {
+	return DefaultLocalizer.Translate(key, args...)
+}
@@ -0,0 +1,167 @@
+package i18n
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/waybeams/waybeams/pkg/events"
+	"github.com/waybeams/waybeams/pkg/store"
+	"golang.org/x/text/language"
+)
+
+const DefaultLocale = "en"
+
+// rightToLeftScripts are the ISO 15924 codes of scripts that are written
+// from right to left.
+var rightToLeftScripts = map[string]bool{
+	"Adlm": true,
+	"Arab": true,
+	"Hebr": true,
+	"Mand": true,
+	"Mend": true,
+	"Nkoo": true,
+	"Rohg": true,
+	"Samr": true,
+	"Syrc": true,
+	"Thaa": true,
+	"Yezi": true,
+}
+
+// Localizer translates message keys with the Catalog of the active locale.
+// Localizer is Observable and emits events.Changed when the locale changes
+// or a Catalog is added, so that a Scheduler can re-render.
+type Localizer struct {
+	store.Model
+
+	catalogs map[language.Tag]*Catalog
+	fallback language.Tag
+	locale   language.Tag
+	mutex    sync.RWMutex
+}
+
+// AddCatalog adds the provided Catalog, replacing any Catalog with the same
+// locale.
+func (l *Localizer) AddCatalog(catalog *Catalog) {
+	l.mutex.Lock()
+	l.catalogs[catalog.locale] = catalog
+	l.mutex.Unlock()
+	l.Changed()
+}
+
+// IsRightToLeft returns true if the active locale is written from right to
+// left (e.g., Arabic or Hebrew).
+func (l *Localizer) IsRightToLeft() bool {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+	script, _ := l.locale.Script()
+	return rightToLeftScripts[script.String()]
+}
+
+// Load adds the catalog file at path. Files with a .json extension are
+// named for their locale (e.g., "locales/pt-BR.json"), and .po files use
+// their Language header, falling back to the file name.
+func (l *Localizer) Load(path string) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return l.LoadBytes(filepath.Base(path), data)
+}
+
+// LoadBytes adds a catalog from data, with the format and locale taken from
+// the provided file name.
+func (l *Localizer) LoadBytes(name string, data []byte) error {
+	extension := filepath.Ext(name)
+	locale := strings.TrimSuffix(name, extension)
+	var catalog *Catalog
+	var err error
+	switch strings.ToLower(extension) {
+	case ".json":
+		catalog, err = ParseJSON(locale, data)
+	case ".po":
+		catalog, err = ParsePO(locale, data)
+	default:
+		err = fmt.Errorf("i18n: unsupported catalog format %q", name)
+	}
+	if err != nil {
+		return err
+	}
+	l.AddCatalog(catalog)
+	return nil
+}
+
+// Locale returns the canonical name of the active locale.
+func (l *Localizer) Locale() string {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+	return l.locale.String()
+}
+
+// SetFallback configures the locale whose Catalog is used for keys that the
+// active locale does not translate.
+func (l *Localizer) SetFallback(locale string) {
+	l.mutex.Lock()
+	l.fallback = language.Make(locale)
+	l.mutex.Unlock()
+	l.Changed()
+}
+
+// SetLocale changes the active locale and emits events.Changed if it is
+// different.
+func (l *Localizer) SetLocale(locale string) {
+	tag := language.Make(locale)
+	l.mutex.Lock()
+	if tag == l.locale {
+		l.mutex.Unlock()
+		return
+	}
+	l.locale = tag
+	l.mutex.Unlock()
+	l.Emit(events.New(events.Changed, l, tag.String()))
+}
+
+// Translate returns the message for key in the active locale, with the
+// provided arguments interpolated. A locale that is not found falls back
+// to its parents (e.g., "pt-BR" to "pt") and then to the fallback locale.
+// Keys that are not translated at all are interpolated and returned as
+// they are.
+func (l *Localizer) Translate(key string, args ...interface{}) string {
+	params, positional, count, hasCount := arguments(args)
+	message := key
+	if translated, ok := l.lookup(key, count, hasCount); ok {
+		message = translated
+	}
+	return interpolate(message, params, positional)
+}
+
+func (l *Localizer) lookup(key string, count operands, hasCount bool) (string, bool) {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+	for _, locale := range []language.Tag{l.locale, l.fallback} {
+		for tag := locale; ; tag = tag.Parent() {
+			if catalog, ok := l.catalogs[tag]; ok {
+				if message, ok := catalog.lookup(key, count, hasCount); ok {
+					return message, true
+				}
+			}
+			if tag.IsRoot() {
+				break
+			}
+		}
+	}
+	return "", false
+}
+
+// NewLocalizer returns a Localizer with no catalogs, where the active and
+// the fallback locale are both DefaultLocale.
+func NewLocalizer() *Localizer {
+	locale := language.Make(DefaultLocale)
+	return &Localizer{
+		catalogs: map[language.Tag]*Catalog{},
+		fallback: locale,
+		locale:   locale,
+	}
+}
@@ -0,0 +1,90 @@
+package i18n_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/waybeams/assert"
+	"github.com/waybeams/waybeams/pkg/i18n"
+)
+
+func TestLocalizer(t *testing.T) {
+	t.Run("Defaults", func(t *testing.T) {
+		l := i18n.NewLocalizer()
+		assert.Equal(l.Locale(), "en")
+		assert.False(l.IsRightToLeft())
+		assert.Equal(l.Translate("Hello, {0}", "Ada"), "Hello, Ada")
+	})
+
+	t.Run("SetLocale emits Changed", func(t *testing.T) {
+		changes := 0
+		l := i18n.NewLocalizer()
+		l.OnChange(func() { changes++ })
+		l.SetLocale("fr")
+		l.SetLocale("fr")
+		assert.Equal(changes, 1)
+		l.SetLocale("pt_BR")
+		assert.Equal(l.Locale(), "pt-BR")
+		assert.Equal(changes, 2)
+	})
+
+	t.Run("Falls back to parent and fallback locales", func(t *testing.T) {
+		en := i18n.NewCatalog("en")
+		en.Add("save", "Save")
+		en.Add("cancel", "Cancel")
+		pt := i18n.NewCatalog("pt")
+		pt.Add("save", "Salvar")
+		pt.Add("cancel", "Cancelar")
+		br := i18n.NewCatalog("pt-BR")
+		br.Add("cancel", "Cancelar agora")
+
+		l := i18n.NewLocalizer()
+		l.AddCatalog(en)
+		l.AddCatalog(pt)
+		l.AddCatalog(br)
+		l.SetLocale("pt-BR")
+		assert.Equal(l.Translate("cancel"), "Cancelar agora")
+		assert.Equal(l.Translate("save"), "Salvar")
+
+		l.SetLocale("de")
+		assert.Equal(l.Translate("save"), "Save")
+		l.SetFallback("pt")
+		assert.Equal(l.Translate("save"), "Salvar")
+		assert.Equal(l.Translate("missing"), "missing")
+	})
+
+	t.Run("Right to left locales", func(t *testing.T) {
+		l := i18n.NewLocalizer()
+		for _, locale := range []string{"ar", "he", "fa", "ur", "ar-EG"} {
+			l.SetLocale(locale)
+			assert.True(l.IsRightToLeft(), locale)
+		}
+		for _, locale := range []string{"en", "ja", "ru", "az-Latn"} {
+			l.SetLocale(locale)
+			assert.False(l.IsRightToLeft(), locale)
+		}
+	})
+
+	t.Run("Load", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "i18n")
+		assert.Nil(err)
+		defer os.RemoveAll(dir)
+		jsonPath := filepath.Join(dir, "es.json")
+		poPath := filepath.Join(dir, "messages.po")
+		ioutil.WriteFile(jsonPath, []byte(`{"save": "Guardar"}`), 0644)
+		ioutil.WriteFile(poPath, []byte("msgid \"\"\nmsgstr \"Language: it\\n\"\n\nmsgid \"save\"\nmsgstr \"Salva\"\n"), 0644)
+
+		l := i18n.NewLocalizer()
+		assert.Nil(l.Load(jsonPath))
+		assert.Nil(l.Load(poPath))
+		l.SetLocale("es")
+		assert.Equal(l.Translate("save"), "Guardar")
+		l.SetLocale("it")
+		assert.Equal(l.Translate("save"), "Salva")
+
+		assert.Match("no such file", l.Load(filepath.Join(dir, "missing.json")).Error())
+		assert.Match("unsupported catalog format", l.LoadBytes("fr.yaml", nil).Error())
+	})
+}
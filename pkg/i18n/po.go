@@ -0,0 +1,390 @@
+package i18n
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// expression is a compiled gettext Plural-Forms expression, which returns
+// the msgstr index for n.
+type expression func(n int) int
+
+// poEntry is a single msgid and its translations.
+type poEntry struct {
+	context    *string
+	id         *string
+	idPlural   *string
+	isFuzzy    bool
+	isObsolete bool
+	strings    map[int]*string
+}
+
+// message returns the msgstr at index, or an empty string.
+func (e *poEntry) message(index int) string {
+	if value, ok := e.strings[index]; ok {
+		return *value
+	}
+	return ""
+}
+
+// ParsePO returns a Catalog from a gettext .po file. The Language header
+// takes precedence over the provided locale, which is only used for files
+// that do not have one. Fuzzy and untranslated entries are ignored, and
+// entries with a msgctxt are keyed by the context, a "\x04" separator and
+// the msgid, as they are in gettext.
+func ParsePO(locale string, data []byte) (*Catalog, error) {
+	entries, err := parsePOEntries(data)
+	if err != nil {
+		return nil, err
+	}
+
+	headers := map[string]string{}
+	for _, entry := range entries {
+		if entry.context == nil && entry.id != nil && *entry.id == "" {
+			for _, line := range strings.Split(entry.message(0), "\n") {
+				if index := strings.IndexByte(line, ':'); index > 0 {
+					headers[strings.TrimSpace(line[:index])] = strings.TrimSpace(line[index+1:])
+				}
+			}
+		}
+	}
+	if value := headers["Language"]; value != "" {
+		locale = value
+	}
+	if locale == "" {
+		return nil, fmt.Errorf("i18n: .po file has no Language header")
+	}
+
+	catalog := NewCatalog(locale)
+	if value, ok := headers["Plural-Forms"]; ok {
+		catalog.pluralForms, err = parsePluralForms(value)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, entry := range entries {
+		if entry.id == nil || *entry.id == "" || entry.isFuzzy || entry.isObsolete {
+			continue
+		}
+		key := *entry.id
+		if entry.context != nil {
+			key = *entry.context + "\x04" + key
+		}
+		if entry.idPlural == nil {
+			if message := entry.message(0); message != "" {
+				catalog.Add(key, message)
+			}
+			continue
+		}
+		forms := make([]string, len(entry.strings))
+		isTranslated := false
+		for index, message := range entry.strings {
+			if index < 0 || index >= len(forms) {
+				return nil, fmt.Errorf("i18n: msgstr[%d] is out of range for %q", index, key)
+			}
+			forms[index] = *message
+			isTranslated = isTranslated || *message != ""
+		}
+		if isTranslated {
+			catalog.messages[key] = forms
+		}
+	}
+	return catalog, nil
+}
+
+// parsePOEntries splits a .po file into entries.
+func parsePOEntries(data []byte) ([]*poEntry, error) {
+	entries := []*poEntry{}
+	entry := &poEntry{strings: map[int]*string{}}
+	// current is the string that continuation lines are appended to.
+	var current *string
+	hasStrings := false
+	isFuzzy := false
+
+	flush := func() {
+		if entry.id != nil {
+			entries = append(entries, entry)
+		}
+		entry = &poEntry{strings: map[int]*string{}}
+		current = nil
+		hasStrings = false
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		isObsolete := strings.HasPrefix(line, "#~")
+		if isObsolete {
+			line = strings.TrimSpace(line[2:])
+		}
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "#") {
+			if strings.HasPrefix(line, "#,") && strings.Contains(line, "fuzzy") {
+				isFuzzy = true
+			}
+			continue
+		}
+
+		if line[0] == '"' {
+			if current == nil {
+				return nil, fmt.Errorf("i18n: .po line %d: unexpected string", lineNumber)
+			}
+			value, err := strconv.Unquote(line)
+			if err != nil {
+				return nil, fmt.Errorf("i18n: .po line %d: invalid string %s", lineNumber, line)
+			}
+			*current += value
+			continue
+		}
+
+		index := strings.IndexFunc(line, unicode.IsSpace)
+		if index < 0 {
+			return nil, fmt.Errorf("i18n: .po line %d: missing string", lineNumber)
+		}
+		keyword := line[:index]
+		value, err := strconv.Unquote(strings.TrimSpace(line[index:]))
+		if err != nil {
+			return nil, fmt.Errorf("i18n: .po line %d: invalid string %s", lineNumber, line[index:])
+		}
+
+		if (keyword == "msgctxt" || keyword == "msgid") && hasStrings {
+			flush()
+		}
+		if keyword == "msgctxt" || (keyword == "msgid" && entry.context == nil) {
+			entry.isFuzzy = isFuzzy
+			isFuzzy = false
+		}
+		entry.isObsolete = entry.isObsolete || isObsolete
+
+		switch {
+		case keyword == "msgctxt":
+			entry.context = &value
+			current = entry.context
+		case keyword == "msgid":
+			entry.id = &value
+			current = entry.id
+		case keyword == "msgid_plural":
+			entry.idPlural = &value
+			current = entry.idPlural
+		case keyword == "msgstr":
+			hasStrings = true
+			entry.strings[0] = &value
+			current = &value
+		case strings.HasPrefix(keyword, "msgstr[") && strings.HasSuffix(keyword, "]"):
+			position, err := strconv.Atoi(keyword[len("msgstr[") : len(keyword)-1])
+			if err != nil {
+				return nil, fmt.Errorf("i18n: .po line %d: invalid keyword %s", lineNumber, keyword)
+			}
+			hasStrings = true
+			entry.strings[position] = &value
+			current = &value
+		default:
+			return nil, fmt.Errorf("i18n: .po line %d: unknown keyword %s", lineNumber, keyword)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	flush()
+	return entries, nil
+}
+
+// parsePluralForms compiles the plural expression from a Plural-Forms header
+// value (e.g., "nplurals=2; plural=(n != 1);").
+func parsePluralForms(value string) (expression, error) {
+	for _, field := range strings.Split(value, ";") {
+		field = strings.TrimSpace(field)
+		if strings.HasPrefix(field, "plural=") {
+			return parsePluralExpression(strings.TrimPrefix(field, "plural="))
+		}
+	}
+	return nil, fmt.Errorf("i18n: Plural-Forms has no plural expression: %q", value)
+}
+
+// parsePluralExpression compiles the C subset that gettext allows in plural
+// expressions: n, integers, parentheses, the ternary operator and the
+// logical, comparison and arithmetic operators.
+func parsePluralExpression(source string) (expression, error) {
+	p := &pluralParser{source: source}
+	result := p.ternary()
+	p.skipSpace()
+	if p.err == nil && p.index < len(p.source) {
+		p.fail("unexpected %q", p.source[p.index:])
+	}
+	if p.err != nil {
+		return nil, p.err
+	}
+	return result, nil
+}
+
+// pluralParser is a recursive descent parser for plural expressions. The
+// first error that is found is kept in err.
+type pluralParser struct {
+	err    error
+	index  int
+	source string
+}
+
+func (p *pluralParser) fail(format string, args ...interface{}) {
+	if p.err == nil {
+		p.err = fmt.Errorf("i18n: invalid plural expression %q: %s", p.source, fmt.Sprintf(format, args...))
+	}
+}
+
+func (p *pluralParser) skipSpace() {
+	for p.index < len(p.source) && p.source[p.index] == ' ' {
+		p.index++
+	}
+}
+
+// accept consumes the first of the provided operators that is next in the
+// source and returns it, or an empty string.
+func (p *pluralParser) accept(operators ...string) string {
+	p.skipSpace()
+	for _, operator := range operators {
+		if strings.HasPrefix(p.source[p.index:], operator) {
+			// Do not mistake "<=" for "<", or "!=" for "!".
+			rest := p.source[p.index+len(operator):]
+			if strings.Contains("!<>", operator) && strings.HasPrefix(rest, "=") {
+				continue
+			}
+			p.index += len(operator)
+			return operator
+		}
+	}
+	return ""
+}
+
+func boolToInt(value bool) int {
+	if value {
+		return 1
+	}
+	return 0
+}
+
+func (p *pluralParser) ternary() expression {
+	condition := p.binary(0)
+	if p.accept("?") == "" {
+		return condition
+	}
+	whenTrue := p.ternary()
+	if p.accept(":") == "" {
+		p.fail("missing ':'")
+	}
+	whenFalse := p.ternary()
+	return func(n int) int {
+		if condition(n) != 0 {
+			return whenTrue(n)
+		}
+		return whenFalse(n)
+	}
+}
+
+// pluralOperators lists binary operators from the lowest to the highest
+// precedence.
+var pluralOperators = [][]string{
+	{"||"},
+	{"&&"},
+	{"==", "!="},
+	{"<=", ">=", "<", ">"},
+	{"+", "-"},
+	{"*", "/", "%"},
+}
+
+func (p *pluralParser) binary(level int) expression {
+	if level == len(pluralOperators) {
+		return p.unary()
+	}
+	left := p.binary(level + 1)
+	for {
+		operator := p.accept(pluralOperators[level]...)
+		if operator == "" {
+			return left
+		}
+		left = applyOperator(operator, left, p.binary(level+1))
+	}
+}
+
+func applyOperator(operator string, left, right expression) expression {
+	return func(n int) int {
+		a, b := left(n), right(n)
+		switch operator {
+		case "||":
+			return boolToInt(a != 0 || b != 0)
+		case "&&":
+			return boolToInt(a != 0 && b != 0)
+		case "==":
+			return boolToInt(a == b)
+		case "!=":
+			return boolToInt(a != b)
+		case "<=":
+			return boolToInt(a <= b)
+		case ">=":
+			return boolToInt(a >= b)
+		case "<":
+			return boolToInt(a < b)
+		case ">":
+			return boolToInt(a > b)
+		case "+":
+			return a + b
+		case "-":
+			return a - b
+		case "*":
+			return a * b
+		}
+		if b == 0 {
+			return 0
+		}
+		if operator == "/" {
+			return a / b
+		}
+		return a % b
+	}
+}
+
+func (p *pluralParser) unary() expression {
+	if p.accept("!") != "" {
+		operand := p.unary()
+		return func(n int) int {
+			return boolToInt(operand(n) == 0)
+		}
+	}
+	return p.primary()
+}
+
+func (p *pluralParser) primary() expression {
+	p.skipSpace()
+	if p.accept("(") != "" {
+		result := p.ternary()
+		if p.accept(")") == "" {
+			p.fail("missing ')'")
+		}
+		return result
+	}
+	if p.accept("n") != "" {
+		return func(n int) int {
+			return n
+		}
+	}
+	start := p.index
+	for p.index < len(p.source) && p.source[p.index] >= '0' && p.source[p.index] <= '9' {
+		p.index++
+	}
+	value, err := strconv.Atoi(p.source[start:p.index])
+	if err != nil {
+		p.fail("expected a number at %d", start)
+		return func(n int) int {
+			return 0
+		}
+	}
+	return func(n int) int {
+		return value
+	}
+}
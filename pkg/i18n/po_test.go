@@ -0,0 +1,100 @@
+package i18n_test
+
+import (
+	"testing"
+
+	"github.com/waybeams/assert"
+	"github.com/waybeams/waybeams/pkg/i18n"
+)
+
+const polishPO = `# Polish translations.
+msgid ""
+msgstr ""
+"Language: pl\n"
+"Plural-Forms: nplurals=3; plural=(n==1 ? 0 : n%10>=2 && n%10<=4 && "
+"(n%100<10 || n%100>=20) ? 1 : 2);\n"
+
+#: main.go:10
+msgid "Hello"
+msgstr "Cześć"
+
+msgid "{0} file"
+msgid_plural "{0} files"
+msgstr[0] "{0} plik"
+msgstr[1] "{0} pliki"
+msgstr[2] "{0} plików"
+
+msgctxt "menu"
+msgid "Open"
+msgstr "Otwórz"
+
+#, fuzzy
+msgid "Close"
+msgstr "Zamknij"
+
+msgid "Untranslated"
+msgstr ""
+
+msgid "Multiple lines"
+msgstr ""
+"Pierwsza\n"
+"Druga"
+
+#~ msgid "Obsolete"
+#~ msgstr "Przestarzały"
+`
+
+func TestPO(t *testing.T) {
+	t.Run("Parses entries", func(t *testing.T) {
+		catalog, err := i18n.ParsePO("", []byte(polishPO))
+		assert.Nil(err)
+		assert.Equal(catalog.Locale(), "pl")
+
+		l := i18n.NewLocalizer()
+		l.AddCatalog(catalog)
+		l.SetLocale("pl")
+		assert.Equal(l.Translate("Hello"), "Cześć")
+		assert.Equal(l.Translate("Multiple lines"), "Pierwsza\nDruga")
+		assert.Equal(l.Translate("menu\x04Open"), "Otwórz")
+		assert.Equal(l.Translate("Open"), "Open")
+		assert.Equal(l.Translate("Close"), "Close", "Fuzzy entries are ignored")
+		assert.Equal(l.Translate("Untranslated"), "Untranslated")
+		assert.Equal(l.Translate("Obsolete"), "Obsolete")
+	})
+
+	t.Run("Plural-Forms", func(t *testing.T) {
+		catalog, err := i18n.ParsePO("", []byte(polishPO))
+		assert.Nil(err)
+		l := i18n.NewLocalizer()
+		l.AddCatalog(catalog)
+		l.SetLocale("pl")
+		assert.Equal(l.Translate("{0} file", 1), "1 plik")
+		assert.Equal(l.Translate("{0} file", 3), "3 pliki")
+		assert.Equal(l.Translate("{0} file", 5), "5 plików")
+		assert.Equal(l.Translate("{0} file", 12), "12 plików")
+		assert.Equal(l.Translate("{0} file", 22), "22 pliki")
+	})
+
+	t.Run("Locale is used without a Language header", func(t *testing.T) {
+		catalog, err := i18n.ParsePO("de", []byte("msgid \"Hello\"\nmsgstr \"Hallo\"\n"))
+		assert.Nil(err)
+		assert.Equal(catalog.Locale(), "de")
+
+		_, err = i18n.ParsePO("", []byte("msgid \"Hello\"\nmsgstr \"Hallo\"\n"))
+		assert.Match("no Language header", err.Error())
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		_, err := i18n.ParsePO("de", []byte("msgid \"Hello\"\nmsgstr Hallo\n"))
+		assert.Match("line 2: invalid string", err.Error())
+
+		_, err = i18n.ParsePO("de", []byte("\"Hello\"\n"))
+		assert.Match("line 1: unexpected string", err.Error())
+
+		_, err = i18n.ParsePO("de", []byte("msgfoo \"Hello\"\n"))
+		assert.Match("line 1: unknown keyword msgfoo", err.Error())
+
+		_, err = i18n.ParsePO("de", []byte("msgid \"\"\nmsgstr \"Plural-Forms: nplurals=2; plural=(n != 1;\\n\"\n"))
+		assert.Match("missing '\\)'", err.Error())
+	})
+}
@@ -0,0 +1,315 @@
+package input
+
+import (
+	"github.com/waybeams/waybeams/pkg/events"
+	"github.com/waybeams/waybeams/pkg/spec"
+)
+
+// MouseEventPayload is the payload of Pressed, Released, Clicked, Moved,
+// DragStarted and DragEnded events, where X and Y are the global cursor
+// position.
+type MouseEventPayload struct {
+	Button   MouseButton
+	Action   Action
+	Modifier ModifierKey
+	X        float64
+	Y        float64
+}
+
+// CaretReader is a focused text input that can report where its caret is,
+// so that the IME candidate window can be placed next to it.
+type CaretReader interface {
+	// CaretRect returns the caret bounds, relative to the Spec.
+	CaretRect() (x, y, width, height float64)
+}
+
+// Controller routes hover, focus, click, char, key and IME composition
+// gestures from a GestureSource into the Spec tree that was most recently
+// provided to Update.
+type Controller struct {
+	lastMoveTarget spec.ReadWriter
+	source         GestureSource
+	composer       CompositionSource
+	isComposing    bool
+	isDragging     bool
+	lastXpos       float64
+	lastYpos       float64
+	lastRoot       spec.ReadWriter
+	lastFocused    spec.ReadWriter
+	lastPressed    spec.ReadWriter
+	lastInputRect  [4]float64
+}
+
+// Update should be called on every frame and will collect any pending
+// changes from the configured GestureSource and then bubble as events
+// into the appropriate nodes of the tree.
+func (c *Controller) Update(root spec.ReadWriter) {
+	if c.lastRoot != nil && root != c.lastRoot {
+		// The tree was re-created, continue with the matching Specs in the
+		// new tree, so that focus, hover and press state is not left behind.
+		c.lastFocused = remap(c.lastFocused, root)
+		c.lastMoveTarget = remap(c.lastMoveTarget, root)
+		c.lastPressed = remap(c.lastPressed, root)
+		if c.lastFocused != nil {
+			root.SetFocusedSpec(c.lastFocused)
+		}
+	}
+	c.lastRoot = root
+	c.updateTextInputRect()
+
+	xpos, ypos := c.source.GetCursorPos()
+	if c.lastXpos == xpos && c.lastYpos == ypos {
+		return
+	}
+	c.lastXpos = xpos
+	c.lastYpos = ypos
+
+	target := spec.CoordToControl(root, xpos, ypos)
+	lastTarget := c.lastMoveTarget
+
+	if lastTarget != target {
+		if lastTarget != nil {
+			c.bubbleOn(lastTarget, events.New(events.Exited, lastTarget, nil))
+		}
+
+		if target.IsFocusable() {
+			cursorName := HandCursor
+			if target.IsText() || target.IsTextInput() {
+				cursorName = IBeamCursor
+			}
+			c.source.SetCursorByName(cursorName)
+
+			c.bubbleOn(target, events.New(events.Entered, target, nil))
+		} else {
+			c.source.SetCursorByName(ArrowCursor)
+		}
+	}
+
+	payload := &MouseEventPayload{X: xpos, Y: ypos}
+	if target != nil {
+		c.bubbleOn(target, events.New(events.Moved, target, payload))
+	}
+	c.lastMoveTarget = target
+
+	// The pressed Spec captures the cursor until the button is released, so
+	// that it can be dragged beyond its bounds.
+	pressed := c.lastPressed
+	if pressed != nil {
+		if !c.isDragging {
+			c.isDragging = true
+			c.bubbleOn(pressed, events.New(events.DragStarted, pressed, payload))
+		}
+		if pressed != target {
+			c.bubbleOn(pressed, events.New(events.Moved, pressed, payload))
+		}
+	}
+}
+
+func (c *Controller) onMouseButtonHandler(button MouseButton, action Action, mods ModifierKey) {
+	if c.lastRoot == nil || button == MouseButtonUnknown {
+		return
+	}
+
+	lastMoveTarget := c.lastMoveTarget
+	if button == MouseButton1 && lastMoveTarget != nil && lastMoveTarget.IsFocusable() {
+		payload := &MouseEventPayload{
+			Button:   button,
+			Action:   action,
+			Modifier: mods,
+			X:        c.lastXpos,
+			Y:        c.lastYpos,
+		}
+
+		if action == Press {
+			c.focusSpec(lastMoveTarget)
+			c.lastPressed = lastMoveTarget
+			c.bubbleOn(lastMoveTarget, events.New(events.Pressed, lastMoveTarget, payload))
+		} else if action == Release {
+			c.bubbleOn(lastMoveTarget, events.New(events.Released, lastMoveTarget, payload))
+			c.bubbleOn(lastMoveTarget, events.New(events.Clicked, lastMoveTarget, payload))
+		}
+	} else {
+		c.focusSpec(nil)
+	}
+
+	if button == MouseButton1 && action == Release {
+		c.endDrag(button, mods)
+	}
+}
+
+// endDrag releases the captured Spec, and emits DragEnded on it if the
+// cursor moved while the button was pressed.
+func (c *Controller) endDrag(button MouseButton, mods ModifierKey) {
+	pressed := c.lastPressed
+	isDragging := c.isDragging
+	c.lastPressed = nil
+	c.isDragging = false
+	if pressed != nil && isDragging {
+		payload := &MouseEventPayload{
+			Button:   button,
+			Action:   Release,
+			Modifier: mods,
+			X:        c.lastXpos,
+			Y:        c.lastYpos,
+		}
+		c.bubbleOn(pressed, events.New(events.DragEnded, pressed, payload))
+	}
+}
+
+func (c *Controller) focusSpec(s spec.ReadWriter) {
+	var lastFocused spec.ReadWriter
+
+	if s != nil {
+		lastFocused = s.FocusedSpec()
+	} else {
+		lastFocused = c.lastFocused
+	}
+
+	if lastFocused != nil && lastFocused != s {
+		if c.isComposing && lastFocused.IsTextInput() {
+			// Text input stops with the blur, so the composition would
+			// otherwise never end.
+			c.isComposing = false
+			c.bubbleOn(lastFocused, events.New(events.CompositionEnded, lastFocused, Composition{}))
+		}
+		lastFocused.SetFocusedSpec(nil)
+		c.bubbleOn(lastFocused, events.New(events.Blurred, lastFocused, s))
+		c.lastFocused = nil
+	}
+	if s != nil {
+		s.SetFocusedSpec(s)
+		c.bubbleOn(s, events.New(events.Focused, s, lastFocused))
+		c.lastFocused = s
+	}
+
+	if c.composer != nil {
+		if s != nil && s.IsTextInput() {
+			c.lastInputRect = [4]float64{}
+			c.composer.StartTextInput()
+			c.updateTextInputRect()
+		} else if lastFocused != nil && lastFocused.IsTextInput() {
+			c.isComposing = false
+			c.composer.StopTextInput()
+		}
+	}
+}
+
+// updateTextInputRect moves the IME candidate window to the caret of the
+// focused text input, whenever the caret has moved.
+func (c *Controller) updateTextInputRect() {
+	caret, ok := c.lastFocused.(CaretReader)
+	if c.composer == nil || !ok {
+		return
+	}
+	x, y, width, height := caret.CaretRect()
+	x, y = spec.LocalToGlobal(c.lastFocused, x, y)
+	rect := [4]float64{x, y, width, height}
+	if rect != c.lastInputRect {
+		c.lastInputRect = rect
+		c.composer.SetTextInputRect(x, y, width, height)
+	}
+}
+
+func (c *Controller) onCharHandler(char rune) {
+	if c.lastRoot == nil {
+		return
+	}
+	// Characters are routed to any focused Spec, so that controls other
+	// than text inputs can respond to typing.
+	focused := c.focusedInput()
+	if focused != nil {
+		c.bubbleOn(focused, events.New(events.CharEntered, focused, string(char)))
+	}
+}
+
+// onCompositionHandler routes IME compositions to the focused text input.
+// The committed text of a composition arrives as CharEntered, after
+// CompositionEnded.
+func (c *Controller) onCompositionHandler(action CompositionAction, composition Composition) {
+	if c.lastRoot == nil {
+		return
+	}
+	focused := c.focusedInput()
+	if focused == nil || !focused.IsTextInput() {
+		return
+	}
+	switch action {
+	case CompositionStart:
+		c.isComposing = true
+		c.bubbleOn(focused, events.New(events.CompositionStarted, focused, composition))
+	case CompositionUpdate:
+		c.bubbleOn(focused, events.New(events.CompositionUpdated, focused, composition))
+	case CompositionEnd:
+		c.isComposing = false
+		c.bubbleOn(focused, events.New(events.CompositionEnded, focused, composition))
+		if composition.Text != "" {
+			c.bubbleOn(focused, events.New(events.CharEntered, focused, composition.Text))
+		}
+	}
+}
+
+// IsComposing returns true while an IME composition is in progress.
+func (c *Controller) IsComposing() bool {
+	return c.isComposing
+}
+
+func (c *Controller) onKeyHandler(key Key, scancode int, action Action, mods ModifierKey) {
+	if c.lastRoot == nil {
+		return
+	}
+	// Keys are routed to any focused Spec, so that controls other than text
+	// inputs (e.g., a Checkbox) can be operated from the keyboard.
+	focused := c.focusedInput()
+	if focused != nil {
+		c.bubbleOn(focused, events.New(events.KeyEntered, focused, key))
+		if action == Release {
+			c.bubbleOn(focused, events.New(events.KeyReleased, focused, key))
+		} else {
+			// Repeats are delivered as presses, so that held keys keep
+			// moving the caret.
+			c.bubbleOn(focused, events.New(events.KeyPressed, focused, key))
+		}
+		if key == KeyEnter && action == Release {
+			c.bubbleOn(focused, events.New(events.EnterKeyReleased, focused, key))
+		}
+	}
+}
+
+// focusedInput returns the focused Spec, or nil if it is beneath a modal
+// overlay and should not receive keyboard input.
+func (c *Controller) focusedInput() spec.ReadWriter {
+	focused := c.lastFocused
+	if focused == nil || spec.IsBlocked(c.lastRoot, focused) {
+		return nil
+	}
+	return focused
+}
+
+// remap returns the Spec in root with the same Path as the provided Spec,
+// or nil if there is none.
+func remap(s spec.ReadWriter, root spec.ReadWriter) spec.ReadWriter {
+	if s == nil || spec.Root(s) == root {
+		return s
+	}
+	return spec.FirstByPath(root, spec.Path(s))
+}
+
+func (c *Controller) bubbleOn(s spec.ReadWriter, event events.Event) {
+	s.Bubble(event)
+	// Also Emit an Invalidated event on the root node, but include the node
+	// that triggered it.
+	c.lastRoot.Emit(events.New(events.Invalidated, s, nil))
+}
+
+// New returns a Controller that subscribes to the provided GestureSource.
+func New(source GestureSource) *Controller {
+	instance := &Controller{source: source}
+	source.SetCharCallback(instance.onCharHandler)
+	source.SetKeyCallback(instance.onKeyHandler)
+	source.SetMouseButtonCallback(instance.onMouseButtonHandler)
+	if composer, ok := source.(CompositionSource); ok {
+		instance.composer = composer
+		composer.SetCompositionCallback(instance.onCompositionHandler)
+	}
+	return instance
+}
@@ -0,0 +1,451 @@
+package input_test
+
+import (
+	"testing"
+
+	"github.com/waybeams/assert"
+	"github.com/waybeams/waybeams/pkg/ctrl"
+	"github.com/waybeams/waybeams/pkg/env/fake"
+	"github.com/waybeams/waybeams/pkg/events"
+	"github.com/waybeams/waybeams/pkg/input"
+	"github.com/waybeams/waybeams/pkg/layout"
+	"github.com/waybeams/waybeams/pkg/opts"
+	"github.com/waybeams/waybeams/pkg/spec"
+)
+
+func TestInputController(t *testing.T) {
+	var createTree = func() *spec.Spec {
+		root := ctrl.VBox(
+			opts.Key("Root"),
+			opts.BgColor(0xffcc00ff),
+			opts.Width(100),
+			opts.Height(100),
+			opts.Child(ctrl.Button(
+				opts.Key("Button"),
+				opts.FlexWidth(1),
+				opts.FlexHeight(1),
+			)),
+			opts.Child(ctrl.Label(
+				opts.Key("TextInput"),
+				opts.IsFocusable(true),
+				opts.IsTextInput(true),
+				opts.FlexWidth(1),
+				opts.FlexHeight(1),
+			)),
+			opts.Child(ctrl.Label(
+				opts.Key("Label"),
+				opts.FlexWidth(1),
+				opts.IsFocusable(true),
+				opts.IsText(true),
+				opts.FlexHeight(1),
+			)),
+		)
+
+		layout.Layout(root, fake.NewSurface())
+		return root
+	}
+
+	t.Run("Emits entered and exited events", func(t *testing.T) {
+		root := createTree()
+
+		received := []events.Event{}
+		var handler = func(e events.Event) {
+			received = append(received, e)
+		}
+		root.On(events.Exited, handler)
+		root.On(events.Entered, handler)
+
+		fakeSource := fake.NewFakeGestureSource()
+		controller := input.New(fakeSource)
+
+		fakeSource.SetCursorPos(10, 10)
+		controller.Update(root)
+		assert.Equal(received[0].Name(), events.Entered)
+		assert.Equal(spec.Path(received[0].Target().(spec.Reader)), spec.Path(root.ChildAt(0)), "entered 1")
+		assert.Equal(len(received), 1)
+		assert.Equal(fakeSource.CursorName, input.HandCursor)
+
+		fakeSource.SetCursorPos(10, 40)
+		controller.Update(root)
+
+		assert.Equal(len(received), 3)
+		assert.Equal(received[1].Name(), events.Exited)
+		assert.Equal(spec.Path(received[1].Target().(spec.Reader)), spec.Path(root.ChildAt(0)), "exited 1")
+
+		assert.Equal(received[2].Name(), events.Entered)
+		assert.Equal(spec.Path(received[2].Target().(spec.Reader)), spec.Path(root.ChildAt(1)), "entered 2")
+		assert.Equal(fakeSource.CursorName, input.IBeamCursor)
+
+		fakeSource.SetCursorPos(10, 70)
+		controller.Update(root)
+
+		assert.Equal(len(received), 5, "received should be five")
+
+		assert.Equal(received[3].Name(), events.Exited)
+		assert.Equal(spec.Path(received[3].Target().(spec.Reader)), spec.Path(root.ChildAt(1)), "exited 2")
+
+		assert.Equal(received[4].Name(), events.Entered)
+		assert.Equal(spec.Path(received[4].Target().(spec.Reader)), spec.Path(root.ChildAt(2)), "entered 2")
+	})
+
+	t.Run("Gestures to render", func(t *testing.T) {
+		root := createTree()
+		received := []events.Event{}
+		var handler = func(e events.Event) {
+			received = append(received, e)
+		}
+		root.On(events.Invalidated, handler)
+
+		fakeSource := fake.NewFakeGestureSource()
+		controller := input.New(fakeSource)
+		fakeSource.SetCursorPos(10, 10)
+		controller.Update(root)
+		assert.Equal(received[0].Name(), events.Invalidated)
+	})
+
+	t.Run("Clicks and focuses", func(t *testing.T) {
+		root := createTree()
+		received := []string{}
+		var handler = func(e events.Event) {
+			received = append(received, e.Name())
+		}
+		root.On(events.Pressed, handler)
+		root.On(events.Focused, handler)
+		root.On(events.Released, handler)
+		root.On(events.Clicked, handler)
+
+		fakeSource := fake.NewFakeGestureSource()
+		controller := input.New(fakeSource)
+		fakeSource.SetCursorPos(10, 10)
+		controller.Update(root)
+
+		fakeSource.MouseCallback(input.MouseButton1, input.Press, 0)
+		fakeSource.MouseCallback(input.MouseButton1, input.Release, 0)
+
+		assert.Equal(len(received), 4)
+		assert.Equal(received[0], events.Focused)
+		assert.Equal(received[1], events.Pressed)
+		assert.Equal(received[2], events.Released)
+		assert.Equal(received[3], events.Clicked)
+		assert.Equal(root.FocusedSpec(), root.ChildAt(0))
+	})
+
+	t.Run("Ignores unknown buttons", func(t *testing.T) {
+		root := createTree()
+		fakeSource := fake.NewFakeGestureSource()
+		controller := input.New(fakeSource)
+		fakeSource.SetCursorPos(10, 10)
+		controller.Update(root)
+		fakeSource.MouseCallback(input.MouseButton1, input.Press, 0)
+		fakeSource.MouseCallback(input.MouseButton1, input.Release, 0)
+
+		received := []string{}
+		var handler = func(e events.Event) {
+			received = append(received, e.Name())
+		}
+		root.On(events.Blurred, handler)
+		root.On(events.Pressed, handler)
+		root.On(events.Clicked, handler)
+
+		fakeSource.MouseCallback(input.MouseButtonUnknown, input.Press, 0)
+		fakeSource.MouseCallback(input.MouseButtonUnknown, input.Release, 0)
+		assert.Equal(len(received), 0)
+		assert.Equal(root.FocusedSpec(), root.ChildAt(0))
+	})
+
+	t.Run("Routes chars and keys to focused text input", func(t *testing.T) {
+		root := createTree()
+		textInput := root.ChildAt(1)
+		chars := []string{}
+		keys := []input.Key{}
+		enterCount := 0
+		textInput.On(events.CharEntered, func(e events.Event) {
+			chars = append(chars, e.Payload().(string))
+		})
+		textInput.On(events.KeyEntered, func(e events.Event) {
+			keys = append(keys, e.Payload().(input.Key))
+		})
+		textInput.On(events.EnterKeyReleased, func(e events.Event) {
+			enterCount++
+		})
+		pressed := 0
+		released := 0
+		textInput.On(events.KeyPressed, func(e events.Event) {
+			pressed++
+		})
+		textInput.On(events.KeyReleased, func(e events.Event) {
+			released++
+		})
+
+		fakeSource := fake.NewFakeGestureSource()
+		controller := input.New(fakeSource)
+
+		// Chars are ignored until a control has focus.
+		fakeSource.SetCursorPos(10, 40)
+		controller.Update(root)
+		fakeSource.CharCallback('a')
+		assert.Equal(len(chars), 0)
+
+		fakeSource.MouseCallback(input.MouseButton1, input.Press, 0)
+		fakeSource.MouseCallback(input.MouseButton1, input.Release, 0)
+		fakeSource.CharCallback('b')
+		fakeSource.KeyCallback(input.KeyEnter, 0, input.Press, 0)
+		fakeSource.KeyCallback(input.KeyEnter, 0, input.Repeat, 0)
+		fakeSource.KeyCallback(input.KeyEnter, 0, input.Release, 0)
+
+		assert.Equal(len(chars), 1)
+		assert.Equal(chars[0], "b")
+		assert.Equal(len(keys), 3)
+		assert.Equal(keys[0], input.KeyEnter)
+		assert.Equal(enterCount, 1)
+		assert.Equal(pressed, 2)
+		assert.Equal(released, 1)
+	})
+
+	t.Run("Routes chars and keys to other focused controls", func(t *testing.T) {
+		root := ctrl.VBox(
+			opts.Width(100),
+			opts.Height(100),
+			opts.Child(ctrl.Checkbox(opts.FlexWidth(1), opts.FlexHeight(1))),
+		)
+		layout.Layout(root, fake.NewSurface())
+		checkbox := root.ChildAt(0).(*ctrl.CheckableSpec)
+		chars := []string{}
+		checkbox.On(events.CharEntered, func(e events.Event) {
+			chars = append(chars, e.Payload().(string))
+		})
+
+		fakeSource := fake.NewFakeGestureSource()
+		controller := input.New(fakeSource)
+		fakeSource.KeyCallback(input.KeySpace, 0, input.Release, 0)
+		assert.False(checkbox.IsChecked())
+
+		fakeSource.SetCursorPos(10, 10)
+		controller.Update(root)
+		fakeSource.MouseCallback(input.MouseButton1, input.Press, 0)
+		fakeSource.MouseCallback(input.MouseButton1, input.Release, 0)
+		assert.True(checkbox.IsChecked())
+		fakeSource.KeyCallback(input.KeySpace, 0, input.Press, 0)
+		fakeSource.KeyCallback(input.KeySpace, 0, input.Release, 0)
+		assert.False(checkbox.IsChecked())
+		fakeSource.CharCallback('x')
+		assert.Equal(len(chars), 1)
+	})
+
+	t.Run("Blocks keys to controls beneath a modal overlay", func(t *testing.T) {
+		root := ctrl.VBox(
+			opts.Width(100),
+			opts.Height(100),
+			opts.Child(ctrl.Checkbox(opts.FlexWidth(1), opts.FlexHeight(1))),
+			opts.Child(ctrl.Box(opts.IsOverlay(true), opts.IsModal(true), opts.Visible(false), opts.Width(50), opts.Height(50))),
+		)
+		layout.Layout(root, fake.NewSurface())
+		checkbox := root.ChildAt(0).(*ctrl.CheckableSpec)
+
+		fakeSource := fake.NewFakeGestureSource()
+		controller := input.New(fakeSource)
+		fakeSource.SetCursorPos(10, 10)
+		controller.Update(root)
+		fakeSource.MouseCallback(input.MouseButton1, input.Press, 0)
+		fakeSource.MouseCallback(input.MouseButton1, input.Release, 0)
+		assert.True(checkbox.IsChecked())
+
+		root.ChildAt(1).SetVisible(true)
+		fakeSource.KeyCallback(input.KeySpace, 0, input.Press, 0)
+		fakeSource.KeyCallback(input.KeySpace, 0, input.Release, 0)
+		assert.True(checkbox.IsChecked(), "Keys do not reach the Checkbox")
+
+		root.ChildAt(1).SetVisible(false)
+		fakeSource.KeyCallback(input.KeySpace, 0, input.Press, 0)
+		fakeSource.KeyCallback(input.KeySpace, 0, input.Release, 0)
+		assert.False(checkbox.IsChecked())
+	})
+
+	t.Run("Pressed control captures the cursor until released", func(t *testing.T) {
+		root := createTree()
+		button := root.ChildAt(0)
+		var received []string
+		var last *input.MouseEventPayload
+		handler := func(e events.Event) {
+			received = append(received, e.Name())
+			last = e.Payload().(*input.MouseEventPayload)
+		}
+		for _, name := range []string{events.Pressed, events.Moved, events.DragStarted, events.DragEnded} {
+			button.On(name, handler)
+		}
+
+		fakeSource := fake.NewFakeGestureSource()
+		controller := input.New(fakeSource)
+		fakeSource.SetCursorPos(10, 10)
+		controller.Update(root)
+		fakeSource.MouseCallback(input.MouseButton1, input.Press, 0)
+		assert.Equal(last.X, 10.0)
+		assert.Equal(last.Y, 10.0)
+
+		// The cursor leaves the button, which still receives Moved.
+		fakeSource.SetCursorPos(20, 80)
+		controller.Update(root)
+		assert.Equal(last.Y, 80.0)
+		fakeSource.MouseCallback(input.MouseButton1, input.Release, 0)
+		fakeSource.SetCursorPos(30, 80)
+		controller.Update(root)
+
+		assert.Equal(received, []string{
+			events.Moved,
+			events.Pressed,
+			events.DragStarted,
+			events.Moved,
+			events.DragEnded,
+		})
+	})
+
+	t.Run("Routes IME compositions to focused text input", func(t *testing.T) {
+		root := createTree()
+		textInput := root.ChildAt(1)
+		received := []string{}
+		var compositions []input.Composition
+		textInput.On(events.CharEntered, func(e events.Event) {
+			received = append(received, e.Name()+":"+e.Payload().(string))
+		})
+		compositionHandler := func(e events.Event) {
+			received = append(received, e.Name())
+			compositions = append(compositions, e.Payload().(input.Composition))
+		}
+		textInput.On(events.CompositionStarted, compositionHandler)
+		textInput.On(events.CompositionUpdated, compositionHandler)
+		textInput.On(events.CompositionEnded, compositionHandler)
+
+		fakeSource := fake.NewFakeGestureSource()
+		controller := input.New(fakeSource)
+		fakeSource.SetCursorPos(10, 40)
+		controller.Update(root)
+
+		// Compositions are ignored until a text input has focus.
+		fakeSource.Compose(input.CompositionStart, "")
+		assert.Equal(len(received), 0)
+		assert.False(fakeSource.IsTextInputActive)
+
+		fakeSource.MouseCallback(input.MouseButton1, input.Press, 0)
+		fakeSource.MouseCallback(input.MouseButton1, input.Release, 0)
+		assert.True(fakeSource.IsTextInputActive)
+
+		fakeSource.Compose(input.CompositionStart, "")
+		assert.True(controller.IsComposing())
+		fakeSource.Compose(input.CompositionUpdate, "にほ")
+		fakeSource.Compose(input.CompositionUpdate, "日本")
+		fakeSource.Compose(input.CompositionEnd, "日本")
+		assert.False(controller.IsComposing())
+
+		// Cancelled compositions do not enter any text.
+		fakeSource.Compose(input.CompositionStart, "")
+		fakeSource.Compose(input.CompositionEnd, "")
+
+		assert.Equal(received, []string{
+			events.CompositionStarted,
+			events.CompositionUpdated,
+			events.CompositionUpdated,
+			events.CompositionEnded,
+			events.CharEntered + ":日本",
+			events.CompositionStarted,
+			events.CompositionEnded,
+		})
+		assert.Equal(compositions[2], input.Composition{Cursor: 6, Text: "日本"})
+
+		// Focusing anything else stops text input.
+		fakeSource.SetCursorPos(10, 10)
+		controller.Update(root)
+		fakeSource.MouseCallback(input.MouseButton1, input.Press, 0)
+		assert.False(fakeSource.IsTextInputActive)
+	})
+
+	t.Run("Ends a composition when the text input is blurred", func(t *testing.T) {
+		s := fake.NewSurface()
+		root := ctrl.VBox(
+			opts.Width(100),
+			opts.Height(100),
+			opts.Child(ctrl.Button(opts.Key("Button"), opts.FlexWidth(1), opts.FlexHeight(1))),
+			opts.Child(ctrl.TextInput(opts.Key("TextInput"), opts.FlexWidth(1), opts.FlexHeight(1), opts.Text("ab"))),
+		)
+		layout.Layout(root, s)
+		textInput := spec.FirstByKey(root, "TextInput").(*ctrl.TextInputSpec)
+		ended := []input.Composition{}
+		textInput.On(events.CompositionEnded, func(e events.Event) {
+			ended = append(ended, e.Payload().(input.Composition))
+		})
+
+		fakeSource := fake.NewFakeGestureSource()
+		controller := input.New(fakeSource)
+		fakeSource.SetCursorPos(10, 80)
+		controller.Update(root)
+		fakeSource.MouseCallback(input.MouseButton1, input.Press, 0)
+		fakeSource.MouseCallback(input.MouseButton1, input.Release, 0)
+		fakeSource.Compose(input.CompositionStart, "")
+		fakeSource.Compose(input.CompositionUpdate, "にほ")
+		assert.True(textInput.IsComposing())
+
+		fakeSource.SetCursorPos(10, 10)
+		controller.Update(root)
+		fakeSource.MouseCallback(input.MouseButton1, input.Press, 0)
+		assert.False(controller.IsComposing())
+		assert.False(textInput.IsComposing())
+		assert.Equal(ended, []input.Composition{{}})
+		assert.Equal(textInput.Text(), "ab", "Cancelled preedit text is not entered")
+	})
+
+	t.Run("Places the IME candidate window at the caret", func(t *testing.T) {
+		s := fake.NewSurface()
+		root := ctrl.VBox(
+			opts.Padding(10),
+			opts.Child(ctrl.TextInput(opts.Key("TextInput"), opts.Padding(5), opts.Text("abc"))),
+		)
+		layout.Layout(root, s)
+		textInput := spec.FirstByKey(root, "TextInput")
+
+		fakeSource := fake.NewFakeGestureSource()
+		controller := input.New(fakeSource)
+		fakeSource.SetCursorPos(20, 20)
+		controller.Update(root)
+		fakeSource.MouseCallback(input.MouseButton1, input.Press, 0)
+
+		x, y, width, height := textInput.(input.CaretReader).CaretRect()
+		assert.True(x > 5)
+		assert.Equal(fakeSource.TextInputRect, [4]float64{x + 10, y + 10, width, height})
+
+		// Moving the caret moves the candidate window on the next Update.
+		textInput.Emit(events.New(events.KeyPressed, textInput, input.KeyHome))
+		layout.Layout(root, s)
+		controller.Update(root)
+		assert.Equal(fakeSource.TextInputRect[0], 15.5)
+	})
+
+	t.Run("Follows focus into a re-created tree", func(t *testing.T) {
+		previous := createTree()
+		fakeSource := fake.NewFakeGestureSource()
+		controller := input.New(fakeSource)
+		fakeSource.SetCursorPos(10, 40)
+		controller.Update(previous)
+		fakeSource.MouseCallback(input.MouseButton1, input.Press, 0)
+		fakeSource.MouseCallback(input.MouseButton1, input.Release, 0)
+
+		root := createTree()
+		textInput := root.ChildAt(1)
+		chars := []string{}
+		textInput.On(events.CharEntered, func(e events.Event) {
+			chars = append(chars, e.Payload().(string))
+		})
+		blurred := 0
+		textInput.On(events.Blurred, func(e events.Event) {
+			blurred++
+		})
+		controller.Update(root)
+		assert.Equal(root.FocusedSpec(), textInput)
+		fakeSource.CharCallback('a')
+		assert.Equal(chars, []string{"a"})
+
+		fakeSource.SetCursorPos(10, 10)
+		controller.Update(root)
+		fakeSource.MouseCallback(input.MouseButton1, input.Press, 0)
+		assert.Equal(blurred, 1)
+		assert.Equal(root.FocusedSpec(), root.ChildAt(0))
+	})
+}
@@ -0,0 +1,121 @@
+package input
+
+import (
+	"github.com/waybeams/waybeams/pkg/events"
+)
+
+// Action describes the state transition of a mouse button or key.
+type Action int
+
+const (
+	Release Action = iota
+	Press
+	Repeat
+)
+
+// Cursor is an environment-neutral name for the pointer shape.
+type Cursor int
+
+const (
+	ArrowCursor Cursor = iota
+	HandCursor
+	IBeamCursor
+	CrosshairCursor
+	HResizeCursor
+	VResizeCursor
+)
+
+// Key is an environment-neutral identifier for keys that have behavior
+// associated with them. Printable characters arrive through the
+// CharCallback instead.
+type Key int
+
+const (
+	KeyUnknown Key = iota
+	KeyBackspace
+	KeyDelete
+	KeyDown
+	KeyEnd
+	KeyEnter
+	KeyEscape
+	KeyHome
+	KeyLeft
+	KeyPageDown
+	KeyPageUp
+	KeyRight
+	KeySpace
+	KeyTab
+	KeyUp
+)
+
+// ModifierKey is a bit mask of the modifier keys held during a gesture.
+type ModifierKey int
+
+const (
+	ModShift ModifierKey = 1 << iota
+	ModControl
+	ModAlt
+	ModSuper
+)
+
+// MouseButton identifies which pointer button was used.
+type MouseButton int
+
+const (
+	MouseButton1 MouseButton = iota
+	MouseButton2
+	MouseButton3
+	// MouseButtonUnknown is any other button (e.g., back or forward), which
+	// the Controller ignores.
+	MouseButtonUnknown
+)
+
+// CompositionAction describes a step in an input method editor (IME)
+// composition, which is how Chinese, Japanese and Korean text (among
+// others) is entered.
+type CompositionAction int
+
+const (
+	CompositionStart CompositionAction = iota
+	CompositionUpdate
+	CompositionEnd
+)
+
+// Composition is the uncommitted (preedit) text of an IME composition. When
+// a composition ends, Text is the committed text, which is empty if the
+// composition was cancelled.
+type Composition struct {
+	// Cursor is the byte offset of the IME cursor within Text.
+	Cursor int
+	Text   string
+}
+
+type CharCallback func(char rune)
+type CompositionCallback func(action CompositionAction, composition Composition)
+type KeyCallback func(key Key, scancode int, action Action, mods ModifierKey)
+type MouseButtonCallback func(button MouseButton, action Action, mods ModifierKey)
+
+// GestureSource is the small surface that each environment (glfw, browser,
+// fake) adapts its native input events to so that a single Controller can
+// route them into the Spec tree.
+type GestureSource interface {
+	GetCursorPos() (xpos, ypos float64)
+	SetCursorByName(name Cursor)
+	SetCharCallback(callback CharCallback) events.Unsubscriber
+	SetKeyCallback(callback KeyCallback) events.Unsubscriber
+	SetMouseButtonCallback(callback MouseButtonCallback) events.Unsubscriber
+}
+
+// CompositionSource is implemented by GestureSources that can report IME
+// compositions before they are committed. Sources that only report
+// committed text through the CharCallback (e.g., glfw) do not implement it.
+type CompositionSource interface {
+	SetCompositionCallback(callback CompositionCallback) events.Unsubscriber
+	// SetTextInputRect places the IME candidate window near the provided
+	// rectangle, in window coordinates, which surrounds the caret.
+	SetTextInputRect(x, y, width, height float64)
+	// StartTextInput is called when a text input gains focus, and
+	// StopTextInput is called when it loses focus.
+	StartTextInput()
+	StopTextInput()
+}
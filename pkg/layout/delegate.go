@@ -6,6 +6,7 @@ import "github.com/waybeams/waybeams/pkg/spec"
 type Delegate interface {
 	ActualSize(d spec.Reader) float64
 	Align(d spec.Reader) spec.Alignment
+	Axis() spec.LayoutAxis
 	Flex(d spec.Reader) float64 // GetPercent?
 	IsFlexible(d spec.Reader) bool
 	LayoutSpec(c spec.ReadWriter) (updatedSize float64)
@@ -24,7 +25,6 @@ type Delegate interface {
 	Size(d spec.Reader) float64
 
 	/*
-		Axis() spec.LayoutAxis
 		ChildrenSize(d spec.Reader) float64
 		InferredSize(d spec.Reader) float64
 		Position(d spec.Reader) float64
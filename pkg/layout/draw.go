@@ -5,9 +5,50 @@ import (
 	"github.com/waybeams/waybeams/pkg/views"
 )
 
-// Draw the provided spec tree onto the provided Surface
+// Draw the provided spec tree onto the provided Surface. Invisible and
+// collapsed Specs are skipped along with their descendants, and Opacity is
+// multiplied down the tree and applied as the Surface global alpha.
+// Overlays are drawn after the rest of the tree, so that they appear above
+// all other content, and each modal overlay is preceded by a backdrop that
+// dims everything beneath it.
 func Draw(r spec.Reader, s spec.Surface) {
+	drawWithAlpha(r, s, 1)
+	for _, overlay := range spec.Overlays(r) {
+		if overlay.IsModal() {
+			s.SetGlobalAlpha(1)
+			views.BackdropView(s, r)
+		}
+		// Offset the Surface to the origin of the overlay's grandparent, so
+		// that the overlay is drawn relative to its parent, as usual.
+		parent := overlay.Parent()
+		x, y := spec.LocalToGlobal(parent, 0, 0)
+		base := spec.NewOffsetSurfaceAt(x-parent.X(), y-parent.Y(), s)
+		drawWithAlpha(overlay, base, ancestorAlpha(overlay))
+	}
+	s.SetGlobalAlpha(1)
+}
+
+// ancestorAlpha returns the product of the Opacity of each ancestor of the
+// provided Spec.
+func ancestorAlpha(r spec.Reader) float64 {
+	alpha := 1.0
+	for parent := r.Parent(); parent != nil; parent = parent.Parent() {
+		alpha *= parent.Opacity()
+	}
+	return alpha
+}
+
+func drawWithAlpha(r spec.Reader, s spec.Surface, parentAlpha float64) {
+	if !r.Visible() || r.Collapsed() {
+		return
+	}
+	alpha := parentAlpha * r.Opacity()
+	if alpha <= 0 {
+		return
+	}
+
 	s = spec.NewOffsetSurface(r, s)
+	s.SetGlobalAlpha(alpha)
 	view := r.View()
 	if view == nil {
 		view = views.RectangleView
@@ -15,6 +56,9 @@ func Draw(r spec.Reader, s spec.Surface) {
 	view(s, r)
 
 	for _, child := range r.Children() {
-		Draw(child, s)
+		// Overlays are drawn after the rest of the tree.
+		if !child.IsOverlay() {
+			drawWithAlpha(child, s, alpha)
+		}
 	}
 }
@@ -0,0 +1,134 @@
+package layout_test
+
+import (
+	"testing"
+
+	"github.com/waybeams/assert"
+	"github.com/waybeams/waybeams/pkg/ctrl"
+	surface "github.com/waybeams/waybeams/pkg/env/fake"
+	"github.com/waybeams/waybeams/pkg/layout"
+	"github.com/waybeams/waybeams/pkg/opts"
+	"github.com/waybeams/waybeams/pkg/spec"
+	"github.com/waybeams/waybeams/pkg/views"
+)
+
+func TestDraw(t *testing.T) {
+	t.Run("Skips invisible and collapsed subtrees", func(t *testing.T) {
+		root := ctrl.VBox(
+			opts.Width(100),
+			opts.Height(100),
+			opts.Child(ctrl.Box(opts.Visible(false), opts.BgColor(0x111111ff),
+				opts.Child(ctrl.Box(opts.BgColor(0x222222ff))))),
+			opts.Child(ctrl.Box(opts.Collapsed(true), opts.BgColor(0x333333ff))),
+			opts.Child(ctrl.Box(opts.BgColor(0x444444ff))),
+		)
+		s := surface.NewSurface()
+		layout.Draw(root, s)
+
+		for _, command := range s.CommandsNamed("SetFillColor") {
+			color := command.Args[0].(uint)
+			assert.True(color != 0x111111ff && color != 0x222222ff && color != 0x333333ff)
+		}
+		assert.Equal(s.CommandsNamed("SetFillColor")[1].Args[0], uint(0x444444ff))
+	})
+
+	t.Run("Composes opacity down the tree", func(t *testing.T) {
+		root := ctrl.Box(
+			opts.Opacity(0.5),
+			opts.Child(ctrl.Box(opts.Opacity(0.5))),
+		)
+		s := surface.NewSurface()
+		layout.Draw(root, s)
+
+		alphas := s.CommandsNamed("SetGlobalAlpha")
+		assert.Equal(alphas[0].Args[0], 0.5)
+		assert.Equal(alphas[1].Args[0], 0.25)
+		assert.Equal(alphas[2].Args[0], 1.0, "Restores alpha when finished")
+	})
+
+	t.Run("Skips fully transparent subtrees", func(t *testing.T) {
+		root := ctrl.Box(opts.Opacity(0), opts.Child(ctrl.Box()))
+		s := surface.NewSurface()
+		layout.Draw(root, s)
+		assert.Equal(len(s.CommandsNamed("Fill")), 0)
+	})
+
+	t.Run("Invisible specs are not hit", func(t *testing.T) {
+		root := ctrl.HBox(
+			opts.Width(200),
+			opts.Height(100),
+			opts.Child(ctrl.Button(opts.Key("hidden"), opts.Visible(false), opts.FlexWidth(1), opts.FlexHeight(1))),
+			opts.Child(ctrl.Button(opts.Key("shown"), opts.FlexWidth(1), opts.FlexHeight(1))),
+		)
+		layout.Layout(root, surface.NewSurface())
+
+		assert.Equal(spec.FirstByKey(root, "shown").X(), 100.0, "Invisible specs still take space")
+		assert.Equal(spec.CoordToControl(root, 50, 50), root)
+		assert.Equal(spec.CoordToControl(root, 150, 50).Key(), "shown")
+	})
+	t.Run("Draws and hits overlays above the rest of the tree", func(t *testing.T) {
+		root := ctrl.VBox(
+			opts.HAlign(spec.AlignLeft),
+			opts.Width(200),
+			opts.Height(200),
+			opts.Child(ctrl.Box(opts.Key("anchor"), opts.IsFocusable(true), opts.BgColor(0x111111ff), opts.Width(100), opts.Height(20),
+				opts.Child(ctrl.VBox(opts.IsOverlay(true), opts.BgColor(0x333333ff),
+					opts.Child(ctrl.Box(opts.Key("item"), opts.Height(50))),
+				)),
+			)),
+			opts.Child(ctrl.Box(opts.Key("below"), opts.IsFocusable(true), opts.BgColor(0x222222ff), opts.Width(100), opts.Height(20))),
+		)
+		layout.Layout(root, surface.NewSurface())
+		s := surface.NewSurface()
+		layout.Draw(root, s)
+
+		var colors []uint
+		for _, command := range s.CommandsNamed("SetFillColor") {
+			if color := command.Args[0].(uint); color != 0 {
+				colors = append(colors, color)
+			}
+		}
+		assert.Equal(colors, []uint{0x111111ff, 0x222222ff, 0x333333ff})
+		rects := s.CommandsNamed("Rect")
+		assert.Equal(rects[6].Args, []interface{}{0.0, 20.0, 100.0, 50.0}, "The overlay is drawn below its parent")
+
+		assert.Equal(spec.CoordToControl(root, 50, 30).Key(), "anchor", "The overlay covers the next sibling")
+		assert.Equal(spec.CoordToControl(root, 150, 30), root)
+	})
+	t.Run("Dims and blocks the layers beneath a modal overlay", func(t *testing.T) {
+		root := ctrl.Box(
+			opts.HAlign(spec.AlignLeft),
+			opts.VAlign(spec.AlignTop),
+			opts.Width(200),
+			opts.Height(200),
+			opts.Child(ctrl.Box(opts.Key("button"), opts.IsFocusable(true), opts.BgColor(0x111111ff), opts.Width(100), opts.Height(20))),
+			opts.Child(ctrl.Box(opts.Key("dialog"), opts.IsOverlay(true), opts.IsModal(true), opts.Placement(spec.PlaceCenter),
+				opts.HAlign(spec.AlignLeft), opts.VAlign(spec.AlignTop),
+				opts.BgColor(0x333333ff), opts.Width(100), opts.Height(100),
+				opts.Child(ctrl.Box(opts.Key("ok"), opts.IsFocusable(true), opts.Width(40), opts.Height(20))),
+			)),
+		)
+		layout.Layout(root, surface.NewSurface())
+		s := surface.NewSurface()
+		layout.Draw(root, s)
+
+		var colors []uint
+		for _, command := range s.CommandsNamed("SetFillColor") {
+			if color := command.Args[0].(uint); color != 0 {
+				colors = append(colors, color)
+			}
+		}
+		assert.Equal(colors, []uint{0x111111ff, views.DefaultBackdropColor, 0x333333ff})
+
+		button := spec.FirstByKey(root, "button")
+		dialog := spec.FirstByKey(root, "dialog")
+		assert.Equal(spec.CoordToControl(root, 10, 10).Key(), "dialog", "The button is beneath the modal")
+		assert.Equal(spec.CoordToControl(root, 60, 60).Key(), "ok")
+		assert.True(spec.IsBlocked(root, button))
+		assert.False(spec.IsBlocked(root, spec.FirstByKey(root, "ok")))
+
+		dialog.SetVisible(false)
+		assert.Equal(spec.CoordToControl(root, 10, 10).Key(), "button")
+		assert.False(spec.IsBlocked(root, button))
+	})
+}
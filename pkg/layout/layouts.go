@@ -21,7 +21,7 @@ func init() {
 // Measure the provided tree, using leaf-first traversal.
 func Measure(r spec.ReadWriter, s spec.Surface) {
 	// Leaf first traversal
-	for _, child := range r.Children() {
+	for _, child := range getParentLayoutChildren(r) {
 		Measure(child, s)
 	}
 	if r.IsMeasured() {
@@ -31,8 +31,82 @@ func Measure(r spec.ReadWriter, s spec.Surface) {
 	}
 }
 
-// Layout the provided control and all of it's children.
+// Layout the provided control and all of it's children, followed by each
+// visible overlay, which is placed against its anchor once the rest of the
+// tree has been laid out.
 func Layout(r spec.ReadWriter, s spec.Surface) spec.ReadWriter {
+	layoutTree(r, s)
+	for _, overlay := range spec.Overlays(r) {
+		anchor := overlayAnchor(overlay, r)
+		placement := overlay.Placement()
+		if anchor == overlay.Parent() && (placement == spec.PlaceBelow || placement == spec.PlaceAbove) {
+			// Like a drop down list, an overlay above or below its parent is
+			// at least as wide as it.
+			overlay.SetMinWidth(math.Max(overlay.MinWidth(), anchor.Width()))
+		}
+		layoutTree(overlay, s)
+		placeOverlay(overlay, anchor, r)
+	}
+	return r
+}
+
+// overlayAnchor returns the Spec with the OverlayAnchor Key of the provided
+// overlay, or the parent of the overlay if there is no such Spec.
+func overlayAnchor(overlay spec.ReadWriter, root spec.ReadWriter) spec.ReadWriter {
+	if key := overlay.OverlayAnchor(); key != "" {
+		if anchor := spec.FirstByKey(root, key); anchor != nil {
+			return anchor
+		}
+	}
+	return overlay.Parent()
+}
+
+// placeOverlay positions an overlay on the side of its anchor that was
+// selected by Placement. The overlay is moved to the opposite side if it
+// would otherwise extend beyond the root and there is room there, and is
+// then shifted to remain within the root.
+func placeOverlay(overlay, anchor, root spec.ReadWriter) {
+	width, height := overlay.Width(), overlay.Height()
+	anchorX, anchorY := spec.LocalToGlobal(anchor, 0, 0)
+	anchorRight, anchorBottom := anchorX+anchor.Width(), anchorY+anchor.Height()
+	rootX, rootY := spec.LocalToGlobal(root, 0, 0)
+	right, bottom := rootX+root.Width(), rootY+root.Height()
+
+	x, y := anchorX, anchorY
+	switch overlay.Placement() {
+	case spec.PlaceAbove:
+		y = anchorY - height
+		if y < rootY && anchorBottom+height <= bottom {
+			y = anchorBottom
+		}
+	case spec.PlaceLeft:
+		x = anchorX - width
+		if x < rootX && anchorRight+width <= right {
+			x = anchorRight
+		}
+	case spec.PlaceRight:
+		x = anchorRight
+		if x+width > right && anchorX-width >= rootX {
+			x = anchorX - width
+		}
+	case spec.PlaceCenter:
+		x = rootX + (root.Width()-width)/2
+		y = rootY + (root.Height()-height)/2
+	default:
+		y = anchorBottom
+		if y+height > bottom && anchorY-height >= rootY {
+			y = anchorY - height
+		}
+	}
+	x = math.Max(rootX, math.Min(x, right-width))
+	y = math.Max(rootY, math.Min(y, bottom-height))
+
+	parentX, parentY := spec.LocalToGlobal(overlay.Parent(), 0, 0)
+	overlay.SetX(x - parentX)
+	overlay.SetY(y - parentY)
+}
+
+func layoutTree(r spec.ReadWriter, s spec.Surface) spec.ReadWriter {
 	s = spec.NewOffsetSurface(r, s)
 	Measure(r, s)
 	if r.ChildCount() == 0 {
@@ -52,7 +126,7 @@ func None(delegate Delegate, d spec.ReadWriter) (childrenSize float64) {
 
 func layoutStackChildren(d spec.ReadWriter, delegate Delegate) float64 {
 	maxSize := 0.0
-	for _, child := range d.Children() {
+	for _, child := range getParentLayoutChildren(d) {
 		maxSize = math.Max(maxSize, delegate.LayoutSpec(child))
 	}
 	return maxSize
@@ -60,7 +134,7 @@ func layoutStackChildren(d spec.ReadWriter, delegate Delegate) float64 {
 
 func layoutFlowChildren(delegate Delegate, d spec.ReadWriter) (childrenSize float64) {
 	var lastChild spec.ReadWriter
-	for _, lastChild = range d.Children() {
+	for _, lastChild = range getParentLayoutChildren(d) {
 		delegate.LayoutSpec(lastChild)
 	}
 	if lastChild == nil {
@@ -105,7 +179,19 @@ func FlowOnAxis(delegate Delegate, d spec.ReadWriter) (childrenSize float64) {
 }
 
 func notExcludedFromLayout(d spec.Reader) bool {
-	return !d.ExcludeFromLayout()
+	return !d.ExcludeFromLayout() && inParentLayout(d)
+}
+
+// inParentLayout returns true for children that belong to the layout of
+// their parent, which collapsed children and overlays do not.
+func inParentLayout(d spec.Reader) bool {
+	return !d.Collapsed() && !d.IsOverlay()
+}
+
+// Collect the children that have not been collapsed, including those that
+// are excluded from layout, but not overlays.
+func getParentLayoutChildren(d spec.ReadWriter) []spec.ReadWriter {
+	return spec.FilteredChildren(d, inParentLayout)
 }
 
 // Collect the layoutable children of a Displayable
@@ -115,7 +201,7 @@ func getLayoutableChildren(d spec.ReadWriter) []spec.ReadWriter {
 
 func getFlexibleChildren(delegate Delegate, d spec.ReadWriter) []spec.ReadWriter {
 	return spec.FilteredChildren(d, func(child spec.Reader) bool {
-		isExcluded := child.ExcludeFromLayout()
+		isExcluded := !notExcludedFromLayout(child)
 		isFlexible := delegate.IsFlexible(child)
 		return isFlexible && !isExcluded
 	})
@@ -179,8 +265,15 @@ func flowPositionChildren(delegate Delegate, s spec.ReadWriter) (childrenSize fl
 	paddingFirst := delegate.PaddingFirst(s)
 	position := paddingFirst
 	gutter := s.Gutter()
+	// Right to left flows are mirrored, so that the first child is placed
+	// against the last padding.
+	isMirrored := delegate.Axis() == spec.LayoutHorizontal && s.LayoutDirection() == spec.RightToLeftDirection
 	for _, child := range children {
-		delegate.SetPosition(child, position)
+		if isMirrored {
+			delegate.SetPosition(child, delegate.Size(s)-delegate.PaddingLast(s)-(position-paddingFirst)-delegate.Size(child))
+		} else {
+			delegate.SetPosition(child, position)
+		}
 		position = position + delegate.Size(child) + gutter
 	}
 	return position - gutter - paddingFirst
@@ -7,6 +7,7 @@ import (
 	"github.com/waybeams/waybeams/pkg/ctrl"
 	surface "github.com/waybeams/waybeams/pkg/env/fake"
 	"github.com/waybeams/waybeams/pkg/fakes"
+	"github.com/waybeams/waybeams/pkg/i18n"
 	"github.com/waybeams/waybeams/pkg/layout"
 	"github.com/waybeams/waybeams/pkg/opts"
 	"github.com/waybeams/waybeams/pkg/spec"
@@ -37,6 +38,20 @@ func TestLayout(t *testing.T) {
 		assert.Equal(root.ChildCount(), 3)
 	})
 
+	t.Run("Collapsed children take no space", func(t *testing.T) {
+		root := layout.Layout(fakes.Fake(
+			opts.Width(150),
+			opts.LayoutType(spec.HorizontalFlowLayoutType),
+			opts.Child(fakes.Fake(opts.FlexWidth(1))),
+			opts.Child(fakes.Fake(opts.FlexWidth(1), opts.Collapsed(true))),
+			opts.Child(fakes.Fake(opts.Width(50))),
+		), fakeSurface())
+
+		assert.Equal(root.ChildAt(0).Width(), 100)
+		assert.Equal(root.ChildAt(1).Width(), 0)
+		assert.Equal(root.ChildAt(2).X(), 100)
+	})
+
 	t.Run("Spread remainder", func(t *testing.T) {
 		root := layout.Layout(fakes.Fake(
 			opts.Width(152),
@@ -427,4 +442,200 @@ func TestLayout(t *testing.T) {
 		assert.Equal(child.Width(), 182)
 		assert.Equal(child.Height(), 34)
 	})
+
+	t.Run("Right to left", func(t *testing.T) {
+		createRow := func(options ...spec.Option) *spec.Spec {
+			options = append(options,
+				opts.PaddingLeft(5),
+				opts.PaddingRight(15),
+				opts.Width(300),
+				opts.Child(ctrl.Box(opts.Key("one"), opts.Width(100), opts.Height(20))),
+				opts.Child(ctrl.Box(opts.Key("two"), opts.FlexWidth(1), opts.Height(20))),
+			)
+			return ctrl.HBox(options...)
+		}
+
+		t.Run("Mirrors horizontal flows", func(t *testing.T) {
+			root := createRow(opts.LayoutDirection(spec.RightToLeftDirection))
+			layout.Layout(root, fakeSurface())
+			one := spec.FirstByKey(root, "one")
+			two := spec.FirstByKey(root, "two")
+			assert.Equal(one.X(), 185)
+			assert.Equal(two.Width(), 180)
+			assert.Equal(two.X(), 5)
+			assert.Equal(root.ChildrenWidth(), 280)
+		})
+
+		t.Run("Follows the locale", func(t *testing.T) {
+			defer i18n.SetLocale(i18n.Locale())
+			i18n.SetLocale("he")
+			root := createRow()
+			layout.Layout(root, fakeSurface())
+			assert.Equal(spec.FirstByKey(root, "one").X(), 185)
+
+			root = createRow(opts.LayoutDirection(spec.LeftToRightDirection))
+			layout.Layout(root, fakeSurface())
+			assert.Equal(spec.FirstByKey(root, "one").X(), 5)
+		})
+
+		t.Run("Does not mirror vertical flows", func(t *testing.T) {
+			root := ctrl.VBox(
+				opts.LayoutDirection(spec.RightToLeftDirection),
+				opts.Padding(5),
+				opts.Child(ctrl.Box(opts.Key("one"), opts.Width(100), opts.Height(20))),
+				opts.Child(ctrl.Box(opts.Key("two"), opts.Width(100), opts.Height(20))),
+			)
+			layout.Layout(root, fakeSurface())
+			assert.Equal(spec.FirstByKey(root, "one").Y(), 5)
+			assert.Equal(spec.FirstByKey(root, "two").Y(), 25)
+		})
+	})
+	t.Run("Overlays", func(t *testing.T) {
+		createTree := func(anchorY float64) *spec.Spec {
+			return ctrl.Box(
+				opts.Width(200),
+				opts.Height(200),
+				opts.Child(ctrl.Box(
+					opts.Key("anchor"),
+					opts.ExcludeFromLayout(true),
+					opts.X(150),
+					opts.Y(anchorY),
+					opts.Width(40),
+					opts.Height(20),
+					opts.Child(ctrl.VBox(
+						opts.Key("overlay"),
+						opts.IsOverlay(true),
+						opts.Child(ctrl.Box(opts.Width(30), opts.Height(50))),
+					)),
+				)),
+			)
+		}
+
+		t.Run("Are not in the layout of their parent", func(t *testing.T) {
+			root := ctrl.VBox(
+				opts.Child(ctrl.Box(opts.Key("one"), opts.Width(100), opts.Height(20),
+					opts.Child(ctrl.Box(opts.IsOverlay(true), opts.Width(300), opts.Height(300))),
+				)),
+				opts.Child(ctrl.Box(opts.Key("two"), opts.Width(100), opts.Height(20))),
+			)
+			layout.Layout(root, fakeSurface())
+			assert.Equal(spec.FirstByKey(root, "one").Width(), 100)
+			assert.Equal(spec.FirstByKey(root, "two").Y(), 20)
+			assert.Equal(root.Height(), 40)
+		})
+
+		t.Run("Are placed below their parent and at least as wide", func(t *testing.T) {
+			root := createTree(10)
+			layout.Layout(root, fakeSurface())
+			overlay := spec.FirstByKey(root, "overlay")
+			assert.Equal(overlay.Width(), 40)
+			assert.Equal(overlay.Height(), 50)
+			assert.Equal(overlay.X(), 0)
+			assert.Equal(overlay.Y(), 20)
+		})
+
+		t.Run("Move above their parent near the bottom", func(t *testing.T) {
+			root := createTree(170)
+			layout.Layout(root, fakeSurface())
+			assert.Equal(spec.FirstByKey(root, "overlay").Y(), -50)
+		})
+
+		t.Run("Move left near the right edge", func(t *testing.T) {
+			root := createTree(10)
+			spec.FirstByKey(root, "overlay").ChildAt(0).SetWidth(80)
+			layout.Layout(root, fakeSurface())
+			overlay := spec.FirstByKey(root, "overlay")
+			assert.Equal(overlay.Width(), 80)
+			assert.Equal(overlay.X(), -30)
+		})
+
+		t.Run("Are skipped while invisible", func(t *testing.T) {
+			root := createTree(10)
+			overlay := spec.FirstByKey(root, "overlay")
+			overlay.SetVisible(false)
+			layout.Layout(root, fakeSurface())
+			assert.Equal(overlay.Height(), 0)
+		})
+
+		t.Run("Placement", func(t *testing.T) {
+			createPlaced := func(placement spec.PlacementValue, anchorX, anchorY float64) spec.ReadWriter {
+				return ctrl.Box(
+					opts.Width(200),
+					opts.Height(200),
+					opts.Child(ctrl.Box(
+						opts.Key("anchor"),
+						opts.ExcludeFromLayout(true),
+						opts.X(anchorX),
+						opts.Y(anchorY),
+						opts.Width(40),
+						opts.Height(20),
+					)),
+					opts.Child(ctrl.Box(
+						opts.Key("overlay"),
+						opts.IsOverlay(true),
+						opts.OverlayAnchor("anchor"),
+						opts.Placement(placement),
+						opts.Width(30),
+						opts.Height(50),
+					)),
+				)
+			}
+			position := func(root spec.ReadWriter) []float64 {
+				layout.Layout(root, fakeSurface())
+				overlay := spec.FirstByKey(root, "overlay")
+				return []float64{overlay.X(), overlay.Y(), overlay.Width()}
+			}
+
+			t.Run("Is relative to the anchor, not the parent", func(t *testing.T) {
+				assert.Equal(position(createPlaced(spec.PlaceBelow, 100, 10)), []float64{100, 30, 30})
+			})
+
+			t.Run("Above", func(t *testing.T) {
+				assert.Equal(position(createPlaced(spec.PlaceAbove, 100, 100)), []float64{100, 50, 30})
+			})
+
+			t.Run("Above flips below near the top", func(t *testing.T) {
+				assert.Equal(position(createPlaced(spec.PlaceAbove, 100, 10)), []float64{100, 30, 30})
+			})
+
+			t.Run("Left", func(t *testing.T) {
+				assert.Equal(position(createPlaced(spec.PlaceLeft, 100, 10)), []float64{70, 10, 30})
+			})
+
+			t.Run("Left flips right near the left edge", func(t *testing.T) {
+				assert.Equal(position(createPlaced(spec.PlaceLeft, 10, 10)), []float64{50, 10, 30})
+			})
+
+			t.Run("Right", func(t *testing.T) {
+				assert.Equal(position(createPlaced(spec.PlaceRight, 100, 10)), []float64{140, 10, 30})
+			})
+
+			t.Run("Right flips left near the right edge", func(t *testing.T) {
+				assert.Equal(position(createPlaced(spec.PlaceRight, 150, 10)), []float64{120, 10, 30})
+			})
+
+			t.Run("Remains within the root", func(t *testing.T) {
+				assert.Equal(position(createPlaced(spec.PlaceRight, 100, 180)), []float64{140, 150, 30})
+			})
+
+			t.Run("Center ignores the anchor", func(t *testing.T) {
+				assert.Equal(position(createPlaced(spec.PlaceCenter, 10, 10)), []float64{85, 75, 30})
+			})
+		})
+
+		t.Run("Stack by ZIndex", func(t *testing.T) {
+			root := ctrl.Box(
+				opts.Child(ctrl.Box(opts.Key("raised"), opts.IsOverlay(true), opts.ZIndex(1))),
+				opts.Child(ctrl.Box(opts.Key("first"), opts.IsOverlay(true),
+					opts.Child(ctrl.Box(opts.Key("nested"), opts.IsOverlay(true))),
+				)),
+				opts.Child(ctrl.Box(opts.Key("second"), opts.IsOverlay(true))),
+			)
+			var keys []string
+			for _, overlay := range spec.Overlays(root) {
+				keys = append(keys, overlay.Key())
+			}
+			assert.Equal(keys, []string{"first", "nested", "second", "raised"})
+		})
+	})
 }
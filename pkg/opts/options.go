@@ -2,7 +2,10 @@ package opts
 
 import (
 	"github.com/waybeams/waybeams/pkg/events"
+	"github.com/waybeams/waybeams/pkg/font"
+	"github.com/waybeams/waybeams/pkg/i18n"
 	. "github.com/waybeams/waybeams/pkg/spec"
+	"github.com/waybeams/waybeams/pkg/text"
 )
 
 func BgColor(color uint) Option {
@@ -11,6 +14,124 @@ func BgColor(color uint) Option {
 	}
 }
 
+// Border configures the same border width and color on every side.
+func Border(width float64, color uint) Option {
+	return func(r ReadWriter) {
+		border := BorderSide{Width: width, Color: color}
+		r.SetBorders(Borders{Top: border, Right: border, Bottom: border, Left: border})
+	}
+}
+
+// BorderTop configures the top border width and color.
+func BorderTop(width float64, color uint) Option {
+	return func(r ReadWriter) {
+		borders := r.Borders()
+		borders.Top = BorderSide{Width: width, Color: color}
+		r.SetBorders(borders)
+	}
+}
+
+// BorderRight configures the right border width and color.
+func BorderRight(width float64, color uint) Option {
+	return func(r ReadWriter) {
+		borders := r.Borders()
+		borders.Right = BorderSide{Width: width, Color: color}
+		r.SetBorders(borders)
+	}
+}
+
+// BorderBottom configures the bottom border width and color.
+func BorderBottom(width float64, color uint) Option {
+	return func(r ReadWriter) {
+		borders := r.Borders()
+		borders.Bottom = BorderSide{Width: width, Color: color}
+		r.SetBorders(borders)
+	}
+}
+
+// BorderLeft configures the left border width and color.
+func BorderLeft(width float64, color uint) Option {
+	return func(r ReadWriter) {
+		borders := r.Borders()
+		borders.Left = BorderSide{Width: width, Color: color}
+		r.SetBorders(borders)
+	}
+}
+
+// CornerRadius configures the same radius on every corner.
+func CornerRadius(radius float64) Option {
+	return CornerRadii(radius, radius, radius, radius)
+}
+
+// CornerRadii configures the radius of each corner, clockwise from the top
+// left.
+func CornerRadii(topLeft, topRight, bottomRight, bottomLeft float64) Option {
+	return func(r ReadWriter) {
+		r.SetCornerRadii(Corners{
+			TopLeft:     topLeft,
+			TopRight:    topRight,
+			BottomRight: bottomRight,
+			BottomLeft:  bottomLeft,
+		})
+	}
+}
+
+// LinearGradient fills the Spec with a gradient from the start point to the
+// end point. Points are fractions (0.0 - 1.0) of the Spec width and height,
+// so LinearGradient(0, 0, 0, 1, a, b) is a vertical gradient.
+func LinearGradient(startX, startY, endX, endY float64, startColor, endColor uint) Option {
+	return func(r ReadWriter) {
+		r.SetGradient(&Gradient{
+			EndColor:   endColor,
+			EndX:       endX,
+			EndY:       endY,
+			StartColor: startColor,
+			StartX:     startX,
+			StartY:     startY,
+			Type:       LinearGradientType,
+		})
+	}
+}
+
+// RadialGradient fills the Spec with a gradient that radiates from the
+// center point, which is a fraction (0.0 - 1.0) of the Spec width and
+// height. Radii are in pixels.
+func RadialGradient(centerX, centerY, innerRadius, outerRadius float64, innerColor, outerColor uint) Option {
+	return func(r ReadWriter) {
+		r.SetGradient(&Gradient{
+			EndColor:    outerColor,
+			InnerRadius: innerRadius,
+			OuterRadius: outerRadius,
+			StartColor:  innerColor,
+			StartX:      centerX,
+			StartY:      centerY,
+			Type:        RadialGradientType,
+		})
+	}
+}
+
+// Shadow adds a drop shadow beneath the Spec.
+func Shadow(offsetX, offsetY, blur, spread float64, color uint) Option {
+	return func(r ReadWriter) {
+		r.AddShadow(BoxShadow{OffsetX: offsetX, OffsetY: offsetY, Blur: blur, Spread: spread, Color: color})
+	}
+}
+
+// InnerShadow adds a shadow that is drawn inside the Spec bounds.
+func InnerShadow(offsetX, offsetY, blur, spread float64, color uint) Option {
+	return func(r ReadWriter) {
+		r.AddShadow(BoxShadow{OffsetX: offsetX, OffsetY: offsetY, Blur: blur, Spread: spread, Color: color, Inset: true})
+	}
+}
+
+// Collapsed will configure Spec.Collapsed, which removes the Spec from
+// layout, drawing and hit-testing.
+func Collapsed(value bool) Option {
+	return func(r ReadWriter) {
+		r.SetCollapsed(value)
+	}
+}
+
 // ExcludeFromLayout will configure Spec.ExcludeFromLayout.
 func ExcludeFromLayout(value bool) Option {
 	return func(r ReadWriter) {
@@ -50,6 +171,22 @@ func FontSize(size float64) Option {
 	}
 }
 
+// FontStyle will configure the font.Style (e.g., font.Italic) that is used
+// along with FontFace and FontWeight to find a registered font face.
+func FontStyle(style font.Style) Option {
+	return func(r ReadWriter) {
+		r.SetFontStyle(style)
+	}
+}
+
+// FontWeight will configure the font weight (e.g., font.Light or 300) that
+// is used along with FontFace and FontStyle to find a registered font face.
+func FontWeight(weight int) Option {
+	return func(r ReadWriter) {
+		r.SetFontWeight(weight)
+	}
+}
+
 func Gutter(value float64) Option {
 	return func(r ReadWriter) {
 		r.SetGutter(value)
@@ -76,6 +213,22 @@ func IsMeasured(measured bool) Option {
 	}
 }
 
+// IsModal will configure Spec.IsModal, so that an overlay (e.g., a dialog)
+// blocks input to everything beneath it while it is visible.
+func IsModal(isModal bool) Option {
+	return func(r ReadWriter) {
+		r.SetIsModal(isModal)
+	}
+}
+
+// IsOverlay will configure Spec.IsOverlay, so that the Spec is drawn above
+// all other content (e.g., a popup list or a menu).
+func IsOverlay(isOverlay bool) Option {
+	return func(r ReadWriter) {
+		r.SetIsOverlay(isOverlay)
+	}
+}
+
 func IsText(value bool) Option {
 	return func(r ReadWriter) {
 		r.SetIsText(value)
@@ -108,6 +261,14 @@ func Key(value string) Option {
 	}
 }
 
+// LayoutDirection will set Spec.LayoutDirection, which overrides the
+// direction of the active locale for horizontal flow layouts.
+func LayoutDirection(direction LayoutDirectionValue) Option {
+	return func(r ReadWriter) {
+		r.SetLayoutDirection(direction)
+	}
+}
+
 // LayoutType will set Spec.LayoutType.
 func LayoutType(layoutType LayoutTypeValue) Option {
 	return func(r ReadWriter) {
@@ -143,6 +304,14 @@ func MinWidth(value float64) Option {
 	}
 }
 
+// OverlayAnchor will place an overlay against the Spec with the provided
+// Key, rather than against its parent.
+func OverlayAnchor(key string) Option {
+	return func(r ReadWriter) {
+		r.SetOverlayAnchor(key)
+	}
+}
+
 // Padding will set Spec.Padding, which will effectively set padding for
 // all four sides as well (bottom, top, left, right, horizontal and vertical).
 func Padding(value float64) Option {
@@ -179,6 +348,14 @@ func PaddingTop(value float64) Option {
 	}
 }
 
+// Placement will set Spec.Placement, which selects the side of its anchor
+// that an overlay is placed on.
+func Placement(placement PlacementValue) Option {
+	return func(r ReadWriter) {
+		r.SetPlacement(placement)
+	}
+}
+
 // PrefHeight will set Spec.PrefHeight.
 func PrefHeight(value float64) Option {
 	return func(r ReadWriter) {
@@ -219,11 +396,20 @@ func StrokeSize(size float64) Option {
 	}
 }
 
+// T will set Spec.Text to the translation of key in the active locale, with
+// the provided arguments interpolated (see i18n.T).
+func T(key string, args ...interface{}) Option {
+	return func(r ReadWriter) {
+		r.SetText(i18n.T(key, args...))
+	}
+}
+
+// Text will set Spec.Text to the provided value, without control characters
+// other than newlines and tabs (see text.Sanitize), as values often come
+// from user input. Use T for text that should be translated.
 func Text(value string) Option {
 	return func(r ReadWriter) {
-		// TODO(lbayes): Sanitize text as user input values can be placed in here.
-		// TODO(lbayes): Localize text using Localization map.
-		r.SetText(value)
+		r.SetText(text.Sanitize(value))
 	}
 }
 
@@ -240,6 +426,16 @@ func View(view RenderHandler) Option {
 	}
 }
 
+// Opacity will configure Spec.Opacity, which is composed with the opacity
+// of each parent when drawn.
+func Opacity(opacity float64) Option {
+	return func(r ReadWriter) {
+		r.SetOpacity(opacity)
+	}
+}
+
+// Visible(false) will skip drawing and hit-testing the Spec and its
+// descendants, but it will continue to occupy space in layout.
 func Visible(visible bool) Option {
 	return func(r ReadWriter) {
 		r.SetVisible(visible)
@@ -267,6 +463,14 @@ func Y(pos float64) Option {
 	}
 }
 
+// ZIndex will set Spec.ZIndex, which stacks overlays with a higher value
+// above those with a lower one.
+func ZIndex(zIndex int) Option {
+	return func(r ReadWriter) {
+		r.SetZIndex(zIndex)
+	}
+}
+
 //-------------------------------------------
 // Special Adapters
 //-------------------------------------------
@@ -390,6 +594,16 @@ func OnState(name string, options ...Option) Option {
 	}
 }
 
+// Transitions interpolate animatable properties when the Spec moves between
+// states. See spec.Transition for how From, To and Property are matched.
+func Transitions(transitions ...Transition) Option {
+	return func(r ReadWriter) {
+		for _, transition := range transitions {
+			r.AddTransition(transition)
+		}
+	}
+}
+
 func SetState(name string) Option {
 	return func(r ReadWriter) {
 		r.SetState(name)
@@ -5,6 +5,7 @@ import (
 
 	"github.com/waybeams/assert"
 	"github.com/waybeams/waybeams/pkg/fakes"
+	"github.com/waybeams/waybeams/pkg/i18n"
 	"github.com/waybeams/waybeams/pkg/opts"
 	"github.com/waybeams/waybeams/pkg/spec"
 )
@@ -220,4 +221,25 @@ func TestOptions(t *testing.T) {
 		f := fakes.Fake(opts.Visible(false))
 		assert.False(f.Visible())
 	})
+
+	t.Run("T", func(t *testing.T) {
+		catalog := i18n.NewCatalog("de")
+		catalog.AddPlural("opts.items", map[string]string{"one": "{0} Element", "other": "{0} Elemente"})
+		i18n.AddCatalog(catalog)
+		defer i18n.SetLocale(i18n.Locale())
+
+		f := fakes.Fake(opts.T("opts.items", 3))
+		assert.Equal(f.Text(), "opts.items")
+
+		i18n.SetLocale("de")
+		f = fakes.Fake(opts.T("opts.items", 3))
+		assert.Equal(f.Text(), "3 Elemente")
+	})
+
+	t.Run("LayoutDirection", func(t *testing.T) {
+		f := fakes.Fake()
+		assert.Equal(f.LayoutDirection(), spec.LeftToRightDirection)
+		f = fakes.Fake(opts.LayoutDirection(spec.RightToLeftDirection))
+		assert.Equal(f.LayoutDirection(), spec.RightToLeftDirection)
+	})
 }
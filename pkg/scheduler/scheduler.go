@@ -1,32 +1,197 @@
 package scheduler
 
 import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/waybeams/waybeams/pkg/anim"
 	"github.com/waybeams/waybeams/pkg/clock"
 	"github.com/waybeams/waybeams/pkg/events"
+	"github.com/waybeams/waybeams/pkg/i18n"
 	"github.com/waybeams/waybeams/pkg/layout"
 	"github.com/waybeams/waybeams/pkg/spec"
+	"github.com/waybeams/waybeams/pkg/store"
 )
 
-const shouldPollEvents = true
+// ErrStopped is returned by Listen after Stop has been called.
+var ErrStopped = errors.New("scheduler: stopped")
+
+// overlay wraps a factory that was provided to PushOverlay, so that it can
+// be found again when it is removed.
+type overlay struct {
+	factory spec.Factory
+}
 
 // Scheduler manages Specification lifecycle and rendering interactions with
 // the host environment.
 type Scheduler struct {
+	animator         *anim.Animator
 	clock            clock.Clock
+	closed           chan struct{}
+	err              error
 	factory          spec.Factory
+	frame            int
 	isClosed         bool
+	isInitialized    bool
+	isStopped        bool
+	isTransitioning  bool
+	isWatching       bool
+	lastFrameTime    time.Time
 	lastWindowHeight float64
 	lastWindowWidth  float64
+	layoutRequested  bool
+	mutex            sync.Mutex
+	overlays         []*overlay
+	posted           []func()
+	renderRequested  bool
 	root             spec.ReadWriter
 	shouldRender     bool
 	shouldLayout     bool
 	shouldDraw       bool
 	surface          spec.Surface
+	unwatchers       []events.Unsubscriber
 	window           spec.Window
 }
 
+// specInvalidatedHandler is called after gestures and explicit calls to
+// Invalidate. Once models are being watched, only a model change will run
+// the factory, so invalidation falls back to layout and draw of the current
+// tree.
 func (s *Scheduler) specInvalidatedHandler(e events.Event) {
-	s.shouldRender = true
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.isWatching {
+		s.layoutRequested = true
+	} else {
+		s.renderRequested = true
+	}
+}
+
+func (s *Scheduler) modelChangedHandler(e events.Event) {
+	s.RequestRender()
+}
+
+// Watch subscribes to events.Changed on each of the provided models. Any
+// change, whether it comes from input handlers, a timer or a function that
+// was provided to Post, will schedule a render on the next frame. Once Watch
+// has been called, frames where no watched model changed skip the factory
+// and only layout and draw the existing tree.
+func (s *Scheduler) Watch(models ...store.Observable) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.isWatching = true
+	for _, model := range models {
+		s.unwatchers = append(s.unwatchers, model.On(events.Changed, s.modelChangedHandler))
+	}
+}
+
+// Post adds the provided function to a queue that is drained on the UI
+// thread at the beginning of the next frame. Post is safe to call from any
+// goroutine and is the expected way for background work to mutate models
+// that are read by the Spec factory.
+func (s *Scheduler) Post(fn func()) {
+	s.mutex.Lock()
+	s.posted = append(s.posted, fn)
+	s.mutex.Unlock()
+}
+
+// RunOnUIThread posts the provided function and blocks until it has been
+// executed. It returns false if the Scheduler was closed before the
+// function could run. RunOnUIThread must not be called from the UI thread.
+func (s *Scheduler) RunOnUIThread(fn func()) bool {
+	done := make(chan struct{})
+	s.Post(func() {
+		fn()
+		close(done)
+	})
+	select {
+	case <-done:
+		return true
+	case <-s.closed:
+		return false
+	}
+}
+
+// PushOverlay adds the Spec returned by the provided factory to the top of
+// the overlay stack (e.g., a menu, a tooltip or a modal dialog). The factory
+// is called on every render, after the Scheduler factory, and its Spec is
+// added to the new root as an overlay, above the overlays that were pushed
+// before it. Use opts.OverlayAnchor and opts.Placement to place it against
+// another Spec, and opts.IsModal to block input to the layers beneath it.
+// The returned function removes the overlay from the stack. PushOverlay is
+// safe to call from any goroutine.
+func (s *Scheduler) PushOverlay(f spec.Factory) events.Unsubscriber {
+	entry := &overlay{factory: f}
+	s.mutex.Lock()
+	s.overlays = append(s.overlays, entry)
+	s.renderRequested = true
+	s.mutex.Unlock()
+
+	return func() bool {
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+		for index, candidate := range s.overlays {
+			if candidate == entry {
+				s.overlays = append(s.overlays[:index], s.overlays[index+1:]...)
+				s.renderRequested = true
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// PopOverlay removes the topmost overlay that was provided to PushOverlay,
+// and returns false if there was none. PopOverlay is safe to call from any
+// goroutine.
+func (s *Scheduler) PopOverlay() bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if len(s.overlays) == 0 {
+		return false
+	}
+	s.overlays = s.overlays[:len(s.overlays)-1]
+	s.renderRequested = true
+	return true
+}
+
+// RequestRender asks the Scheduler to create a new Spec tree on the next
+// frame. RequestRender is safe to call from any goroutine.
+func (s *Scheduler) RequestRender() {
+	s.mutex.Lock()
+	s.renderRequested = true
+	s.mutex.Unlock()
+}
+
+// drainPosted executes any functions that were provided to Post and picks
+// up render and layout requests that arrived since the last frame.
+func (s *Scheduler) drainPosted() {
+	s.mutex.Lock()
+	posted := s.posted
+	s.posted = nil
+	s.mutex.Unlock()
+
+	for _, fn := range posted {
+		fn()
+	}
+
+	s.mutex.Lock()
+	if s.renderRequested {
+		s.shouldRender = true
+		s.renderRequested = false
+	}
+	if s.layoutRequested {
+		s.shouldLayout = true
+		s.layoutRequested = false
+	}
+	s.mutex.Unlock()
+}
+
+func (s *Scheduler) shouldExit() bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.isClosed || s.isStopped
 }
 
 func (s *Scheduler) renderSpecs() {
@@ -36,46 +201,159 @@ func (s *Scheduler) renderSpecs() {
 		s.lastWindowHeight = h
 		s.lastWindowWidth = w
 
-		// Create a new Spec tree and store it.
+		// Create a new Spec tree, carry interaction state forward from the
+		// previous tree and store it.
+		previous := s.root
 		root = s.factory()
+		s.mutex.Lock()
+		overlays := append([]*overlay(nil), s.overlays...)
+		s.mutex.Unlock()
+		for _, entry := range overlays {
+			child := entry.factory()
+			child.SetIsOverlay(true)
+			child.SetParent(root)
+			root.SetChildren(append(root.Children(), child))
+		}
+		spec.Reconcile(previous, root)
 		s.root = root
 		root.On(events.Invalidated, s.specInvalidatedHandler)
+		emitMountEvents(previous, root)
 	}
 }
 
+// emitMountEvents emits Unmounted on each Spec whose Path is no longer in
+// the tree and then Mounted on each Spec at a Path that was added to it. A
+// Spec that replaces another at the same Path, as happens on every render,
+// remains mounted.
+func emitMountEvents(previous, next spec.ReadWriter) {
+	nextPaths := pathSet(next)
+	previousPaths := pathSet(previous)
+	if previous != nil {
+		spec.Walk(previous, func(node spec.ReadWriter) {
+			if !nextPaths[spec.Path(node)] {
+				node.Emit(events.New(events.Unmounted, node, nil))
+			}
+		})
+	}
+	if next != nil {
+		spec.Walk(next, func(node spec.ReadWriter) {
+			if !previousPaths[spec.Path(node)] {
+				node.Emit(events.New(events.Mounted, node, nil))
+			}
+		})
+	}
+}
+
+// pathSet returns the Path of each Spec in the provided tree.
+func pathSet(root spec.ReadWriter) map[string]bool {
+	result := map[string]bool{}
+	if root != nil {
+		spec.Walk(root, func(node spec.ReadWriter) {
+			result[spec.Path(node)] = true
+		})
+	}
+	return result
+}
+
+// enterFrame emits FrameEntered on every Spec in the current tree.
+func (s *Scheduler) enterFrame(delta time.Duration) {
+	payload := events.FramePayload{Delta: delta, Frame: s.frame}
+	spec.Walk(s.root, func(node spec.ReadWriter) {
+		node.Emit(events.New(events.FrameEntered, node, payload))
+	})
+}
+
 func (s *Scheduler) layoutSpecs() {
 	if s.shouldRender || s.shouldLayout {
 		s.root.SetWidth(s.window.Width())
 		s.root.SetHeight(s.window.Height())
 
+		startTime := s.clock.Now()
 		layout.Layout(s.root, s.surface)
+		s.root.Emit(events.New(events.LayoutCompleted, s.root, s.clock.Since(startTime)))
 	}
 }
 
 func (s *Scheduler) drawSpecs() {
 	if s.shouldRender || s.shouldLayout {
+		startTime := s.clock.Now()
 		layout.Draw(s.root, s.surface)
+		s.root.Emit(events.New(events.DrawCompleted, s.root, s.clock.Since(startTime)))
 	}
 }
 
-func (s *Scheduler) Listen() {
+// Init prepares the Window and Surface for rendering. It is called by
+// Listen and lazily by Step, but hosts that own their own loop may call it
+// explicitly before the first frame.
+func (s *Scheduler) Init() {
+	if s.isInitialized {
+		return
+	}
+	s.isInitialized = true
 	s.window.Init()
 	s.window.OnResize(s.windowResizedHandler)
 
+	// Text options resolve against the active locale when the factory runs,
+	// so a locale change needs a new Spec tree.
+	s.mutex.Lock()
+	s.unwatchers = append(s.unwatchers, i18n.DefaultLocalizer.On(events.Changed, s.modelChangedHandler))
+	s.mutex.Unlock()
+
 	s.surface.Init()
+}
+
+// Listen blocks and executes frames at the Window frame rate until the
+// Window asks to close or Stop is called. When Stop ends the loop, Listen
+// returns ErrStopped.
+func (s *Scheduler) Listen() error {
+	s.Init()
 
 	defer s.Close()
 
-	s.clock.OnFrame(func() bool {
-		return s.frameHandler(shouldPollEvents)
-	}, s.window.FrameRate())
+	s.clock.OnFrame(s.Step, s.window.FrameRate())
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.err
 }
 
 func (s *Scheduler) windowResizedHandler(e events.Event) {
 	s.shouldLayout = true
 }
 
-func (s *Scheduler) frameHandler(pollEvents bool) bool {
+// Step executes a single frame and returns true if the Scheduler should
+// exit. This method allows tests and host environments that own their own
+// loop (e.g., a game engine or a mobile runtime) to drive frames explicitly.
+func (s *Scheduler) Step() bool {
+	if s.shouldExit() {
+		return true
+	}
+
+	s.Init()
+	s.drainPosted()
+
+	now := s.clock.Now()
+	var delta time.Duration
+	if s.frame > 0 {
+		delta = now.Sub(s.lastFrameTime)
+	}
+	s.lastFrameTime = now
+	s.frame++
+
+	// FrameEntered is emitted before any rendering so that handlers may
+	// mutate the tree for this frame. The very first frame has no tree yet,
+	// so it is emitted once that tree has been rendered.
+	isFrameEntered := s.root != nil
+	if isFrameEntered {
+		s.enterFrame(delta)
+	}
+
+	// Running animations and transitions need a fresh layout and draw on
+	// every frame.
+	if s.animator.IsActive() || s.isTransitioning {
+		s.shouldLayout = true
+	}
+
 	if s.shouldRender || s.shouldLayout {
 		// BeginFrame on the Window.
 		s.window.BeginFrame()
@@ -87,7 +365,11 @@ func (s *Scheduler) frameHandler(pollEvents bool) bool {
 
 		// Render the Specs.
 		s.renderSpecs()
+		s.isTransitioning = spec.StepTransitions(s.root, delta)
 		s.layoutSpecs()
+		// Animations are stepped after layout so that they may override
+		// positions and sizes that layout just assigned.
+		s.animator.Update()
 		s.drawSpecs()
 
 		// EndFrame on Surface and then Window.
@@ -99,19 +381,71 @@ func (s *Scheduler) frameHandler(pollEvents bool) bool {
 		s.shouldLayout = false
 	}
 
+	if !isFrameEntered && s.root != nil {
+		s.enterFrame(delta)
+	}
+
 	s.window.UpdateInput(s.root)
+	s.window.PollEvents()
 
-	// Return true if we should exita.
-	if pollEvents {
-		s.window.PollEvents()
+	return s.shouldExit() || s.window.ShouldClose()
+}
+
+// RunFrames executes up to n frames and returns true if the Scheduler asked
+// to exit before all of them were run.
+func (s *Scheduler) RunFrames(n int) bool {
+	for i := 0; i < n; i++ {
+		if s.Step() {
+			return true
+		}
 	}
-	return s.isClosed || s.window.ShouldClose()
+	return false
 }
 
+// Stop asks the Scheduler to exit at the end of the current frame. Listen
+// will then Close the Window and Surface and return ErrStopped. Stop is safe
+// to call from any goroutine.
+func (s *Scheduler) Stop() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if !s.isStopped {
+		s.isStopped = true
+		s.err = ErrStopped
+	}
+}
+
+// Close releases the Surface and Window. Calling Close more than once has
+// no effect.
 func (s *Scheduler) Close() {
+	s.mutex.Lock()
+	if s.isClosed {
+		s.mutex.Unlock()
+		return
+	}
+	s.isClosed = true
+	close(s.closed)
+	unwatchers := s.unwatchers
+	s.unwatchers = nil
+	s.mutex.Unlock()
+
+	emitMountEvents(s.root, nil)
+
+	for _, unwatch := range unwatchers {
+		unwatch()
+	}
+
 	s.surface.Close()
 	s.Window().Close()
-	s.isClosed = true
+}
+
+// Animator returns the Animator that is stepped on every frame against the
+// Scheduler clock.
+func (s *Scheduler) Animator() *anim.Animator {
+	return s.animator
+}
+
+func (s *Scheduler) Clock() clock.Clock {
+	return s.clock
 }
 
 func (s *Scheduler) Root() spec.ReadWriter {
@@ -124,6 +458,8 @@ func (s *Scheduler) Window() spec.Window {
 
 func New(w spec.Window, s spec.Surface, f spec.Factory, c clock.Clock) *Scheduler {
 	return &Scheduler{
+		animator:     anim.New(c),
+		closed:       make(chan struct{}),
 		shouldRender: true,
 		shouldLayout: true,
 		window:       w,
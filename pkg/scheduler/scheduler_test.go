@@ -1,13 +1,21 @@
 package scheduler_test
 
 import (
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/waybeams/waybeams/pkg/anim"
 	"github.com/waybeams/waybeams/pkg/clock"
 
 	"github.com/waybeams/waybeams/pkg/ctrl"
+	"github.com/waybeams/waybeams/pkg/events"
+	"github.com/waybeams/waybeams/pkg/i18n"
+	"github.com/waybeams/waybeams/pkg/input"
+	"github.com/waybeams/waybeams/pkg/opts"
 	"github.com/waybeams/waybeams/pkg/spec"
+	"github.com/waybeams/waybeams/pkg/store"
 
 	"github.com/waybeams/assert"
 	"github.com/waybeams/waybeams/pkg/env/fake"
@@ -36,4 +44,384 @@ func TestScheduler(t *testing.T) {
 		fakeClock.Add(100 * time.Millisecond)
 		assert.True(factoryCalled)
 	})
+	t.Run("Instantiable as spec.Scheduler", func(t *testing.T) {
+		var instance spec.Scheduler
+		instance = scheduler.New(fake.NewWindow(), fake.NewSurface(), func() spec.ReadWriter {
+			return ctrl.VBox()
+		}, clock.NewFake())
+		assert.NotNil(instance)
+	})
+
+	t.Run("Step renders without Listen", func(t *testing.T) {
+		factoryCalls := 0
+		b := scheduler.New(fake.NewWindow(), fake.NewSurface(), func() spec.ReadWriter {
+			factoryCalls++
+			return ctrl.VBox()
+		}, clock.NewFake())
+		defer b.Close()
+
+		assert.Nil(b.Root())
+		shouldExit := b.Step()
+		assert.False(shouldExit)
+		assert.Equal(factoryCalls, 1)
+		assert.NotNil(b.Root())
+
+		// Subsequent frames do not render unless something was invalidated.
+		b.RunFrames(3)
+		assert.Equal(factoryCalls, 1)
+
+		b.Root().Invalidate()
+		b.Step()
+		assert.Equal(factoryCalls, 2)
+	})
+
+	t.Run("Stop ends Listen with ErrStopped", func(t *testing.T) {
+		var b *scheduler.Scheduler
+		renderCount := 0
+		b = scheduler.New(fake.NewWindow(), fake.NewSurface(), func() spec.ReadWriter {
+			renderCount++
+			b.Stop()
+			return ctrl.VBox()
+		}, clock.NewFake())
+
+		err := b.Listen()
+		assert.Equal(err, scheduler.ErrStopped)
+		assert.Equal(renderCount, 1)
+		assert.True(b.Step(), "Step reports exit after Stop")
+	})
+
+	t.Run("RunFrames returns early after Stop", func(t *testing.T) {
+		b := scheduler.New(fake.NewWindow(), fake.NewSurface(), func() spec.ReadWriter {
+			return ctrl.VBox()
+		}, clock.NewFake())
+		defer b.Close()
+
+		assert.False(b.RunFrames(2))
+		b.Stop()
+		assert.True(b.RunFrames(2))
+	})
+
+	t.Run("Post runs on next Step", func(t *testing.T) {
+		factoryCalls := 0
+		b := scheduler.New(fake.NewWindow(), fake.NewSurface(), func() spec.ReadWriter {
+			factoryCalls++
+			return ctrl.VBox()
+		}, clock.NewFake())
+		defer b.Close()
+		b.Step()
+
+		wg := sync.WaitGroup{}
+		var count int64
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				b.Post(func() { atomic.AddInt64(&count, 1) })
+				b.RequestRender()
+			}()
+		}
+		wg.Wait()
+		assert.Equal(atomic.LoadInt64(&count), int64(0), "Posted funcs wait for Step")
+
+		b.Step()
+		assert.Equal(atomic.LoadInt64(&count), int64(10))
+		assert.Equal(factoryCalls, 2, "Many requests coalesce into one render")
+	})
+
+	t.Run("RunOnUIThread blocks until Step", func(t *testing.T) {
+		b := scheduler.New(fake.NewWindow(), fake.NewSurface(), func() spec.ReadWriter {
+			return ctrl.VBox()
+		}, clock.NewFake())
+		defer b.Close()
+
+		value := ""
+		result := make(chan bool)
+		go func() {
+			result <- b.RunOnUIThread(func() { value = "abcd" })
+		}()
+
+		for {
+			b.Step()
+			select {
+			case ok := <-result:
+				assert.True(ok)
+				assert.Equal(value, "abcd")
+				return
+			default:
+				time.Sleep(time.Millisecond)
+			}
+		}
+	})
+
+	t.Run("RunOnUIThread returns false after Close", func(t *testing.T) {
+		b := scheduler.New(fake.NewWindow(), fake.NewSurface(), func() spec.ReadWriter {
+			return ctrl.VBox()
+		}, clock.NewFake())
+		b.Close()
+		assert.False(b.RunOnUIThread(func() {}))
+	})
+
+	t.Run("Watch renders only when models change", func(t *testing.T) {
+		factoryCalls := 0
+		model := store.NewValue("abcd")
+		b := scheduler.New(fake.NewWindow(), fake.NewSurface(), func() spec.ReadWriter {
+			factoryCalls++
+			return ctrl.Label(opts.Text(model.String()))
+		}, clock.NewFake())
+		defer b.Close()
+		b.Watch(model)
+		b.Step()
+		assert.Equal(factoryCalls, 1)
+
+		// Gestures and explicit invalidation only layout and draw.
+		b.Root().Invalidate()
+		b.Step()
+		assert.Equal(factoryCalls, 1)
+
+		go b.Post(func() { model.Set("efgh") })
+		for factoryCalls < 2 {
+			b.Step()
+		}
+		assert.Equal(b.Root().Text(), "efgh")
+
+		b.RunFrames(2)
+		assert.Equal(factoryCalls, 2)
+	})
+
+	t.Run("Locale changes render", func(t *testing.T) {
+		catalog := i18n.NewCatalog("fr")
+		catalog.Add("scheduler.greeting", "Bonjour")
+		i18n.AddCatalog(catalog)
+		defer i18n.SetLocale(i18n.Locale())
+
+		factoryCalls := 0
+		model := store.NewValue("abcd")
+		b := scheduler.New(fake.NewWindow(), fake.NewSurface(), func() spec.ReadWriter {
+			factoryCalls++
+			return ctrl.Label(opts.T("scheduler.greeting"))
+		}, clock.NewFake())
+		defer b.Close()
+		b.Watch(model)
+		b.Step()
+		assert.Equal(b.Root().Text(), "scheduler.greeting")
+
+		i18n.SetLocale("fr")
+		b.Step()
+		assert.Equal(factoryCalls, 2)
+		assert.Equal(b.Root().Text(), "Bonjour")
+	})
+
+	t.Run("Animations draw on every frame", func(t *testing.T) {
+		fakeClock := clock.NewFake()
+		fakeSurface := fake.NewSurface()
+		factoryCalls := 0
+		b := scheduler.New(fake.NewWindow(), fakeSurface, func() spec.ReadWriter {
+			factoryCalls++
+			return ctrl.VBox(opts.Child(ctrl.Box(opts.Key("abcd"), opts.Width(10), opts.Height(10))))
+		}, fakeClock)
+		defer b.Close()
+		b.Step()
+
+		box := spec.FirstByKey(b.Root(), "abcd")
+		b.Animator().Start(anim.X(box, 100, time.Second))
+		fakeClock.Add(500 * time.Millisecond)
+		b.Step()
+		assert.Equal(box.X(), 50.0, "Tween overrides layout")
+
+		fakeClock.Add(time.Second)
+		b.Step()
+		assert.Equal(box.X(), 100.0)
+		assert.False(b.Animator().IsActive())
+		assert.Equal(factoryCalls, 1, "Animation does not run the factory")
+	})
+
+	t.Run("State transitions survive renders", func(t *testing.T) {
+		driver := fake.NewDriver(func() spec.ReadWriter {
+			return ctrl.VBox(opts.Child(ctrl.Button(
+				opts.Key("btn"),
+				opts.Width(100),
+				opts.Height(40),
+				opts.Transitions(spec.Transition{Duration: 100 * time.Millisecond}),
+			)))
+		})
+		defer driver.Close()
+		assert.Equal(driver.Find("btn").BgColor(), uint(0xce3262ff))
+
+		driver.Hover("btn")
+		btn := driver.Find("btn")
+		assert.Equal(btn.State(), "hovered")
+		assert.True(btn.IsTransitioning())
+
+		driver.AdvanceFrames(10)
+		btn = driver.Find("btn")
+		assert.False(btn.IsTransitioning())
+		assert.Equal(btn.BgColor(), uint(0x00acd7ff))
+	})
+
+	t.Run("Emits FrameEntered with frame number and delta", func(t *testing.T) {
+		fakeClock := clock.NewFake()
+		payloads := []events.FramePayload{}
+		b := scheduler.New(fake.NewWindow(), fake.NewSurface(), func() spec.ReadWriter {
+			return ctrl.VBox(opts.On(events.FrameEntered, func(e events.Event) {
+				payloads = append(payloads, e.Payload().(events.FramePayload))
+			}))
+		}, fakeClock)
+		defer b.Close()
+
+		b.Step()
+		fakeClock.Add(16 * time.Millisecond)
+		b.Step()
+		assert.Equal(len(payloads), 2)
+		assert.Equal(payloads[0].Frame, 1)
+		assert.Equal(payloads[0].Delta, time.Duration(0))
+		assert.Equal(payloads[1].Frame, 2)
+		assert.Equal(payloads[1].Delta, 16*time.Millisecond)
+	})
+
+	t.Run("Emits LayoutCompleted and DrawCompleted on root", func(t *testing.T) {
+		received := []string{}
+		record := func(e events.Event) {
+			_, ok := e.Payload().(time.Duration)
+			assert.True(ok, "Payload is elapsed time")
+			received = append(received, e.Name())
+		}
+		b := scheduler.New(fake.NewWindow(), fake.NewSurface(), func() spec.ReadWriter {
+			return ctrl.VBox(
+				opts.On(events.LayoutCompleted, record),
+				opts.On(events.DrawCompleted, record),
+			)
+		}, clock.NewFake())
+		defer b.Close()
+		b.Step()
+		assert.Equal(received, []string{events.LayoutCompleted, events.DrawCompleted})
+	})
+
+	t.Run("Emits Mounted and Unmounted", func(t *testing.T) {
+		mounted := 0
+		unmounted := 0
+		isShown := true
+		b := scheduler.New(fake.NewWindow(), fake.NewSurface(), func() spec.ReadWriter {
+			root := ctrl.VBox()
+			if isShown {
+				opts.Child(ctrl.Box(
+					opts.Key("panel"),
+					opts.On(events.Mounted, func(e events.Event) { mounted++ }),
+					opts.On(events.Unmounted, func(e events.Event) { unmounted++ }),
+				))(root)
+			}
+			return root
+		}, clock.NewFake())
+		b.Step()
+		assert.Equal(mounted, 1)
+		assert.Equal(unmounted, 0)
+
+		b.RequestRender()
+		b.Step()
+		assert.Equal(mounted, 1, "A new instance at the same Path stays mounted")
+		assert.Equal(unmounted, 0)
+
+		isShown = false
+		b.RequestRender()
+		b.Step()
+		assert.Equal(unmounted, 1, "Removed from the tree")
+
+		isShown = true
+		b.RequestRender()
+		b.Step()
+		assert.Equal(mounted, 2, "Added back to the tree")
+
+		b.Close()
+		assert.Equal(unmounted, 2)
+	})
+
+	t.Run("Drags a Slider across re-created trees", func(t *testing.T) {
+		window := fake.NewWindow()
+		window.SetWidth(200)
+		window.SetHeight(200)
+		value := 0.0
+		renders := 0
+		b := scheduler.New(window, fake.NewSurface(), func() spec.ReadWriter {
+			renders++
+			return ctrl.VBox(
+				opts.HAlign(spec.AlignLeft),
+				opts.VAlign(spec.AlignTop),
+				opts.Child(ctrl.Slider(
+					opts.Key("slider"),
+					opts.Width(100),
+					ctrl.Value(value),
+					opts.On(events.Changed, func(e events.Event) {
+						value = e.Payload().(float64)
+					}),
+				)),
+			)
+		}, clock.NewFake())
+		defer b.Close()
+		slider := func() *ctrl.SliderSpec {
+			return spec.FirstByKey(b.Root(), "slider").(*ctrl.SliderSpec)
+		}
+		b.Step()
+
+		gestures := window.Gestures()
+		x, y, size, _ := slider().ThumbRect()
+		gestures.SetCursorPos(x+size/2, y+size/2)
+		b.Step()
+		gestures.PressButton(input.MouseButton1)
+		assert.True(slider().IsDragging())
+
+		gestures.SetCursorPos(x+size/2+30, y+size/2)
+		b.Step()
+		first := value
+		assert.True(first > 0)
+
+		// The Changed value re-creates the tree while the button is down.
+		rendered := renders
+		b.Step()
+		assert.Equal(renders, rendered+1)
+		assert.True(slider().IsDragging(), "Drag is carried into the new tree")
+
+		// Beyond the bounds, only the captured Slider receives the cursor.
+		gestures.SetCursorPos(x+size/2+60, y+size*4)
+		b.Step()
+		assert.True(value > first)
+		assert.Equal(slider().Value(), value)
+
+		gestures.ReleaseButton(input.MouseButton1)
+		assert.False(slider().IsDragging())
+	})
+
+	t.Run("Pushes and pops overlays above the tree", func(t *testing.T) {
+		window := fake.NewWindow()
+		window.SetWidth(200)
+		window.SetHeight(200)
+		b := scheduler.New(window, fake.NewSurface(), func() spec.ReadWriter {
+			return ctrl.VBox(opts.HAlign(spec.AlignLeft), opts.Child(ctrl.Box(opts.Key("anchor"), opts.Width(50), opts.Height(20))))
+		}, clock.NewFake())
+		defer b.Close()
+		b.Step()
+
+		remove := b.PushOverlay(func() spec.ReadWriter {
+			return ctrl.Box(opts.Key("menu"), opts.IsFocusable(true), opts.OverlayAnchor("anchor"), opts.Placement(spec.PlaceRight), opts.Width(30), opts.Height(40))
+		})
+		b.PushOverlay(func() spec.ReadWriter {
+			return ctrl.Box(opts.Key("dialog"), opts.IsModal(true), opts.Placement(spec.PlaceCenter), opts.Width(100), opts.Height(100))
+		})
+		b.Step()
+		overlays := spec.Overlays(b.Root())
+		assert.Equal(len(overlays), 2)
+		assert.Equal(overlays[0].Key(), "menu")
+		assert.Equal(overlays[1].Key(), "dialog")
+		assert.Equal(overlays[0].X(), 50, "Placed right of its anchor")
+		assert.Equal(overlays[0].Y(), 0)
+		assert.Equal(spec.CoordToControl(b.Root(), 60, 10).Key(), "dialog", "Modal blocks the menu beneath it")
+
+		assert.True(b.PopOverlay())
+		b.Step()
+		assert.Equal(spec.CoordToControl(b.Root(), 60, 10).Key(), "menu")
+
+		assert.True(remove())
+		assert.False(remove())
+		assert.False(b.PopOverlay())
+		b.Step()
+		assert.Equal(len(spec.Overlays(b.Root())), 0)
+	})
 }
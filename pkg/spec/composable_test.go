@@ -135,6 +135,20 @@ func TestComposable(t *testing.T) {
 		})
 	})
 
+	t.Run("FirstByPath", func(t *testing.T) {
+		root := fakes.Fake(opts.Key("root"),
+			opts.Child(fakes.Fake(opts.Key("one"),
+				opts.Child(fakes.Fake()),
+				opts.Child(fakes.Fake()),
+			)),
+		)
+
+		second := spec.FirstByPath(root, "/root/one/FakeControl-1")
+		assert.Equal(second, root.ChildAt(0).ChildAt(1))
+		assert.Equal(spec.FirstByPath(root, "/root"), root)
+		assert.Nil(spec.FirstByPath(root, "/root/two"))
+	})
+
 	t.Run("Path", func(t *testing.T) {
 		t.Run("root", func(t *testing.T) {
 			root := fakes.Fake(opts.Key("root"))
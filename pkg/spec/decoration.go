@@ -0,0 +1,105 @@
+package spec
+
+// Corners holds a radius for each corner of a box.
+type Corners struct {
+	TopLeft     float64
+	TopRight    float64
+	BottomRight float64
+	BottomLeft  float64
+}
+
+// IsZero returns true if no corner is rounded.
+func (c Corners) IsZero() bool {
+	return c.TopLeft == 0 && c.TopRight == 0 && c.BottomRight == 0 && c.BottomLeft == 0
+}
+
+// BorderSide describes a single side of a box border.
+type BorderSide struct {
+	Color uint
+	Width float64
+}
+
+// Borders holds a BorderSide for each side of a box.
+type Borders struct {
+	Top    BorderSide
+	Right  BorderSide
+	Bottom BorderSide
+	Left   BorderSide
+}
+
+// IsZero returns true if no side has a visible border.
+func (b Borders) IsZero() bool {
+	return b.Top.Width == 0 && b.Right.Width == 0 && b.Bottom.Width == 0 && b.Left.Width == 0
+}
+
+// IsUniform returns true if every side has the same width and color.
+func (b Borders) IsUniform() bool {
+	return b.Top == b.Right && b.Top == b.Bottom && b.Top == b.Left
+}
+
+type GradientType int
+
+const (
+	LinearGradientType = GradientType(iota)
+	RadialGradientType
+)
+
+// Gradient describes a two color gradient fill. Positions (StartX, StartY,
+// EndX, EndY) are fractions (0.0 - 1.0) of the box width and height. For
+// RadialGradientType, Start is the center and the radii are in pixels.
+type Gradient struct {
+	EndColor    uint
+	EndX        float64
+	EndY        float64
+	InnerRadius float64
+	OuterRadius float64
+	StartColor  uint
+	StartX      float64
+	StartY      float64
+	Type        GradientType
+}
+
+// BoxShadow describes a blurred shadow that is drawn beneath (or, when Inset,
+// inside) a box.
+type BoxShadow struct {
+	Blur    float64
+	Color   uint
+	Inset   bool
+	OffsetX float64
+	OffsetY float64
+	Spread  float64
+}
+
+func (c *Spec) Borders() Borders {
+	return c.borders
+}
+
+func (c *Spec) CornerRadii() Corners {
+	return c.cornerRadii
+}
+
+// Gradient returns the configured gradient fill, or nil if the Spec should
+// be filled with BgColor.
+func (c *Spec) Gradient() *Gradient {
+	return c.gradient
+}
+
+func (c *Spec) Shadows() []BoxShadow {
+	return c.shadows
+}
+
+func (c *Spec) SetBorders(borders Borders) {
+	c.borders = borders
+}
+
+func (c *Spec) SetCornerRadii(corners Corners) {
+	c.cornerRadii = corners
+}
+
+func (c *Spec) SetGradient(gradient *Gradient) {
+	c.gradient = gradient
+}
+
+func (c *Spec) AddShadow(shadow BoxShadow) {
+	c.shadows = append(c.shadows, shadow)
+}
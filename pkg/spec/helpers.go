@@ -1,6 +1,11 @@
 package spec
 
-import "strconv"
+import (
+	"sort"
+	"strconv"
+
+	"github.com/waybeams/waybeams/pkg/events"
+)
 
 func applyOptionsForState(rw ReadWriter) ReadWriter {
 	options := rw.OptionsForState(rw.State())
@@ -15,7 +20,15 @@ func Apply(rw ReadWriter, options ...Option) ReadWriter {
 	for _, option := range options {
 		option(rw)
 	}
-	return applyOptionsForState(rw)
+	rw.Emit(events.New(events.Created, rw, nil))
+	applyOptionsForState(rw)
+	rw.Emit(events.New(events.Configured, rw, nil))
+	if provider, ok := rw.(specProvider); ok {
+		s := provider.baseSpec()
+		s.isConfigured = true
+		s.initialState = s.currentState
+	}
+	return rw
 }
 
 // ApplyAll will take arbitrary slices of Options and will apply each set
@@ -28,6 +41,15 @@ func ApplyAll(rw ReadWriter, optionSets ...[]Option) ReadWriter {
 	return Apply(rw, options...)
 }
 
+// Walk calls the provided function with the provided node and then each of
+// its descendants in depth-first order.
+func Walk(rw ReadWriter, fn func(node ReadWriter)) {
+	fn(rw)
+	for _, child := range rw.Children() {
+		Walk(child, fn)
+	}
+}
+
 func FirstChild(r Reader) ReadWriter {
 	return r.Children()[0]
 }
@@ -76,6 +98,20 @@ func FirstByKey(rw ReadWriter, key string) ReadWriter {
 	return nil
 }
 
+// FirstByPath returns the first node whose Path matches the provided value.
+func FirstByPath(rw ReadWriter, path string) ReadWriter {
+	if Path(rw) == path {
+		return rw
+	}
+	for _, child := range rw.Children() {
+		result := FirstByPath(child, path)
+		if result != nil {
+			return result
+		}
+	}
+	return nil
+}
+
 func Path(r Reader) string {
 	parent := r.Parent()
 	localPath := "/" + pathPart(r)
@@ -142,11 +178,30 @@ func ContainsCoordinate(r Reader, globalX, globalY float64) bool {
 // CoordToControl will return the deepest Focusable node that contains the
 // provided global coordinate.
 //
-// The search will begin at the provided node (usually root), and at each level,
-// will step forward only along the child that contains the coordinate. Once a
-// leaf is found, the code will walk back up until the nearest Focusable node
-// is returned.
+// Overlays are checked first, from the topmost down, because they are drawn
+// above all other content. A coordinate outside of a modal overlay returns
+// the modal overlay itself, so that the layers beneath it cannot be reached.
+// The search will then begin at the provided node (usually root), and at
+// each level, will step forward only along the child that contains the
+// coordinate. Once a leaf is found, the code will walk back up until the
+// nearest Focusable node is returned.
 func CoordToControl(r ReadWriter, globalX, globalY float64) ReadWriter {
+	overlays := Overlays(r)
+	for index := len(overlays) - 1; index >= 0; index-- {
+		overlay := overlays[index]
+		if ContainsCoordinate(overlay, globalX, globalY) {
+			// Padding between the children of an overlay still belongs to
+			// the control that owns it.
+			return NearestFocusable(coordToControl(overlay, globalX, globalY))
+		}
+		if overlay.IsModal() {
+			return overlay
+		}
+	}
+	return coordToControl(r, globalX, globalY)
+}
+
+func coordToControl(r ReadWriter, globalX, globalY float64) ReadWriter {
 	result := r
 
 	children := r.Children()
@@ -157,8 +212,13 @@ func CoordToControl(r ReadWriter, globalX, globalY float64) ReadWriter {
 	}
 
 	for _, child := range children {
+		// Invisible and collapsed subtrees cannot be hit, and overlays were
+		// already checked.
+		if !child.Visible() || child.Collapsed() || child.IsOverlay() {
+			continue
+		}
 		if ContainsCoordinate(child, globalX, globalY) {
-			result = CoordToControl(child, globalX, globalY)
+			result = coordToControl(child, globalX, globalY)
 			break
 		}
 	}
@@ -166,6 +226,48 @@ func CoordToControl(r ReadWriter, globalX, globalY float64) ReadWriter {
 	return result
 }
 
+// Overlays returns the visible overlays within the provided node (but not
+// the node itself), in the order that they are drawn. Overlays are ordered
+// by ZIndex, and then by tree order, where overlays within an overlay follow
+// it, so that they are drawn above it.
+func Overlays(r Reader) []ReadWriter {
+	result := overlays(r)
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].ZIndex() < result[j].ZIndex()
+	})
+	return result
+}
+
+func overlays(r Reader) []ReadWriter {
+	var result []ReadWriter
+	for _, child := range r.Children() {
+		if !child.Visible() || child.Collapsed() {
+			continue
+		}
+		if child.IsOverlay() {
+			result = append(result, child)
+		}
+		result = append(result, overlays(child)...)
+	}
+	return result
+}
+
+// IsBlocked returns true if the provided Spec is beneath the topmost modal
+// overlay within root, and should not receive input.
+func IsBlocked(root Reader, s Reader) bool {
+	overlays := Overlays(root)
+	for index := len(overlays) - 1; index >= 0; index-- {
+		overlay := overlays[index]
+		if overlay == s || Contains(overlay, s) {
+			return false
+		}
+		if overlay.IsModal() {
+			return true
+		}
+	}
+	return false
+}
+
 // LocalToGlobal returns the corresponding coordinate on the Global stage,
 // given the control local coordinates.
 func LocalToGlobal(r Reader, localX, localY float64) (float64, float64) {
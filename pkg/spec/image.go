@@ -0,0 +1,17 @@
+package spec
+
+// ImageFit describes how an image is scaled into the bounds of a Spec.
+type ImageFit int
+
+const (
+	// FitContain scales the image to fit entirely within the bounds while
+	// preserving the aspect ratio.
+	FitContain = ImageFit(iota)
+	// FitCover scales the image to fill the bounds while preserving the
+	// aspect ratio, cropping whatever does not fit.
+	FitCover
+	// FitFill stretches the image to exactly fill the bounds.
+	FitFill
+	// FitNone draws the image at its intrinsic size, centered and cropped.
+	FitNone
+)
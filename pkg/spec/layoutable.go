@@ -1,5 +1,9 @@
 package spec
 
+import (
+	"github.com/waybeams/waybeams/pkg/i18n"
+)
+
 type LayoutAxis int
 
 const (
@@ -24,6 +28,17 @@ const (
 	RowLayoutType
 )
 
+// LayoutDirectionValue selects the order of children in horizontal flow
+// layouts. Specs inherit the direction of their parent, and the root
+// follows the active locale (see i18n.IsRightToLeft).
+type LayoutDirectionValue int
+
+const (
+	InheritDirection = iota
+	LeftToRightDirection
+	RightToLeftDirection
+)
+
 // Alignment is used represent alignment of Spec children, text or any other
 // alignable entities.
 type Alignment int
@@ -37,6 +52,19 @@ const (
 	AlignMiddle // DO NOT USE EXCEPT FOR COMPAT w/fontstashmini alignment api
 )
 
+// PlacementValue selects where an overlay is placed against its anchor. An
+// overlay that collides with the edge of the root is moved to the opposite
+// side of its anchor, if there is room, and is then kept inside the root.
+type PlacementValue int
+
+const (
+	PlaceBelow = iota
+	PlaceAbove
+	PlaceLeft
+	PlaceRight
+	PlaceCenter // Centered in the root, without regard for the anchor
+)
+
 // LayoutHandler is a concrete implementation of a given layout. These handlers
 // are pure functions that accept a Displayable and manage the scale and
 // position of the children for that element.
@@ -60,6 +88,7 @@ type LayoutableWriter interface {
 	SetChildrenHeight(height float64)
 	SetChildrenWidth(width float64)
 	SetContentHeight(height float64)
+	SetCollapsed(bool)
 	SetContentWidth(width float64)
 	SetExcludeFromLayout(bool)
 	SetFlexHeight(int float64)
@@ -67,16 +96,21 @@ type LayoutableWriter interface {
 	SetGutter(value float64)
 	SetHAlign(align Alignment)
 	SetIsMeasured(measured bool)
+	SetIsModal(isModal bool)
+	SetIsOverlay(isOverlay bool)
+	SetLayoutDirection(direction LayoutDirectionValue)
 	SetLayoutType(layoutType LayoutTypeValue)
 	SetMaxHeight(h float64)
 	SetMaxWidth(w float64)
 	SetMinHeight(h float64)
 	SetMinWidth(w float64)
+	SetOverlayAnchor(key string)
 	SetPadding(value float64)
 	SetPaddingBottom(value float64)
 	SetPaddingLeft(value float64)
 	SetPaddingRight(value float64)
 	SetPaddingTop(value float64)
+	SetPlacement(placement PlacementValue)
 	SetPrefHeight(value float64)
 	SetPrefWidth(value float64)
 	SetTextX(value float64)
@@ -84,6 +118,7 @@ type LayoutableWriter interface {
 	SetVAlign(align Alignment)
 	SetX(x float64)
 	SetY(y float64)
+	SetZIndex(zIndex int)
 }
 
 type LayoutableReader interface {
@@ -93,6 +128,7 @@ type LayoutableReader interface {
 	ActualWidth() float64
 	ChildrenHeight() float64
 	ChildrenWidth() float64
+	Collapsed() bool
 	ContentHeight() float64
 	ContentWidth() float64
 	ExcludeFromLayout() bool
@@ -101,17 +137,22 @@ type LayoutableReader interface {
 	Gutter() float64
 	HAlign() Alignment
 	IsMeasured() bool
+	IsModal() bool
+	IsOverlay() bool
 	HorizontalPadding() float64
+	LayoutDirection() LayoutDirectionValue
 	LayoutType() LayoutTypeValue
 	MaxHeight() float64
 	MaxWidth() float64
 	Measure(s Surface)
 	MinHeight() float64
 	MinWidth() float64
+	OverlayAnchor() string
 	PaddingBottom() float64
 	PaddingLeft() float64
 	PaddingRight() float64
 	PaddingTop() float64
+	Placement() PlacementValue
 	PrefHeight() float64
 	PrefWidth() float64
 	TextX() float64
@@ -122,6 +163,7 @@ type LayoutableReader interface {
 	XOffset() float64
 	Y() float64
 	YOffset() float64
+	ZIndex() int
 }
 
 type LayoutableReadWriter interface {
@@ -145,6 +187,24 @@ func (c *Spec) LayoutType() LayoutTypeValue {
 	return c.layoutType
 }
 
+func (c *Spec) SetLayoutDirection(direction LayoutDirectionValue) {
+	c.layoutDirection = direction
+}
+
+func (c *Spec) LayoutDirection() LayoutDirectionValue {
+	if c.layoutDirection != InheritDirection {
+		return c.layoutDirection
+	}
+	parent := c.Parent()
+	if parent != nil {
+		return parent.LayoutDirection()
+	}
+	if i18n.IsRightToLeft() {
+		return RightToLeftDirection
+	}
+	return LeftToRightDirection
+}
+
 func (c *Spec) IsMeasured() bool {
 	return c.isMeasured
 }
@@ -161,6 +221,60 @@ func (c *Spec) SetIsMeasured(measured bool) {
 	c.isMeasured = measured
 }
 
+// IsOverlay returns true if the Spec floats above the rest of the tree,
+// rather than being laid out and drawn inside its parent.
+func (c *Spec) IsOverlay() bool {
+	return c.isOverlay
+}
+
+// SetIsOverlay removes this Spec from the layout of its parent, and lays it
+// out, draws it and hit-tests it above all other content.
+func (c *Spec) SetIsOverlay(isOverlay bool) {
+	c.isOverlay = isOverlay
+}
+
+// IsModal returns true if this overlay blocks input to everything drawn
+// beneath it.
+func (c *Spec) IsModal() bool {
+	return c.isModal
+}
+
+// SetIsModal makes this overlay block pointer and keyboard input to the
+// layers beneath it.
+func (c *Spec) SetIsModal(isModal bool) {
+	c.isModal = isModal
+}
+
+// OverlayAnchor returns the Key of the Spec that this overlay is placed
+// against. An empty Key places the overlay against its parent.
+func (c *Spec) OverlayAnchor() string {
+	return c.overlayAnchor
+}
+
+func (c *Spec) SetOverlayAnchor(key string) {
+	c.overlayAnchor = key
+}
+
+// Placement returns the side of its anchor that this overlay is placed on.
+func (c *Spec) Placement() PlacementValue {
+	return c.placement
+}
+
+func (c *Spec) SetPlacement(placement PlacementValue) {
+	c.placement = placement
+}
+
+// ZIndex returns the stacking order of this overlay. Overlays with a higher
+// ZIndex are drawn and hit-tested above those with a lower one, and
+// overlays with the same ZIndex stack in tree order.
+func (c *Spec) ZIndex() int {
+	return c.zIndex
+}
+
+func (c *Spec) SetZIndex(zIndex int) {
+	c.zIndex = zIndex
+}
+
 func (c *Spec) SetX(x float64) {
 	c.x = x
 }
@@ -319,6 +433,12 @@ func (c *Spec) SetExcludeFromLayout(value bool) {
 	c.excludeFromLayout = value
 }
 
+// SetCollapsed removes this Spec and its descendants from layout, drawing
+// and hit-testing, as if it were not in the tree.
+func (c *Spec) SetCollapsed(value bool) {
+	c.isCollapsed = value
+}
+
 func (c *Spec) SetMinWidth(min float64) {
 	c.minWidth = min
 }
@@ -355,6 +475,10 @@ func (c *Spec) ExcludeFromLayout() bool {
 	return c.excludeFromLayout
 }
 
+func (c *Spec) Collapsed() bool {
+	return c.isCollapsed
+}
+
 func (c *Spec) SetFlexWidth(value float64) {
 	c.flexWidth = value
 }
@@ -1,5 +1,10 @@
 package spec
 
+import (
+	"github.com/waybeams/waybeams/pkg/font"
+	"github.com/waybeams/waybeams/pkg/text"
+)
+
 // OffsetSurface provides a Surface interface to a concrete Surface
 // implementation, but will offset any global coordinates to the local
 // coordinate space.
@@ -57,11 +62,107 @@ func (s *OffsetSurface) RoundedRect(x, y, width, height, radius float64) {
 	s.delegateTo.RoundedRect(x, y, width, height, radius)
 }
 
+// RoundedRectVarying draws a rectangle with a different radius at each
+// corner.
+func (s *OffsetSurface) RoundedRectVarying(x, y, width, height, topLeft, topRight, bottomRight, bottomLeft float64) {
+	x += s.offsetX
+	y += s.offsetY
+	s.delegateTo.RoundedRectVarying(x, y, width, height, topLeft, topRight, bottomRight, bottomLeft)
+}
+
+// SetFillBoxGradient configures a feathered box gradient fill.
+func (s *OffsetSurface) SetFillBoxGradient(x, y, width, height, radius, feather float64, innerColor, outerColor uint) {
+	x += s.offsetX
+	y += s.offsetY
+	s.delegateTo.SetFillBoxGradient(x, y, width, height, radius, feather, innerColor, outerColor)
+}
+
+// SetFillLinearGradient configures a linear gradient fill.
+func (s *OffsetSurface) SetFillLinearGradient(startX, startY, endX, endY float64, startColor, endColor uint) {
+	startX += s.offsetX
+	startY += s.offsetY
+	endX += s.offsetX
+	endY += s.offsetY
+	s.delegateTo.SetFillLinearGradient(startX, startY, endX, endY, startColor, endColor)
+}
+
+// SetFillRadialGradient configures a radial gradient fill.
+func (s *OffsetSurface) SetFillRadialGradient(centerX, centerY, innerRadius, outerRadius float64, innerColor, outerColor uint) {
+	centerX += s.offsetX
+	centerY += s.offsetY
+	s.delegateTo.SetFillRadialGradient(centerX, centerY, innerRadius, outerRadius, innerColor, outerColor)
+}
+
+// BezierTo adds a cubic bezier segment to the current path.
+func (s *OffsetSurface) BezierTo(c1x, c1y, c2x, c2y, x, y float64) {
+	s.delegateTo.BezierTo(c1x+s.offsetX, c1y+s.offsetY, c2x+s.offsetX, c2y+s.offsetY, x+s.offsetX, y+s.offsetY)
+}
+
+// ClosePath closes the current sub-path.
+func (s *OffsetSurface) ClosePath() {
+	s.delegateTo.ClosePath()
+}
+
+// Ellipse adds an ellipse sub-path to the current path.
+func (s *OffsetSurface) Ellipse(cx, cy, rx, ry float64) {
+	s.delegateTo.Ellipse(cx+s.offsetX, cy+s.offsetY, rx, ry)
+}
+
+// LineTo adds a line segment to the current path.
+func (s *OffsetSurface) LineTo(x, y float64) {
+	s.delegateTo.LineTo(x+s.offsetX, y+s.offsetY)
+}
+
+// MoveTo starts a new sub-path.
+func (s *OffsetSurface) MoveTo(x, y float64) {
+	s.delegateTo.MoveTo(x+s.offsetX, y+s.offsetY)
+}
+
+// PathWinding sets the winding of the current sub-path.
+func (s *OffsetSurface) PathWinding(winding Winding) {
+	s.delegateTo.PathWinding(winding)
+}
+
+// QuadTo adds a quadratic bezier segment to the current path.
+func (s *OffsetSurface) QuadTo(cx, cy, x, y float64) {
+	s.delegateTo.QuadTo(cx+s.offsetX, cy+s.offsetY, x+s.offsetX, y+s.offsetY)
+}
+
+// CreateImage loads and caches the image at path.
+func (s *OffsetSurface) CreateImage(name, path string) error {
+	return s.delegateTo.CreateImage(name, path)
+}
+
+// CreateImageFromBytes caches the provided encoded image data.
+func (s *OffsetSurface) CreateImageFromBytes(name string, data []byte) error {
+	return s.delegateTo.CreateImageFromBytes(name, data)
+}
+
+// DrawImage draws the named image scaled into the provided box.
+func (s *OffsetSurface) DrawImage(name string, x, y, width, height float64) {
+	s.delegateTo.DrawImage(name, x+s.offsetX, y+s.offsetY, width, height)
+}
+
+// DrawImageRegion draws part of the named image scaled into the provided box.
+func (s *OffsetSurface) DrawImageRegion(name string, srcX, srcY, srcWidth, srcHeight, x, y, width, height float64) {
+	s.delegateTo.DrawImageRegion(name, srcX, srcY, srcWidth, srcHeight, x+s.offsetX, y+s.offsetY, width, height)
+}
+
+// ImageSize returns the intrinsic size of the named image.
+func (s *OffsetSurface) ImageSize(name string) (width, height float64, ok bool) {
+	return s.delegateTo.ImageSize(name)
+}
+
 // Fill will fill the previously drawn shape.
 func (s *OffsetSurface) Fill() {
 	s.delegateTo.Fill()
 }
 
+// SetGlobalAlpha configures the opacity of subsequent drawing operations.
+func (s *OffsetSurface) SetGlobalAlpha(alpha float64) {
+	s.delegateTo.SetGlobalAlpha(alpha)
+}
+
 // SetStrokeWidth configures the width in pixels of the next shape.
 func (s *OffsetSurface) SetStrokeWidth(width float64) {
 	s.delegateTo.SetStrokeWidth(width)
@@ -88,8 +189,12 @@ func (s *OffsetSurface) GetOffsetSurfaceFor(r Reader) Surface {
 	return NewOffsetSurface(r, s)
 }
 
-func (s *OffsetSurface) AddFont(name string, path string) {
-	s.delegateTo.AddFont(name, path)
+func (s *OffsetSurface) AddFont(name string, path string) error {
+	return s.delegateTo.AddFont(name, path)
+}
+
+func (s *OffsetSurface) Fonts() *font.Registry {
+	return s.delegateTo.Fonts()
 }
 
 func (s *OffsetSurface) SetFontSize(size float64) {
@@ -110,6 +215,16 @@ func (s *OffsetSurface) TextBounds(face string, size float64, text string) (x, y
 	return s.delegateTo.TextBounds(face, size, text)
 }
 
+func (s *OffsetSurface) Shaper() text.Shaper {
+	return s.delegateTo.Shaper()
+}
+
+func (s *OffsetSurface) DrawGlyphs(x, y float64, line text.Line) {
+	x += s.offsetX
+	y += s.offsetY
+	s.delegateTo.DrawGlyphs(x, y, line)
+}
+
 func (s *OffsetSurface) SetWidth(w float64) {
 	s.delegateTo.SetWidth(w)
 }
@@ -140,3 +255,13 @@ func NewOffsetSurface(r Reader, delegateTo Surface) Surface {
 		offsetY:    y,
 	}
 }
+
+// NewOffsetSurfaceAt creates a new surface delegate that offsets all
+// coordinates by the provided amounts.
+func NewOffsetSurfaceAt(x, y float64, delegateTo Surface) Surface {
+	return &OffsetSurface{
+		delegateTo: delegateTo,
+		offsetX:    x,
+		offsetY:    y,
+	}
+}
@@ -1,11 +1,25 @@
 package spec
 
-import "github.com/waybeams/waybeams/pkg/clock"
+import (
+	"github.com/waybeams/waybeams/pkg/clock"
+	"github.com/waybeams/waybeams/pkg/events"
+	"github.com/waybeams/waybeams/pkg/store"
+)
 
 // Scheduler manages Specification lifecycle and environment interactions.
 type Scheduler interface {
 	Clock() clock.Clock
 	Close()
-	Listen()
+	Init()
+	Listen() error
+	PopOverlay() bool
+	Post(fn func())
+	PushOverlay(f Factory) events.Unsubscriber
+	RequestRender()
 	Root() ReadWriter
+	RunFrames(n int) bool
+	RunOnUIThread(fn func()) bool
+	Step() bool
+	Stop()
+	Watch(models ...store.Observable)
 }
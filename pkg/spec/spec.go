@@ -2,6 +2,7 @@ package spec
 
 import (
 	"github.com/waybeams/waybeams/pkg/events"
+	"github.com/waybeams/waybeams/pkg/font"
 )
 
 type Factory func() ReadWriter
@@ -51,15 +52,18 @@ type ReadWriter interface {
 type Spec struct {
 	events.EmitterBase
 
+	activeTransitions []*propertyTransition
 	actualHeight      float64
 	actualWidth       float64
 	bgColor           uint
+	borders           Borders
 	children          []ReadWriter
 	childrenHeight    float64
 	childrenWidth     float64
 	composer          interface{}
 	contentHeight     float64
 	contentWidth      float64
+	cornerRadii       Corners
 	currentState      string
 	excludeFromLayout bool
 	factory           func() ReadWriter
@@ -69,42 +73,57 @@ type Spec struct {
 	fontColor         uint
 	fontFace          string
 	fontSize          float64
+	fontStyle         font.Style
+	fontWeight        int
+	gradient          *Gradient
 	gutter            float64
 	hAlign            Alignment
 	height            float64
+	isCollapsed       bool
+	initialState      string
+	isConfigured      bool
 	isFocusable       bool
 	isInvisible       bool
 	isMeasured        bool
+	isModal           bool
+	isOverlay         bool
 	isText            bool
 	isTextInput       bool
 	key               string
+	layoutDirection   LayoutDirectionValue
 	layoutType        LayoutTypeValue
 	maxHeight         float64
 	maxWidth          float64
 	minHeight         float64
 	minWidth          float64
 	name              string
+	overlayAnchor     string
 	paddingBottom     float64
 	paddingLeft       float64
 	paddingRight      float64
 	paddingTop        float64
 	parent            ReadWriter
+	placement         PlacementValue
 	prefHeight        float64
 	prefWidth         float64
+	shadows           []BoxShadow
 	siblingsFactory   func() []ReadWriter
 	specName          string
 	states            map[string][]Option
 	strokeColor       uint
 	strokeSize        float64
 	text              string
+	transitions       []Transition
 	textX             float64
 	textY             float64
+	transparency      float64
 	unsubs            []events.Unsubscriber
 	vAlign            Alignment
 	view              RenderHandler
 	width             float64
 	x                 float64
 	y                 float64
+	zIndex            int
 }
 
 func (c *Spec) Invalidate() {
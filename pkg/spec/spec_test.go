@@ -4,6 +4,7 @@ import (
 	"github.com/waybeams/assert"
 	"github.com/waybeams/waybeams/pkg/events"
 	"github.com/waybeams/waybeams/pkg/fakes"
+	"github.com/waybeams/waybeams/pkg/font"
 	"github.com/waybeams/waybeams/pkg/opts"
 	"github.com/waybeams/waybeams/pkg/spec"
 	"testing"
@@ -140,4 +141,46 @@ func TestSpec(t *testing.T) {
 		assert.Nil(received[0].Target(), "Expected nil target because Go embed != inherit")
 		assert.Nil(received[0].Payload())
 	})
+
+	t.Run("Apply emits Created and Configured", func(t *testing.T) {
+		received := []string{}
+		record := func(e events.Event) {
+			received = append(received, e.Name()+":"+e.Target().(spec.ReadWriter).Text())
+		}
+		fakes.Fake(
+			opts.Text("abcd"),
+			opts.On(events.Created, record),
+			opts.On(events.Configured, record),
+			opts.OnState("active", opts.Text("efgh")),
+		)
+		assert.Equal(received, []string{"Created:abcd", "Configured:efgh"})
+	})
+
+	t.Run("Opacity defaults to opaque and clamps", func(t *testing.T) {
+		instance := fakes.Fake()
+		assert.Equal(instance.Opacity(), 1.0)
+		instance.SetOpacity(0.25)
+		assert.Equal(instance.Opacity(), 0.25)
+		instance.SetOpacity(2)
+		assert.Equal(instance.Opacity(), 1.0)
+		instance.SetOpacity(-1)
+		assert.Equal(instance.Opacity(), 0.0)
+	})
+
+	t.Run("Font weight and style inherit from parent", func(t *testing.T) {
+		child := fakes.Fake()
+		assert.Equal(child.FontWeight(), font.Regular)
+		assert.Equal(child.FontStyle(), font.Normal)
+
+		fakes.Fake(
+			opts.FontWeight(font.Light),
+			opts.FontStyle(font.Italic),
+			opts.Child(child),
+		)
+		assert.Equal(child.FontWeight(), font.Light)
+		assert.Equal(child.FontStyle(), font.Italic)
+
+		child.SetFontWeight(font.Bold)
+		assert.Equal(child.FontWeight(), font.Bold)
+	})
 }
@@ -2,12 +2,15 @@ package spec
 
 type StatefulReader interface {
 	HasState(name string) bool
+	IsTransitioning() bool
 	OnState(name string, options ...Option)
 	OptionsForState(stateName string) []Option
 	State() string
+	Transitions() []Transition
 }
 
 type StatefulWriter interface {
+	AddTransition(transition Transition)
 	SetState(name string)
 }
 
@@ -30,8 +33,15 @@ func (c *Spec) OnState(name string, options ...Option) {
 	c.getStates()[name] = options
 }
 
+// SetState moves the Spec to the named state. Once the Spec has been
+// configured, the options for the new state are applied immediately and any
+// matching Transitions interpolate the properties that changed.
 func (c *Spec) SetState(name string) {
+	previous := c.currentState
 	c.currentState = name
+	if c.isConfigured && previous != name {
+		c.applyState(previous, name)
+	}
 }
 
 func (c *Spec) OptionsForState(stateName string) []Option {
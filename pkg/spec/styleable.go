@@ -1,28 +1,50 @@
 package spec
 
+import (
+	"math"
+
+	"github.com/waybeams/waybeams/pkg/font"
+)
+
 const DefaultBgColor = 0xce3262ff
 const DefaultFontColor = 0xffffffff
 const DefaultFontSize = 24
 const DefaultFontFace = "Roboto"
+const DefaultFontStyle = font.Normal
+const DefaultFontWeight = font.Regular
 const DefaultStrokeColor = 0xffffffff
 const DefaultStrokeSize = 1
 
 // Styleable entities can have their visual styles updated.
 type StyleableReader interface {
 	BgColor() uint
+	Borders() Borders
+	CornerRadii() Corners
 	FontColor() uint
 	FontFace() string
 	FontSize() float64
+	FontStyle() font.Style
+	FontWeight() int
+	Gradient() *Gradient
+	Opacity() float64
+	Shadows() []BoxShadow
 	StrokeColor() uint
 	StrokeSize() float64
 	Visible() bool
 }
 
 type StyleableWriter interface {
+	AddShadow(shadow BoxShadow)
 	SetBgColor(color uint)
+	SetBorders(borders Borders)
+	SetCornerRadii(corners Corners)
 	SetFontColor(color uint)
 	SetFontFace(face string)
 	SetFontSize(size float64)
+	SetFontStyle(style font.Style)
+	SetFontWeight(weight int)
+	SetGradient(gradient *Gradient)
+	SetOpacity(opacity float64)
 	SetStrokeColor(color uint)
 	SetStrokeSize(size float64)
 	SetVisible(visible bool)
@@ -74,6 +96,34 @@ func (c *Spec) FontSize() float64 {
 	return fontSize
 }
 
+// FontStyle returns the configured font.Style, or inherits it from the
+// nearest parent.
+func (c *Spec) FontStyle() font.Style {
+	fontStyle := c.fontStyle
+	if fontStyle == 0 {
+		parent := c.Parent()
+		if parent != nil {
+			return parent.FontStyle()
+		}
+		return DefaultFontStyle
+	}
+	return fontStyle
+}
+
+// FontWeight returns the configured weight (e.g., font.Light), or inherits
+// it from the nearest parent.
+func (c *Spec) FontWeight() int {
+	fontWeight := c.fontWeight
+	if fontWeight == 0 {
+		parent := c.Parent()
+		if parent != nil {
+			return parent.FontWeight()
+		}
+		return DefaultFontWeight
+	}
+	return fontWeight
+}
+
 func (c *Spec) SetBgColor(color uint) {
 	c.bgColor = color
 }
@@ -86,6 +136,14 @@ func (c *Spec) SetFontSize(size float64) {
 	c.fontSize = size
 }
 
+func (c *Spec) SetFontStyle(style font.Style) {
+	c.fontStyle = style
+}
+
+func (c *Spec) SetFontWeight(weight int) {
+	c.fontWeight = weight
+}
+
 func (c *Spec) SetFontColor(size uint) {
 	c.fontColor = size
 }
@@ -94,6 +152,13 @@ func (c *Spec) SetStrokeColor(size uint) {
 	c.strokeColor = size
 }
 
+// SetOpacity configures the opacity (0.0 - 1.0) of this Spec and all of its
+// descendants. Values outside that range are clamped.
+func (c *Spec) SetOpacity(opacity float64) {
+	// We store transparency so that the default value is fully opaque.
+	c.transparency = 1 - math.Max(0, math.Min(1, opacity))
+}
+
 func (c *Spec) SetStrokeSize(size float64) {
 	c.strokeSize = size
 }
@@ -103,6 +168,12 @@ func (c *Spec) SetVisible(visible bool) {
 	c.isInvisible = !visible
 }
 
+// Opacity returns the opacity of this Spec, not including any opacity that
+// is inherited from parents at draw time.
+func (c *Spec) Opacity() float64 {
+	return 1 - c.transparency
+}
+
 func (c *Spec) StrokeColor() uint {
 	return c.strokeColor
 }
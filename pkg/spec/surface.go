@@ -1,5 +1,21 @@
 package spec
 
+import (
+	"github.com/waybeams/waybeams/pkg/font"
+	"github.com/waybeams/waybeams/pkg/text"
+)
+
+// Winding describes the direction of a closed sub-path, which determines
+// whether it is filled or cut out of the shapes that surround it.
+type Winding int
+
+const (
+	// SolidWinding sub-paths are filled.
+	SolidWinding = Winding(iota + 1)
+	// HoleWinding sub-paths are cut out of the surrounding shape.
+	HoleWinding
+)
+
 // Surface is an interface that should hide concrete drawing implementations
 // from controls. Using this interface should allow us to reasonably easily
 // swap rendering backends (e.g., NanoVg, Cairo, Skia, HTML Canvas, etc.)
@@ -12,6 +28,29 @@ type Surface interface {
 	// BeginPath starts a new stroke or fill path.
 	BeginPath()
 
+	// BezierTo adds a cubic bezier segment from the current point to x and y
+	// using the two provided control points.
+	BezierTo(c1x, c1y, c2x, c2y, x, y float64)
+
+	// ClosePath closes the current sub-path with a line segment.
+	ClosePath()
+
+	// Ellipse adds an ellipse sub-path centered on cx and cy.
+	Ellipse(cx, cy, rx, ry float64)
+
+	// LineTo adds a line segment from the current point to x and y.
+	LineTo(x, y float64)
+
+	// MoveTo starts a new sub-path at x and y.
+	MoveTo(x, y float64)
+
+	// PathWinding sets the winding of the current sub-path.
+	PathWinding(winding Winding)
+
+	// QuadTo adds a quadratic bezier segment from the current point to x and
+	// y using the provided control point.
+	QuadTo(cx, cy, x, y float64)
+
 	// BeginFrame initiates a new frame rendering.
 	BeginFrame()
 
@@ -21,6 +60,26 @@ type Surface interface {
 	// Close the surface for further operations.
 	Close()
 
+	// CreateImage loads the PNG, JPEG or GIF file at the provided path and
+	// caches it by name. Creating an image with a name that is already cached
+	// does nothing.
+	CreateImage(name, path string) error
+
+	// CreateImageFromBytes caches the provided encoded image data by name.
+	CreateImageFromBytes(name string, data []byte) error
+
+	// DrawImage draws the named image scaled into the provided box. Like
+	// DrawImageRegion, it replaces the current path.
+	DrawImage(name string, x, y, width, height float64)
+
+	// DrawImageRegion draws the source region (in image pixels) of the named
+	// image scaled into the provided box.
+	DrawImageRegion(name string, srcX, srcY, srcWidth, srcHeight, x, y, width, height float64)
+
+	// ImageSize returns the intrinsic size of the named image, and false if
+	// the image has not been created (or, in the browser, has not loaded).
+	ImageSize(name string) (width, height float64, ok bool)
+
 	// DebugDumpPathCache will print the current Path cache to log.
 	DebugDumpPathCache()
 
@@ -33,6 +92,27 @@ type Surface interface {
 	// Rect draws a rectangle with rounded corners from x and y to width and height.
 	RoundedRect(x, y, width, height, radius float64)
 
+	// RoundedRectVarying draws a rectangle with a different radius at each
+	// corner, clockwise from the top left.
+	RoundedRectVarying(x, y, width, height, topLeft, topRight, bottomRight, bottomLeft float64)
+
+	// SetFillBoxGradient configures the fill as a feathered, rounded box that
+	// blends from innerColor to outerColor. This is most often used to draw
+	// shadows.
+	SetFillBoxGradient(x, y, width, height, radius, feather float64, innerColor, outerColor uint)
+
+	// SetFillLinearGradient configures the fill as a gradient from the start
+	// point to the end point.
+	SetFillLinearGradient(startX, startY, endX, endY float64, startColor, endColor uint)
+
+	// SetFillRadialGradient configures the fill as a gradient that radiates
+	// from the provided center point.
+	SetFillRadialGradient(centerX, centerY, innerRadius, outerRadius float64, innerColor, outerColor uint)
+
+	// SetGlobalAlpha configures the opacity (0.0 - 1.0) that is applied to
+	// all subsequent fill, stroke and text operations.
+	SetGlobalAlpha(alpha float64)
+
 	// SetStrokeWidth configures the width in pixels of the next shape.
 	SetStrokeWidth(width float64)
 
@@ -49,7 +129,13 @@ type Surface interface {
 	// they can use local coordinates for positioning.
 	// GetOffsetSurfaceFor(d Reader) Surface
 
-	AddFont(name string, path string)
+	// AddFont registers the font file at path as the regular weight and
+	// normal style of the named family.
+	AddFont(name string, path string) error
+
+	// Fonts returns the Registry that is used to resolve a family, weight
+	// and style to the face name that SetFontFace and TextBounds expect.
+	Fonts() *font.Registry
 
 	SetFontSize(size float64)
 
@@ -59,6 +145,15 @@ type Surface interface {
 
 	TextBounds(face string, size float64, text string) (x, y, w, h float64)
 
+	// Shaper returns the text Shaper for this surface, which measures text
+	// that TextBounds cannot (e.g., right to left or complex scripts) and
+	// wraps lines.
+	Shaper() text.Shaper
+
+	// DrawGlyphs draws a shaped Line with the current fill color, font face
+	// and size, with the start of the line at x and its baseline at y.
+	DrawGlyphs(x, y float64, line text.Line)
+
 	// SetWidth sets the horizontal size of the surface.
 	SetWidth(w float64)
 
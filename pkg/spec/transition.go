@@ -0,0 +1,300 @@
+package spec
+
+import (
+	"time"
+
+	"github.com/waybeams/waybeams/pkg/helpers"
+)
+
+// Animatable property names that may be provided to Transition.Property.
+const BgColorProperty = "BgColor"
+const FontColorProperty = "FontColor"
+const FontSizeProperty = "FontSize"
+const OpacityProperty = "Opacity"
+const PrefHeightProperty = "PrefHeight"
+const PrefWidthProperty = "PrefWidth"
+const StrokeColorProperty = "StrokeColor"
+const StrokeSizeProperty = "StrokeSize"
+
+// Transition describes how animatable properties interpolate when a Spec
+// moves from one state to another. Empty From, To or Property values match
+// any state or property, and the most specific matching Transition wins.
+type Transition struct {
+	Delay    time.Duration
+	Duration time.Duration
+	Easing   func(t float64) float64
+	From     string
+	Property string
+	To       string
+}
+
+func (t Transition) specificity(from, to, property string) int {
+	score := 0
+	for _, pair := range [][2]string{{t.From, from}, {t.To, to}, {t.Property, property}} {
+		if pair[0] == "" {
+			continue
+		}
+		if pair[0] != pair[1] {
+			return -1
+		}
+		score++
+	}
+	return score
+}
+
+type animatableProperty struct {
+	name    string
+	isColor bool
+	get     func(r ReadWriter) float64
+	set     func(w ReadWriter, value float64)
+}
+
+var animatableProperties = []animatableProperty{
+	{BgColorProperty, true, func(r ReadWriter) float64 { return float64(r.BgColor()) }, func(w ReadWriter, v float64) { w.SetBgColor(uint(v)) }},
+	{FontColorProperty, true, func(r ReadWriter) float64 { return float64(r.FontColor()) }, func(w ReadWriter, v float64) { w.SetFontColor(uint(v)) }},
+	{FontSizeProperty, false, func(r ReadWriter) float64 { return r.FontSize() }, func(w ReadWriter, v float64) { w.SetFontSize(v) }},
+	{OpacityProperty, false, func(r ReadWriter) float64 { return r.Opacity() }, func(w ReadWriter, v float64) { w.SetOpacity(v) }},
+	{PrefHeightProperty, false, func(r ReadWriter) float64 { return r.PrefHeight() }, func(w ReadWriter, v float64) { w.SetPrefHeight(v) }},
+	{PrefWidthProperty, false, func(r ReadWriter) float64 { return r.PrefWidth() }, func(w ReadWriter, v float64) { w.SetPrefWidth(v) }},
+	{StrokeColorProperty, true, func(r ReadWriter) float64 { return float64(r.StrokeColor()) }, func(w ReadWriter, v float64) { w.SetStrokeColor(uint(v)) }},
+	{StrokeSizeProperty, false, func(r ReadWriter) float64 { return r.StrokeSize() }, func(w ReadWriter, v float64) { w.SetStrokeSize(v) }},
+}
+
+// propertyTransition is an in-flight interpolation of a single property.
+type propertyTransition struct {
+	elapsed    time.Duration
+	from       float64
+	property   animatableProperty
+	to         float64
+	transition Transition
+}
+
+func (p *propertyTransition) value() float64 {
+	active := p.elapsed - p.transition.Delay
+	if active >= p.transition.Duration {
+		return p.to
+	}
+	if active <= 0 {
+		return p.from
+	}
+	progress := float64(active) / float64(p.transition.Duration)
+	if p.transition.Easing != nil {
+		progress = p.transition.Easing(progress)
+	}
+	if p.property.isColor {
+		return float64(helpers.LerpColor(uint(p.from), uint(p.to), progress))
+	}
+	return p.from + (p.to-p.from)*progress
+}
+
+func (p *propertyTransition) isDone() bool {
+	return p.elapsed-p.transition.Delay >= p.transition.Duration
+}
+
+// specProvider is satisfied by any type that embeds Spec and gives
+// package-level helpers access to transition state.
+type specProvider interface {
+	baseSpec() *Spec
+}
+
+func (c *Spec) baseSpec() *Spec {
+	return c
+}
+
+func (c *Spec) AddTransition(transition Transition) {
+	c.transitions = append(c.transitions, transition)
+}
+
+func (c *Spec) Transitions() []Transition {
+	return c.transitions
+}
+
+// IsTransitioning returns true while any property is being interpolated.
+func (c *Spec) IsTransitioning() bool {
+	return len(c.activeTransitions) > 0
+}
+
+func (c *Spec) transitionFor(from, to, property string) (Transition, bool) {
+	best := -1
+	var result Transition
+	for _, transition := range c.transitions {
+		score := transition.specificity(from, to, property)
+		if score >= best && score >= 0 {
+			best = score
+			result = transition
+		}
+	}
+	return result, best >= 0
+}
+
+func snapshotProperties(rw ReadWriter) []float64 {
+	values := make([]float64, len(animatableProperties))
+	for index, property := range animatableProperties {
+		values[index] = property.get(rw)
+	}
+	return values
+}
+
+// applyState applies the options for the named state and starts any
+// Transitions that match properties that were changed by those options.
+func (c *Spec) applyState(from, to string) {
+	before := snapshotProperties(c)
+	applyOptionsForState(c)
+	after := snapshotProperties(c)
+	if c.transitionProperties(from, to, before, after) {
+		c.Invalidate()
+	}
+}
+
+// transitionProperties starts a Transition for each property whose value
+// differs between before and after, and returns true if any were started.
+func (c *Spec) transitionProperties(from, to string, before, after []float64) bool {
+	isStarted := false
+	for index, property := range animatableProperties {
+		if before[index] == after[index] {
+			continue
+		}
+		// Any in-flight transition is replaced or, without a match, the new
+		// value snaps into place.
+		c.removeTransition(property.name)
+		transition, ok := c.transitionFor(from, to, property.name)
+		if !ok || transition.Duration+transition.Delay <= 0 {
+			continue
+		}
+		property.set(c, before[index])
+		c.activeTransitions = append(c.activeTransitions, &propertyTransition{
+			from:       before[index],
+			property:   property,
+			to:         after[index],
+			transition: transition,
+		})
+		isStarted = true
+	}
+	return isStarted
+}
+
+func (c *Spec) removeTransition(name string) {
+	remaining := c.activeTransitions[:0:0]
+	for _, active := range c.activeTransitions {
+		if active.property.name != name {
+			remaining = append(remaining, active)
+		}
+	}
+	c.activeTransitions = remaining
+}
+
+func (c *Spec) stepTransitions(delta time.Duration) bool {
+	if len(c.activeTransitions) == 0 {
+		return false
+	}
+	remaining := c.activeTransitions[:0:0]
+	for _, active := range c.activeTransitions {
+		active.elapsed += delta
+		active.property.set(c, active.value())
+		if !active.isDone() {
+			remaining = append(remaining, active)
+		}
+	}
+	c.activeTransitions = remaining
+	return true
+}
+
+// inheritState carries interaction state and in-flight transitions from the
+// Spec that occupied the same path in the previous tree.
+func (c *Spec) inheritState(previous *Spec) {
+	declared := c.currentState
+
+	// Only carry a state forward if the declared state is unchanged, so that
+	// interaction (e.g., hovered) survives, but a newly declared state (e.g.,
+	// disabled) wins.
+	if previous.initialState == declared && previous.currentState != declared {
+		c.currentState = previous.currentState
+		applyOptionsForState(c)
+	}
+
+	next := snapshotProperties(c)
+	current := snapshotProperties(previous)
+
+	for _, active := range previous.activeTransitions {
+		index := propertyIndex(active.property.name)
+		carried := *active
+		carried.to = next[index]
+		carried.property.set(c, carried.value())
+		c.activeTransitions = append(c.activeTransitions, &carried)
+		// Mark as handled so that it is not transitioned again below.
+		current[index] = next[index]
+	}
+
+	if previous.currentState != c.currentState {
+		c.transitionProperties(previous.currentState, c.currentState, current, next)
+	}
+}
+
+func propertyIndex(name string) int {
+	for index, property := range animatableProperties {
+		if property.name == name {
+			return index
+		}
+	}
+	return -1
+}
+
+// StepTransitions advances in-flight state Transitions on every Spec in the
+// provided tree and returns true if any are still running.
+func StepTransitions(root ReadWriter, delta time.Duration) bool {
+	isTransitioning := false
+	Walk(root, func(node ReadWriter) {
+		if provider, ok := node.(specProvider); ok {
+			s := provider.baseSpec()
+			s.stepTransitions(delta)
+			if s.IsTransitioning() {
+				isTransitioning = true
+			}
+		}
+	})
+	return isTransitioning
+}
+
+// Reconciler is implemented by Specs that carry state of their own (e.g.,
+// the caret of a TextInput) from the previous tree to the next one.
+type Reconciler interface {
+	Reconcile(previous ReadWriter)
+}
+
+// Reconcile carries interaction state and in-flight Transitions from each
+// Spec in the previous tree to the Spec at the same Path in the next tree.
+// The Scheduler calls Reconcile after each render so that replacing the tree
+// does not reset hover states or interrupt transitions.
+//
+// Once every Spec has been matched, each Reconciler in the next tree is
+// called with its match, so that it may change the tree without affecting
+// the Paths that were matched.
+func Reconcile(previous, next ReadWriter) {
+	if previous == nil || next == nil {
+		return
+	}
+	byPath := map[string]ReadWriter{}
+	Walk(previous, func(node ReadWriter) {
+		byPath[Path(node)] = node
+	})
+	var reconcilers []Reconciler
+	var matches []ReadWriter
+	Walk(next, func(node ReadWriter) {
+		match, ok := byPath[Path(node)]
+		if !ok {
+			return
+		}
+		provider, isProvider := node.(specProvider)
+		matchProvider, isMatchProvider := match.(specProvider)
+		if isProvider && isMatchProvider {
+			provider.baseSpec().inheritState(matchProvider.baseSpec())
+		}
+		if reconciler, ok := node.(Reconciler); ok {
+			reconcilers = append(reconcilers, reconciler)
+			matches = append(matches, match)
+		}
+	})
+	for index, reconciler := range reconcilers {
+		reconciler.Reconcile(matches[index])
+	}
+}
@@ -0,0 +1,114 @@
+package spec_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/waybeams/assert"
+	"github.com/waybeams/waybeams/pkg/ctrl"
+	"github.com/waybeams/waybeams/pkg/opts"
+	"github.com/waybeams/waybeams/pkg/spec"
+)
+
+func createStatefulBox(options ...spec.Option) spec.ReadWriter {
+	return ctrl.Box(
+		opts.Key("abcd"),
+		opts.OnState("active", opts.BgColor(0x000000ff), opts.StrokeSize(0)),
+		opts.OnState("hovered", opts.BgColor(0xff0000ff), opts.StrokeSize(10)),
+		opts.Bag(options...),
+	)
+}
+
+func TestTransition(t *testing.T) {
+	t.Run("SetState snaps without Transitions", func(t *testing.T) {
+		box := createStatefulBox()
+		assert.Equal(box.BgColor(), uint(0x000000ff))
+		box.SetState("hovered")
+		assert.Equal(box.BgColor(), uint(0xff0000ff))
+		assert.False(box.IsTransitioning())
+	})
+
+	t.Run("Interpolates numbers and colors", func(t *testing.T) {
+		box := createStatefulBox(opts.Transitions(spec.Transition{Duration: time.Second}))
+		box.SetState("hovered")
+		assert.True(box.IsTransitioning())
+		assert.Equal(box.BgColor(), uint(0x000000ff), "Starts from previous value")
+
+		assert.True(spec.StepTransitions(box, 500*time.Millisecond))
+		assert.Equal(box.BgColor(), uint(0x800000ff))
+		assert.Equal(box.StrokeSize(), 5.0)
+
+		assert.False(spec.StepTransitions(box, 500*time.Millisecond))
+		assert.Equal(box.BgColor(), uint(0xff0000ff))
+		assert.Equal(box.StrokeSize(), 10.0)
+	})
+
+	t.Run("Most specific Transition wins", func(t *testing.T) {
+		box := createStatefulBox(opts.Transitions(
+			spec.Transition{Duration: time.Second},
+			spec.Transition{Property: spec.StrokeSizeProperty, Duration: 2 * time.Second},
+			spec.Transition{From: "hovered", To: "active", Duration: 0},
+		))
+		box.SetState("hovered")
+		spec.StepTransitions(box, time.Second)
+		assert.Equal(box.BgColor(), uint(0xff0000ff))
+		assert.Equal(box.StrokeSize(), 5.0)
+
+		box.SetState("active")
+		assert.False(box.IsTransitioning(), "In-flight StrokeSize is replaced")
+		spec.StepTransitions(box, time.Second)
+		assert.Equal(box.BgColor(), uint(0x000000ff))
+		assert.Equal(box.StrokeSize(), 0.0)
+	})
+
+	t.Run("Delay and Easing", func(t *testing.T) {
+		box := createStatefulBox(opts.Transitions(spec.Transition{
+			Delay:    time.Second,
+			Duration: time.Second,
+			Easing:   func(t float64) float64 { return t * t },
+			Property: spec.StrokeSizeProperty,
+		}))
+		box.SetState("hovered")
+		spec.StepTransitions(box, time.Second)
+		assert.Equal(box.StrokeSize(), 0.0)
+		spec.StepTransitions(box, 500*time.Millisecond)
+		assert.Equal(box.StrokeSize(), 2.5)
+	})
+
+	t.Run("Reconcile keeps interaction state", func(t *testing.T) {
+		previous := ctrl.VBox(opts.Child(createStatefulBox()))
+		spec.FirstByKey(previous, "abcd").SetState("hovered")
+
+		next := ctrl.VBox(opts.Child(createStatefulBox()))
+		spec.Reconcile(previous, next)
+		box := spec.FirstByKey(next, "abcd")
+		assert.Equal(box.State(), "hovered")
+		assert.Equal(box.BgColor(), uint(0xff0000ff))
+	})
+
+	t.Run("Reconcile prefers newly declared state", func(t *testing.T) {
+		previous := ctrl.VBox(opts.Child(createStatefulBox()))
+		spec.FirstByKey(previous, "abcd").SetState("hovered")
+
+		next := ctrl.VBox(opts.Child(createStatefulBox(opts.SetState("active"), opts.OnState("disabled"), opts.SetState("disabled"))))
+		spec.Reconcile(previous, next)
+		assert.Equal(spec.FirstByKey(next, "abcd").State(), "disabled")
+	})
+
+	t.Run("Reconcile continues in-flight transitions", func(t *testing.T) {
+		transitions := opts.Transitions(spec.Transition{Duration: time.Second})
+		previous := ctrl.VBox(opts.Child(createStatefulBox(transitions)))
+		spec.FirstByKey(previous, "abcd").SetState("hovered")
+		spec.StepTransitions(previous, 500*time.Millisecond)
+
+		next := ctrl.VBox(opts.Child(createStatefulBox(transitions)))
+		spec.Reconcile(previous, next)
+		box := spec.FirstByKey(next, "abcd")
+		assert.True(box.IsTransitioning())
+		assert.Equal(box.StrokeSize(), 5.0)
+
+		spec.StepTransitions(next, 500*time.Millisecond)
+		assert.Equal(box.StrokeSize(), 10.0)
+		assert.False(box.IsTransitioning())
+	})
+}
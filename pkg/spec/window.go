@@ -4,8 +4,6 @@ import (
 	"github.com/waybeams/waybeams/pkg/events"
 )
 
-type CharCallback func(r rune)
-
 type Window interface {
 	ResizableWriter
 	ResizableReader
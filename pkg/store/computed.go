@@ -0,0 +1,63 @@
+package store
+
+import (
+	"sync"
+
+	"github.com/waybeams/waybeams/pkg/events"
+)
+
+// Computed is an Observable value that is derived from other Observables.
+// It is recomputed whenever a dependency changes and only emits
+// events.Changed when the derived value is actually different.
+type Computed struct {
+	Model
+
+	compute      func() interface{}
+	mutex        sync.RWMutex
+	unsubscribes []events.Unsubscriber
+	value        interface{}
+}
+
+func (c *Computed) dependencyChanged(e events.Event) {
+	value := c.compute()
+
+	c.mutex.Lock()
+	if isEqual(c.value, value) {
+		c.mutex.Unlock()
+		return
+	}
+	c.value = value
+	c.mutex.Unlock()
+
+	c.Emit(events.New(events.Changed, c, value))
+}
+
+// Get returns the most recently computed value.
+func (c *Computed) Get() interface{} {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.value
+}
+
+// Dispose unsubscribes from all dependencies.
+func (c *Computed) Dispose() {
+	c.mutex.Lock()
+	unsubscribes := c.unsubscribes
+	c.unsubscribes = nil
+	c.mutex.Unlock()
+
+	for _, unsub := range unsubscribes {
+		unsub()
+	}
+}
+
+// NewComputed returns a Computed that calls compute immediately, and again
+// whenever any of the provided dependencies change.
+func NewComputed(compute func() interface{}, deps ...Observable) *Computed {
+	c := &Computed{compute: compute}
+	c.value = compute()
+	for _, dep := range deps {
+		c.unsubscribes = append(c.unsubscribes, dep.On(events.Changed, c.dependencyChanged))
+	}
+	return c
+}
@@ -0,0 +1,47 @@
+package store_test
+
+import (
+	"testing"
+
+	"github.com/waybeams/assert"
+	"github.com/waybeams/waybeams/pkg/store"
+)
+
+func TestComputed(t *testing.T) {
+	t.Run("Derives value from dependencies", func(t *testing.T) {
+		first := store.NewValue("Jane")
+		last := store.NewValue("Doe")
+		full := store.NewComputed(func() interface{} {
+			return first.String() + " " + last.String()
+		}, first, last)
+
+		assert.Equal(full.Get(), "Jane Doe")
+		last.Set("Smith")
+		assert.Equal(full.Get(), "Jane Smith")
+	})
+
+	t.Run("Only emits when derived value changes", func(t *testing.T) {
+		changes := 0
+		count := store.NewValue(1)
+		isEven := store.NewComputed(func() interface{} {
+			return count.Int()%2 == 0
+		}, count)
+		isEven.OnChange(func() { changes++ })
+
+		count.Set(3)
+		assert.Equal(changes, 0)
+		count.Set(4)
+		assert.Equal(changes, 1)
+		assert.Equal(isEven.Get(), true)
+	})
+
+	t.Run("Dispose stops tracking", func(t *testing.T) {
+		count := store.NewValue(1)
+		doubled := store.NewComputed(func() interface{} {
+			return count.Int() * 2
+		}, count)
+		doubled.Dispose()
+		count.Set(5)
+		assert.Equal(doubled.Get(), 2)
+	})
+}
@@ -0,0 +1,118 @@
+package store
+
+import (
+	"sync"
+
+	"github.com/waybeams/waybeams/pkg/events"
+)
+
+// List is an Observable, ordered collection that emits events.Changed
+// whenever an item is added, removed or replaced.
+type List struct {
+	Model
+
+	items []interface{}
+	mutex sync.RWMutex
+}
+
+func (l *List) changed() {
+	l.Emit(events.New(events.Changed, l, nil))
+}
+
+// Append adds the provided items to the end of the List.
+func (l *List) Append(items ...interface{}) {
+	if len(items) == 0 {
+		return
+	}
+	l.mutex.Lock()
+	l.items = append(l.items, items...)
+	l.mutex.Unlock()
+	l.changed()
+}
+
+// At returns the item at the provided index.
+func (l *List) At(index int) interface{} {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+	return l.items[index]
+}
+
+// Clear removes all items from the List.
+func (l *List) Clear() {
+	l.mutex.Lock()
+	if len(l.items) == 0 {
+		l.mutex.Unlock()
+		return
+	}
+	l.items = nil
+	l.mutex.Unlock()
+	l.changed()
+}
+
+// Filter replaces the List contents with only those items for which the
+// provided function returns true.
+func (l *List) Filter(keep func(item interface{}) bool) {
+	l.mutex.Lock()
+	remaining := []interface{}{}
+	for _, item := range l.items {
+		if keep(item) {
+			remaining = append(remaining, item)
+		}
+	}
+	isChanged := len(remaining) != len(l.items)
+	l.items = remaining
+	l.mutex.Unlock()
+
+	if isChanged {
+		l.changed()
+	}
+}
+
+// Items returns a copy of the List contents.
+func (l *List) Items() []interface{} {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+	result := make([]interface{}, len(l.items))
+	copy(result, l.items)
+	return result
+}
+
+// Len returns the number of items in the List.
+func (l *List) Len() int {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+	return len(l.items)
+}
+
+// Remove removes the first instance of the provided item and returns true if
+// it was found.
+func (l *List) Remove(item interface{}) bool {
+	l.mutex.Lock()
+	for index, entry := range l.items {
+		if entry == item {
+			l.items = append(l.items[:index:index], l.items[index+1:]...)
+			l.mutex.Unlock()
+			l.changed()
+			return true
+		}
+	}
+	l.mutex.Unlock()
+	return false
+}
+
+// Set replaces the item at the provided index.
+func (l *List) Set(index int, item interface{}) {
+	l.mutex.Lock()
+	if l.items[index] == item {
+		l.mutex.Unlock()
+		return
+	}
+	l.items[index] = item
+	l.mutex.Unlock()
+	l.changed()
+}
+
+// NewList returns a List that contains the provided items.
+func NewList(items ...interface{}) *List {
+	return &List{items: items}
+}
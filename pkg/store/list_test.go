@@ -0,0 +1,56 @@
+package store_test
+
+import (
+	"testing"
+
+	"github.com/waybeams/assert"
+	"github.com/waybeams/waybeams/pkg/store"
+)
+
+func TestList(t *testing.T) {
+	t.Run("Append and read", func(t *testing.T) {
+		l := store.NewList("a")
+		l.Append("b", "c")
+		assert.Equal(l.Len(), 3)
+		assert.Equal(l.At(1), "b")
+		assert.Equal(len(l.Items()), 3)
+	})
+
+	t.Run("Mutations emit Changed", func(t *testing.T) {
+		changes := 0
+		l := store.NewList("a", "b", "c")
+		l.OnChange(func() { changes++ })
+
+		l.Set(0, "z")
+		assert.Equal(changes, 1)
+		assert.True(l.Remove("b"))
+		assert.Equal(changes, 2)
+		l.Filter(func(item interface{}) bool { return item != "c" })
+		assert.Equal(changes, 3)
+		assert.Equal(l.Items(), []interface{}{"z"})
+		l.Clear()
+		assert.Equal(changes, 4)
+		assert.Equal(l.Len(), 0)
+	})
+
+	t.Run("No-op mutations do not emit", func(t *testing.T) {
+		changes := 0
+		l := store.NewList("a")
+		l.OnChange(func() { changes++ })
+		l.Set(0, "a")
+		assert.False(l.Remove("b"))
+		l.Filter(func(item interface{}) bool { return true })
+		l.Append()
+		assert.Equal(changes, 0)
+		l.Clear()
+		l.Clear()
+		assert.Equal(changes, 1)
+	})
+
+	t.Run("Items returns a copy", func(t *testing.T) {
+		l := store.NewList("a")
+		items := l.Items()
+		items[0] = "b"
+		assert.Equal(l.At(0), "a")
+	})
+}
@@ -0,0 +1,40 @@
+package store
+
+import (
+	"reflect"
+
+	"github.com/waybeams/waybeams/pkg/events"
+)
+
+// Observable is any entity that emits events.Changed when its state has been
+// mutated. A Scheduler can Watch any number of Observables and will only
+// execute the Spec factory after one of them has changed.
+type Observable interface {
+	On(eventName string, handler events.EventHandler) events.Unsubscriber
+}
+
+// Model can be embedded in application model structs in order to make them
+// Observable. Mutating methods should call Changed once they are finished.
+type Model struct {
+	events.EmitterBase
+}
+
+// Changed notifies subscribers that the model has been mutated.
+func (m *Model) Changed() {
+	m.Emit(events.New(events.Changed, m, nil))
+}
+
+// OnChange calls the provided handler whenever the model changes.
+func (m *Model) OnChange(handler func()) events.Unsubscriber {
+	return m.On(events.Changed, events.EmptyHandler(handler))
+}
+
+// Bubble is not supported on models; they are not part of a tree, so events
+// are emitted directly to subscribers.
+func (m *Model) Bubble(event events.Event) {
+	m.Emit(event)
+}
+
+func isEqual(a, b interface{}) bool {
+	return reflect.DeepEqual(a, b)
+}
@@ -0,0 +1,69 @@
+package store
+
+import (
+	"sync"
+
+	"github.com/waybeams/waybeams/pkg/events"
+)
+
+// Value is an Observable container for a single value. Value is safe to use
+// from more than one goroutine, but handlers are called on the goroutine that
+// called Set, so background work should usually Post mutations to the UI
+// thread.
+type Value struct {
+	Model
+
+	mutex sync.RWMutex
+	value interface{}
+}
+
+// Get returns the current value.
+func (v *Value) Get() interface{} {
+	v.mutex.RLock()
+	defer v.mutex.RUnlock()
+	return v.value
+}
+
+// Set stores the provided value and emits events.Changed if it differs from
+// the current value.
+func (v *Value) Set(value interface{}) {
+	v.mutex.Lock()
+	if isEqual(v.value, value) {
+		v.mutex.Unlock()
+		return
+	}
+	v.value = value
+	v.mutex.Unlock()
+
+	v.Emit(events.New(events.Changed, v, value))
+}
+
+// Bool returns the current value as a bool, or false if it is not one.
+func (v *Value) Bool() bool {
+	result, _ := v.Get().(bool)
+	return result
+}
+
+// Float returns the current value as a float64, or zero if it is not one.
+func (v *Value) Float() float64 {
+	result, _ := v.Get().(float64)
+	return result
+}
+
+// Int returns the current value as an int, or zero if it is not one.
+func (v *Value) Int() int {
+	result, _ := v.Get().(int)
+	return result
+}
+
+// String returns the current value as a string, or an empty string if it is
+// not one.
+func (v *Value) String() string {
+	result, _ := v.Get().(string)
+	return result
+}
+
+// NewValue returns a Value that holds the provided initial value.
+func NewValue(initial interface{}) *Value {
+	return &Value{value: initial}
+}
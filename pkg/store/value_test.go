@@ -0,0 +1,40 @@
+package store_test
+
+import (
+	"testing"
+
+	"github.com/waybeams/assert"
+	"github.com/waybeams/waybeams/pkg/store"
+)
+
+func TestValue(t *testing.T) {
+	t.Run("Get returns initial value", func(t *testing.T) {
+		v := store.NewValue("abcd")
+		assert.Equal(v.Get(), "abcd")
+		assert.Equal(v.String(), "abcd")
+	})
+
+	t.Run("Typed getters", func(t *testing.T) {
+		assert.Equal(store.NewValue(23).Int(), 23)
+		assert.Equal(store.NewValue(2.5).Float(), 2.5)
+		assert.True(store.NewValue(true).Bool())
+		assert.Equal(store.NewValue(23).String(), "")
+	})
+
+	t.Run("Set emits Changed", func(t *testing.T) {
+		changes := 0
+		v := store.NewValue(1)
+		v.OnChange(func() { changes++ })
+		v.Set(2)
+		assert.Equal(v.Int(), 2)
+		assert.Equal(changes, 1)
+	})
+
+	t.Run("Set with equal value does not emit", func(t *testing.T) {
+		changes := 0
+		v := store.NewValue([]string{"a", "b"})
+		v.OnChange(func() { changes++ })
+		v.Set([]string{"a", "b"})
+		assert.Equal(changes, 0)
+	})
+}
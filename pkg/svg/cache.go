@@ -0,0 +1,67 @@
+package svg
+
+import (
+	"io/ioutil"
+	"sync"
+)
+
+// Cache holds parsed Documents by name so that each icon is only read and
+// parsed once, no matter how many times it is rendered.
+type Cache struct {
+	documents map[string]*Document
+	mutex     sync.Mutex
+}
+
+// Load returns the Document for the SVG file at the provided path, which is
+// also used as the cache key.
+func (c *Cache) Load(path string) (*Document, error) {
+	if document := c.get(path); document != nil {
+		return document, nil
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return c.LoadBytes(path, data)
+}
+
+// LoadBytes returns the Document for the provided SVG data, which is only
+// parsed the first time a name is seen. Errors are not cached.
+func (c *Cache) LoadBytes(name string, data []byte) (*Document, error) {
+	if document := c.get(name); document != nil {
+		return document, nil
+	}
+	document, err := Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.documents[name] = document
+	return document, nil
+}
+
+func (c *Cache) get(name string) *Document {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.documents[name]
+}
+
+// NewCache returns an empty Cache.
+func NewCache() *Cache {
+	return &Cache{documents: map[string]*Document{}}
+}
+
+// DefaultCache is used by Load and LoadBytes.
+var DefaultCache = NewCache()
+
+// Load returns the Document for the SVG file at path from the DefaultCache.
+func Load(path string) (*Document, error) {
+	return DefaultCache.Load(path)
+}
+
+// LoadBytes returns the Document for the provided data from the
+// DefaultCache.
+func LoadBytes(name string, data []byte) (*Document, error) {
+	return DefaultCache.LoadBytes(name, data)
+}
@@ -0,0 +1,75 @@
+package svg
+
+import (
+	"strconv"
+	"strings"
+)
+
+var namedColors = map[string]uint{
+	"black":       0x000000ff,
+	"blue":        0x0000ffff,
+	"gray":        0x808080ff,
+	"green":       0x008000ff,
+	"grey":        0x808080ff,
+	"red":         0xff0000ff,
+	"transparent": 0x00000000,
+	"white":       0xffffffff,
+}
+
+// paint is a parsed fill or stroke value. The alpha channel of color holds
+// the declared opacity, even for currentColor paints.
+type paint struct {
+	color     uint
+	isCurrent bool
+	isNone    bool
+}
+
+// parsePaint reads an SVG paint value (e.g., "#fff", "#00ff00", "rgb(0,0,0)",
+// "none" or "currentColor"). Unsupported values, like gradient references,
+// return false.
+func parsePaint(value string) (paint, bool) {
+	value = strings.TrimSpace(value)
+	switch {
+	case value == "none":
+		return paint{isNone: true}, true
+	case value == "currentColor":
+		return paint{color: 0x000000ff, isCurrent: true}, true
+	case strings.HasPrefix(value, "#"):
+		hex := value[1:]
+		if len(hex) == 3 {
+			hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+		}
+		if len(hex) != 6 {
+			return paint{}, false
+		}
+		rgb, err := strconv.ParseUint(hex, 16, 32)
+		if err != nil {
+			return paint{}, false
+		}
+		return paint{color: uint(rgb)<<8 | 0xff}, true
+	case strings.HasPrefix(value, "rgb(") && strings.HasSuffix(value, ")"):
+		parts := strings.Split(value[4:len(value)-1], ",")
+		if len(parts) != 3 {
+			return paint{}, false
+		}
+		var color uint
+		for _, part := range parts {
+			channel, err := strconv.Atoi(strings.TrimSpace(part))
+			if err != nil || channel < 0 || channel > 255 {
+				return paint{}, false
+			}
+			color = color<<8 | uint(channel)
+		}
+		return paint{color: color<<8 | 0xff}, true
+	}
+	if color, ok := namedColors[strings.ToLower(value)]; ok {
+		return paint{color: color}, true
+	}
+	return paint{}, false
+}
+
+// withOpacity multiplies the alpha channel of color by opacity.
+func withOpacity(color uint, opacity float64) uint {
+	alpha := float64(color&0xff) * opacity
+	return color&^0xff | uint(alpha+0.5)&0xff
+}
@@ -0,0 +1,336 @@
+// Package svg parses a practical subset of SVG (svg, g, path, rect, circle,
+// ellipse, line, polyline and polygon elements with fill, stroke,
+// stroke-width, opacity and translate/scale transforms) into vector Paths
+// that can be drawn onto any spec.Surface.
+package svg
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/waybeams/waybeams/pkg/helpers"
+	"github.com/waybeams/waybeams/pkg/spec"
+	"github.com/waybeams/waybeams/pkg/vector"
+)
+
+// Element is a single filled and/or stroked shape in document coordinates.
+type Element struct {
+	fill        paint
+	path        *vector.Path
+	stroke      paint
+	strokeWidth float64
+}
+
+func (e *Element) Path() *vector.Path {
+	return e.path
+}
+
+// Document is a parsed SVG image.
+type Document struct {
+	elements []*Element
+	viewBox  [4]float64
+}
+
+func (d *Document) Elements() []*Element {
+	return d.elements
+}
+
+// ViewBox returns the region of document coordinates that Draw scales into
+// the destination box.
+func (d *Document) ViewBox() (x, y, width, height float64) {
+	return d.viewBox[0], d.viewBox[1], d.viewBox[2], d.viewBox[3]
+}
+
+// Draw scales the document to fit within the provided box (preserving the
+// aspect ratio and centering it) using the colors declared in the document.
+// Any "currentColor" paint is drawn in black.
+func (d *Document) Draw(s spec.Surface, x, y, width, height float64) {
+	d.draw(s, x, y, width, height, 0x000000ff, false)
+}
+
+// DrawTinted is like Draw, but replaces every color in the document with the
+// provided tint, while keeping any declared opacity.
+func (d *Document) DrawTinted(s spec.Surface, x, y, width, height float64, tint uint) {
+	d.draw(s, x, y, width, height, tint, true)
+}
+
+func (d *Document) draw(s spec.Surface, x, y, width, height float64, tint uint, isTinted bool) {
+	viewX, viewY, viewWidth, viewHeight := d.ViewBox()
+	if viewWidth <= 0 || viewHeight <= 0 || width <= 0 || height <= 0 {
+		return
+	}
+	scale := math.Min(width/viewWidth, height/viewHeight)
+	offsetX := x + (width-viewWidth*scale)/2 - viewX*scale
+	offsetY := y + (height-viewHeight*scale)/2 - viewY*scale
+
+	colorFor := func(p paint) uint {
+		if isTinted || p.isCurrent {
+			return withOpacity(tint, float64(p.color&0xff)/0xff)
+		}
+		return p.color
+	}
+
+	for _, element := range d.elements {
+		s.BeginPath()
+		element.path.Transform(offsetX, offsetY, scale, scale).Draw(s)
+		if !element.fill.isNone {
+			s.SetFillColor(colorFor(element.fill))
+			s.Fill()
+		}
+		if !element.stroke.isNone && element.strokeWidth > 0 {
+			s.SetStrokeWidth(element.strokeWidth * scale)
+			s.SetStrokeColor(colorFor(element.stroke))
+			s.Stroke()
+		}
+	}
+}
+
+// transform is a scale followed by a translation.
+type transform struct {
+	scaleX, scaleY, translateX, translateY float64
+}
+
+var identity = transform{scaleX: 1, scaleY: 1}
+
+// then returns the transform that applies t and then parent.
+func (t transform) then(parent transform) transform {
+	return transform{
+		scaleX:     t.scaleX * parent.scaleX,
+		scaleY:     t.scaleY * parent.scaleY,
+		translateX: t.translateX*parent.scaleX + parent.translateX,
+		translateY: t.translateY*parent.scaleY + parent.translateY,
+	}
+}
+
+// style is the inheritable presentation state of an element.
+type style struct {
+	fill        paint
+	opacity     float64
+	stroke      paint
+	strokeWidth float64
+	transform   transform
+}
+
+func attributes(element xml.StartElement) map[string]string {
+	result := map[string]string{}
+	for _, attr := range element.Attr {
+		result[attr.Name.Local] = attr.Value
+	}
+	// Declarations in the style attribute take precedence.
+	for _, declaration := range strings.Split(result["style"], ";") {
+		parts := strings.SplitN(declaration, ":", 2)
+		if len(parts) == 2 {
+			result[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+		}
+	}
+	return result
+}
+
+func (s style) inherit(attrs map[string]string) (style, error) {
+	if value, ok := attrs["fill"]; ok {
+		if p, ok := parsePaint(value); ok {
+			s.fill = p
+		}
+	}
+	if value, ok := attrs["stroke"]; ok {
+		if p, ok := parsePaint(value); ok {
+			s.stroke = p
+		}
+	}
+	if value, ok := attrs["stroke-width"]; ok {
+		s.strokeWidth = length(value)
+	}
+	if value, ok := attrs["opacity"]; ok {
+		s.opacity *= length(value)
+	}
+	if value, ok := attrs["transform"]; ok {
+		local, err := parseTransform(value)
+		if err != nil {
+			return s, err
+		}
+		s.transform = local.then(s.transform)
+	}
+	return s, nil
+}
+
+// length parses a number, ignoring any "px" unit.
+func length(value string) float64 {
+	result, _ := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(value), "px"), 64)
+	return result
+}
+
+func numbers(value string) []float64 {
+	fields := strings.FieldsFunc(value, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t' || r == '\n' || r == '\r'
+	})
+	result := make([]float64, 0, len(fields))
+	for _, field := range fields {
+		result = append(result, length(field))
+	}
+	return result
+}
+
+// parseTransform supports a list of translate() and scale() functions.
+func parseTransform(value string) (transform, error) {
+	result := identity
+	value = strings.TrimSpace(value)
+	for value != "" {
+		open := strings.Index(value, "(")
+		end := strings.Index(value, ")")
+		if open < 0 || end < open {
+			return result, fmt.Errorf("svg: invalid transform %q", value)
+		}
+		name := strings.TrimSpace(value[:open])
+		args := numbers(value[open+1 : end])
+		value = strings.TrimLeft(value[end+1:], " ,")
+
+		local := identity
+		switch {
+		case name == "translate" && len(args) > 0:
+			local.translateX = args[0]
+			if len(args) > 1 {
+				local.translateY = args[1]
+			}
+		case name == "scale" && len(args) > 0:
+			local.scaleX, local.scaleY = args[0], args[0]
+			if len(args) > 1 {
+				local.scaleY = args[1]
+			}
+		default:
+			return result, fmt.Errorf("svg: unsupported transform %q", name)
+		}
+		// Functions apply right to left.
+		result = local.then(result)
+	}
+	return result, nil
+}
+
+func shapePath(name string, attrs map[string]string) (*vector.Path, error) {
+	number := func(key string) float64 {
+		return length(attrs[key])
+	}
+	p := vector.New()
+	switch name {
+	case "path":
+		return vector.Parse(attrs["d"])
+	case "rect":
+		radius := number("rx")
+		if radius == 0 {
+			radius = number("ry")
+		}
+		helpers.RoundedRectVaryingPath(p, number("x"), number("y"), number("width"), number("height"), radius, radius, radius, radius)
+	case "circle":
+		p.Ellipse(number("cx"), number("cy"), number("r"), number("r"))
+	case "ellipse":
+		p.Ellipse(number("cx"), number("cy"), number("rx"), number("ry"))
+	case "line":
+		p.MoveTo(number("x1"), number("y1"))
+		p.LineTo(number("x2"), number("y2"))
+	case "polyline", "polygon":
+		points := numbers(attrs["points"])
+		for i := 0; i+1 < len(points); i += 2 {
+			if i == 0 {
+				p.MoveTo(points[i], points[i+1])
+			} else {
+				p.LineTo(points[i], points[i+1])
+			}
+		}
+		if name == "polygon" {
+			p.ClosePath()
+		}
+	default:
+		return nil, nil
+	}
+	return p, nil
+}
+
+// Parse reads the provided SVG document. Unsupported elements (e.g., text,
+// defs or gradients) are ignored, while malformed XML, path data or
+// transforms return an error.
+func Parse(data []byte) (*Document, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	document := &Document{}
+	root := style{
+		fill:        paint{color: 0x000000ff},
+		opacity:     1,
+		stroke:      paint{isNone: true},
+		strokeWidth: 1,
+		transform:   identity,
+	}
+	stack := []style{root}
+	isRootFound := false
+
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch token := token.(type) {
+		case xml.StartElement:
+			attrs := attributes(token)
+			current, err := stack[len(stack)-1].inherit(attrs)
+			if err != nil {
+				return nil, err
+			}
+			name := token.Name.Local
+			switch name {
+			case "svg":
+				if !isRootFound {
+					isRootFound = true
+					document.viewBox = viewBox(attrs)
+				}
+			case "g":
+			case "defs", "clipPath", "mask", "symbol", "linearGradient", "radialGradient", "pattern", "style", "title", "desc":
+				// Not drawn directly.
+				if err := decoder.Skip(); err != nil {
+					return nil, err
+				}
+				continue
+			default:
+				path, err := shapePath(name, attrs)
+				if err != nil {
+					return nil, err
+				}
+				if path != nil && !path.IsEmpty() {
+					t := current.transform
+					document.elements = append(document.elements, &Element{
+						fill:        opaque(current.fill, current.opacity),
+						path:        path.Transform(t.translateX, t.translateY, t.scaleX, t.scaleY),
+						stroke:      opaque(current.stroke, current.opacity),
+						strokeWidth: current.strokeWidth * (math.Abs(t.scaleX) + math.Abs(t.scaleY)) / 2,
+					})
+				}
+			}
+			stack = append(stack, current)
+		case xml.EndElement:
+			if len(stack) > 1 {
+				stack = stack[:len(stack)-1]
+			}
+		}
+	}
+	if !isRootFound {
+		return nil, fmt.Errorf("svg: missing <svg> element")
+	}
+	return document, nil
+}
+
+// opaque applies the inherited opacity to the paint.
+func opaque(p paint, opacity float64) paint {
+	p.color = withOpacity(p.color, opacity)
+	return p
+}
+
+func viewBox(attrs map[string]string) [4]float64 {
+	if values := numbers(attrs["viewBox"]); len(values) == 4 {
+		return [4]float64{values[0], values[1], values[2], values[3]}
+	}
+	return [4]float64{0, 0, length(attrs["width"]), length(attrs["height"])}
+}
@@ -0,0 +1,95 @@
+package svg_test
+
+import (
+	"testing"
+
+	"github.com/waybeams/assert"
+	"github.com/waybeams/waybeams/pkg/env/fake"
+	"github.com/waybeams/waybeams/pkg/svg"
+	"github.com/waybeams/waybeams/pkg/vector"
+)
+
+const icon = `<?xml version="1.0"?>
+<svg xmlns="http://www.w3.org/2000/svg" width="24" height="24" viewBox="0 0 24 24">
+	<title>Sample</title>
+	<defs><linearGradient id="ignored"/></defs>
+	<g fill="#f00" transform="translate(2, 2)">
+		<rect x="0" y="0" width="10" height="10"/>
+		<circle cx="5" cy="5" r="2" fill="none" stroke="currentColor" stroke-width="2"/>
+	</g>
+	<path d="M0 0 L24 24" style="fill:none;stroke:#00ff00"/>
+	<polygon points="0,0 4,0 4,4" opacity="0.5"/>
+</svg>`
+
+func TestSvg(t *testing.T) {
+	t.Run("Parses supported elements", func(t *testing.T) {
+		doc, err := svg.Parse([]byte(icon))
+		assert.Nil(err)
+		x, y, w, h := doc.ViewBox()
+		assert.Equal([]float64{x, y, w, h}, []float64{0, 0, 24, 24})
+		assert.Equal(len(doc.Elements()), 4)
+
+		rect := doc.Elements()[0].Path().Commands()
+		assert.Equal(rect[0].Type, vector.MoveToCommand)
+		assert.Equal(rect[0].Args, []float64{2, 2}, "Group transform is applied")
+		circle := doc.Elements()[1].Path().Commands()
+		assert.Equal(circle[0].Args, []float64{7, 7, 2, 2})
+	})
+
+	t.Run("Falls back to width and height", func(t *testing.T) {
+		doc, err := svg.Parse([]byte(`<svg width="16px" height="8"><rect width="1" height="1"/></svg>`))
+		assert.Nil(err)
+		_, _, w, h := doc.ViewBox()
+		assert.Equal([]float64{w, h}, []float64{16, 8})
+	})
+
+	t.Run("Draws with declared colors", func(t *testing.T) {
+		doc, _ := svg.Parse([]byte(icon))
+		s := fake.NewSurface()
+		doc.Draw(s, 0, 0, 48, 48)
+
+		fills := s.CommandsNamed("SetFillColor")
+		assert.Equal(fills[0].Args[0], uint(0xff0000ff))
+		assert.Equal(fills[1].Args[0], uint(0x00000080), "Opacity applies to alpha")
+		strokes := s.CommandsNamed("SetStrokeColor")
+		assert.Equal(strokes[0].Args[0], uint(0x000000ff), "currentColor defaults to black")
+		assert.Equal(strokes[1].Args[0], uint(0x00ff00ff))
+		assert.Equal(s.CommandsNamed("SetLineWidth")[0].Args[0], 4.0, "Stroke width is scaled")
+		assert.Equal(s.CommandsNamed("MoveTo")[0].Args, []interface{}{4.0, 4.0})
+	})
+
+	t.Run("Tints every color", func(t *testing.T) {
+		doc, _ := svg.Parse([]byte(icon))
+		s := fake.NewSurface()
+		doc.DrawTinted(s, 0, 0, 24, 24, 0x336699ff)
+
+		fills := s.CommandsNamed("SetFillColor")
+		assert.Equal(fills[0].Args[0], uint(0x336699ff))
+		assert.Equal(fills[1].Args[0], uint(0x33669980))
+		for _, command := range s.CommandsNamed("SetStrokeColor") {
+			assert.Equal(command.Args[0], uint(0x336699ff))
+		}
+	})
+
+	t.Run("Returns errors", func(t *testing.T) {
+		_, err := svg.Parse([]byte(`<svg><path d="M0 0 L"/></svg>`))
+		assert.NotNil(err)
+		_, err = svg.Parse([]byte(`<svg><g transform="rotate(45)"/></svg>`))
+		assert.NotNil(err)
+		_, err = svg.Parse([]byte(`<html/>`))
+		assert.NotNil(err)
+		_, err = svg.Parse([]byte(`<svg>`))
+		assert.NotNil(err)
+	})
+
+	t.Run("Cache parses once per name", func(t *testing.T) {
+		cache := svg.NewCache()
+		first, err := cache.LoadBytes("icon", []byte(icon))
+		assert.Nil(err)
+		second, _ := cache.LoadBytes("icon", []byte(`<svg/>`))
+		assert.Equal(first, second)
+
+		_, err = cache.Load("/does/not/exist.svg")
+		assert.NotNil(err)
+	})
+}
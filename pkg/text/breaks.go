@@ -0,0 +1,85 @@
+package text
+
+import (
+	"github.com/go-text/typesetting/segmenter"
+)
+
+// Break is a line break opportunity, as defined by UAX #14.
+type Break struct {
+	// Index is the byte offset of the first character after the break.
+	Index int
+	// Mandatory is true for explicit breaks, like newlines.
+	Mandatory bool
+}
+
+// runeOffsets returns the byte offset of each rune in value, followed by
+// len(value).
+func runeOffsets(value string) []int {
+	offsets := make([]int, 0, len(value)+1)
+	for index := range value {
+		offsets = append(offsets, index)
+	}
+	return append(offsets, len(value))
+}
+
+// Graphemes returns the byte offsets of the boundaries between user perceived
+// characters (UAX #29 grapheme clusters) in value, including zero and
+// len(value).
+func Graphemes(value string) []int {
+	if value == "" {
+		return []int{0}
+	}
+	offsets := runeOffsets(value)
+	var seg segmenter.Segmenter
+	seg.Init([]rune(value))
+	iter := seg.GraphemeIterator()
+	result := []int{}
+	for iter.Next() {
+		result = append(result, offsets[iter.Grapheme().Offset])
+	}
+	return append(result, len(value))
+}
+
+// NextGrapheme returns the byte offset of the grapheme boundary that follows
+// index, or len(value) if there is none.
+func NextGrapheme(value string, index int) int {
+	for _, boundary := range Graphemes(value) {
+		if boundary > index {
+			return boundary
+		}
+	}
+	return len(value)
+}
+
+// PreviousGrapheme returns the byte offset of the grapheme boundary that
+// precedes index, or zero if there is none.
+func PreviousGrapheme(value string, index int) int {
+	boundaries := Graphemes(value)
+	for i := len(boundaries) - 1; i >= 0; i-- {
+		if boundaries[i] < index {
+			return boundaries[i]
+		}
+	}
+	return 0
+}
+
+// LineBreaks returns each line break opportunity in value, excluding the
+// start of the string. The end of the string is always the final Break.
+func LineBreaks(value string) []Break {
+	if value == "" {
+		return nil
+	}
+	offsets := runeOffsets(value)
+	var seg segmenter.Segmenter
+	seg.Init([]rune(value))
+	iter := seg.LineIterator()
+	result := []Break{}
+	for iter.Next() {
+		line := iter.Line()
+		result = append(result, Break{
+			Index:     offsets[line.Offset+len(line.Text)],
+			Mandatory: line.IsMandatoryBreak,
+		})
+	}
+	return result
+}
@@ -0,0 +1,104 @@
+package text_test
+
+import (
+	"testing"
+
+	"github.com/waybeams/assert"
+	"github.com/waybeams/waybeams/pkg/text"
+)
+
+func TestGraphemes(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		assert.Equal(text.Graphemes(""), []int{0})
+	})
+
+	t.Run("Ascii", func(t *testing.T) {
+		assert.Equal(text.Graphemes("abc"), []int{0, 1, 2, 3})
+	})
+
+	t.Run("Combining marks", func(t *testing.T) {
+		// "e" followed by a combining acute accent is one grapheme.
+		assert.Equal(text.Graphemes("éx"), []int{0, 3, 4})
+	})
+
+	t.Run("Emoji sequences", func(t *testing.T) {
+		family := "\U0001F468‍\U0001F469‍\U0001F467"
+		flag := "\U0001F1EF\U0001F1F5"
+		value := "a" + family + flag
+		assert.Equal(text.Graphemes(value), []int{0, 1, 1 + len(family), len(value)})
+	})
+
+	t.Run("Next and previous", func(t *testing.T) {
+		value := "aéb"
+		assert.Equal(text.NextGrapheme(value, 1), 4)
+		assert.Equal(text.NextGrapheme(value, 5), 5)
+		assert.Equal(text.PreviousGrapheme(value, 4), 1)
+		assert.Equal(text.PreviousGrapheme(value, 0), 0)
+	})
+}
+
+func TestLineBreaks(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		assert.Equal(len(text.LineBreaks("")), 0)
+	})
+
+	t.Run("Breaks after spaces", func(t *testing.T) {
+		breaks := text.LineBreaks("Hello big world")
+		assert.Equal(len(breaks), 3)
+		assert.Equal(breaks[0].Index, 6)
+		assert.Equal(breaks[1].Index, 10)
+		assert.Equal(breaks[2].Index, 15)
+		assert.False(breaks[0].Mandatory)
+	})
+
+	t.Run("Newlines are mandatory", func(t *testing.T) {
+		breaks := text.LineBreaks("one\ntwo")
+		assert.Equal(breaks[0].Index, 4)
+		assert.True(breaks[0].Mandatory)
+	})
+
+	t.Run("Does not break before punctuation", func(t *testing.T) {
+		breaks := text.LineBreaks("Hello, world!")
+		assert.Equal(len(breaks), 2)
+		assert.Equal(breaks[0].Index, 7)
+	})
+}
+
+func TestDirection(t *testing.T) {
+	t.Run("BaseDirection", func(t *testing.T) {
+		assert.Equal(text.BaseDirection("abc"), text.LeftToRight)
+		assert.Equal(text.BaseDirection("123 שלום abc"), text.RightToLeft)
+		assert.Equal(text.BaseDirection("مرحبا"), text.RightToLeft)
+		assert.Equal(text.BaseDirection("123"), text.LeftToRight)
+	})
+
+	t.Run("NeedsShaping", func(t *testing.T) {
+		assert.False(text.NeedsShaping("Hello World"))
+		assert.False(text.NeedsShaping("Grüße, Ελληνικά, Кириллица"))
+		assert.True(text.NeedsShaping("שלום"))
+		assert.True(text.NeedsShaping("नमस्ते"))
+		assert.True(text.NeedsShaping("é"))
+		assert.True(text.NeedsShaping("\U0001F600"))
+	})
+}
+
+func TestSanitize(t *testing.T) {
+	t.Run("Keeps clean text", func(t *testing.T) {
+		assert.Equal(text.Sanitize("Hello\tWorld\nשלום"), "Hello\tWorld\nשלום")
+		assert.Equal(text.StripControl("Hello World"), "Hello World")
+	})
+
+	t.Run("Removes control characters", func(t *testing.T) {
+		assert.Equal(text.Sanitize("a\x00b\x1bc\x7fd\u0085e"), "abcde")
+		assert.Equal(text.StripControl("a\tb\nc\x00d"), "abcd")
+	})
+
+	t.Run("Normalizes line endings", func(t *testing.T) {
+		assert.Equal(text.Sanitize("a\r\nb\rc"), "a\nb\nc")
+	})
+
+	t.Run("Replaces invalid UTF-8", func(t *testing.T) {
+		assert.Equal(text.Sanitize("a\xffb"), "a�b")
+		assert.Equal(text.StripControl("a\xffb"), "a�b")
+	})
+}
@@ -0,0 +1,80 @@
+package text
+
+import (
+	"unicode"
+
+	"golang.org/x/text/unicode/bidi"
+)
+
+// BaseDirection returns the paragraph direction of value, which is the
+// direction of its first strongly directional character (UAX #9 rules P2 and
+// P3), or LeftToRight if there is none.
+func BaseDirection(value string) Direction {
+	for len(value) > 0 {
+		properties, size := bidi.LookupString(value)
+		switch properties.Class() {
+		case bidi.L:
+			return LeftToRight
+		case bidi.R, bidi.AL:
+			return RightToLeft
+		}
+		value = value[size:]
+	}
+	return LeftToRight
+}
+
+// complexScripts require contextual glyph substitution or reordering.
+var complexScripts = []*unicode.RangeTable{
+	unicode.Arabic,
+	unicode.Bengali,
+	unicode.Devanagari,
+	unicode.Gujarati,
+	unicode.Gurmukhi,
+	unicode.Hangul,
+	unicode.Hebrew,
+	unicode.Kannada,
+	unicode.Khmer,
+	unicode.Lao,
+	unicode.Malayalam,
+	unicode.Mongolian,
+	unicode.Myanmar,
+	unicode.Nko,
+	unicode.Oriya,
+	unicode.Sinhala,
+	unicode.Syriac,
+	unicode.Tamil,
+	unicode.Telugu,
+	unicode.Thaana,
+	unicode.Thai,
+	unicode.Tibetan,
+}
+
+// NeedsShaping returns true if value cannot be measured and drawn correctly
+// one character at a time, because it contains right to left or complex
+// scripts, combining marks, joiners, variation selectors or emoji.
+func NeedsShaping(value string) bool {
+	for _, r := range value {
+		switch {
+		case r < 0x0300:
+			continue
+		case r == 0x200C || r == 0x200D:
+			// Zero width non-joiner and joiner.
+			return true
+		case r >= 0xFE00 && r <= 0xFE0F:
+			// Variation selectors.
+			return true
+		case r >= 0x1F000:
+			// Emoji, regional indicators and supplementary planes.
+			return true
+		case unicode.In(r, unicode.Mn, unicode.Mc, unicode.Me):
+			return true
+		case unicode.In(r, complexScripts...):
+			return true
+		}
+		properties, _ := bidi.LookupRune(r)
+		if class := properties.Class(); class == bidi.R || class == bidi.AL || class == bidi.AN {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,46 @@
+package text
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Sanitize removes control characters other than newlines and tabs,
+// converts "\r\n" and "\r" line endings to "\n" and replaces invalid UTF-8
+// with U+FFFD.
+func Sanitize(value string) string {
+	value = strings.Replace(value, "\r\n", "\n", -1)
+	value = strings.Replace(value, "\r", "\n", -1)
+	return strip(value, func(r rune) bool {
+		return r != '\n' && r != '\t' && unicode.IsControl(r)
+	})
+}
+
+// StripControl removes every control character, including newlines and
+// tabs, and replaces invalid UTF-8 with U+FFFD. It is intended for single
+// line input.
+func StripControl(value string) string {
+	return strip(value, unicode.IsControl)
+}
+
+func strip(value string, isRemoved func(r rune) bool) string {
+	isClean := utf8.ValidString(value)
+	for _, r := range value {
+		if !isClean || isRemoved(r) {
+			isClean = false
+			break
+		}
+	}
+	if isClean {
+		return value
+	}
+
+	var result strings.Builder
+	for _, r := range value {
+		if !isRemoved(r) {
+			result.WriteRune(r)
+		}
+	}
+	return result.String()
+}
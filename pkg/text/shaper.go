@@ -0,0 +1,401 @@
+package text
+
+import (
+	"bytes"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/go-text/typesetting/di"
+	"github.com/go-text/typesetting/font/opentype"
+	"github.com/go-text/typesetting/shaping"
+	"github.com/waybeams/waybeams/pkg/font"
+	"golang.org/x/image/math/fixed"
+
+	gofont "github.com/go-text/typesetting/font"
+)
+
+// Option configures a Shaper.
+type Option func(s *harfBuzzShaper)
+
+// PixelHeight configures the Shaper to treat sizes as the distance from the
+// ascender to the descender of a face, which is how NanoVG sizes text, rather
+// than as the em size that CSS uses.
+func PixelHeight() Option {
+	return func(s *harfBuzzShaper) {
+		s.pixelHeight = true
+	}
+}
+
+// harfBuzzShaper shapes text with a pure Go port of HarfBuzz, using the font
+// data of the faces in a font Registry.
+type harfBuzzShaper struct {
+	faces       map[string]*gofont.Face
+	mutex       sync.Mutex
+	names       map[*gofont.Face]string
+	pixelHeight bool
+	registry    *font.Registry
+	segmenter   shaping.Segmenter
+	shaper      shaping.HarfbuzzShaper
+	wrapper     shaping.LineWrapper
+}
+
+// fontmap selects the first face that has a glyph for each rune.
+type fontmap []*gofont.Face
+
+func (f fontmap) ResolveFace(r rune) *gofont.Face {
+	for _, face := range f {
+		if _, ok := face.NominalGlyph(r); ok {
+			return face
+		}
+	}
+	return f[0]
+}
+
+// parse returns the parsed face for the provided Registry face, or nil if it
+// has no data or cannot be parsed.
+func (s *harfBuzzShaper) parse(face *font.Face) *gofont.Face {
+	parsed, ok := s.faces[face.Name]
+	if !ok {
+		if face.Data != nil {
+			parsed, _ = gofont.ParseTTF(bytes.NewReader(face.Data))
+		}
+		// Store failures too, so that invalid data is only parsed once.
+		s.faces[face.Name] = parsed
+		if parsed != nil {
+			s.names[parsed] = face.Name
+		}
+	}
+	return parsed
+}
+
+// fontmapFor returns the named face followed by its fallbacks.
+func (s *harfBuzzShaper) fontmapFor(name string) fontmap {
+	face, ok := s.registry.Named(name)
+	if !ok {
+		return nil
+	}
+	primary := s.parse(face)
+	if primary == nil {
+		return nil
+	}
+	result := fontmap{primary}
+	for _, fallback := range s.registry.FallbackFaces(face) {
+		if parsed := s.parse(fallback); parsed != nil {
+			result = append(result, parsed)
+		}
+	}
+	return result
+}
+
+// emSize returns the em size in pixels of the provided face at size.
+func (s *harfBuzzShaper) emSize(face *gofont.Face, size float64) float64 {
+	if s.pixelHeight {
+		extents, ok := face.FontHExtents()
+		if height := float64(extents.Ascender - extents.Descender); ok && height > 0 {
+			return size * float64(face.Upem()) / height
+		}
+	}
+	return size
+}
+
+func (s *harfBuzzShaper) Shape(face string, size float64, value string) Line {
+	lines := s.layout(face, size, value, math.MaxInt32)
+	if len(lines) == 0 {
+		return Line{}
+	}
+	line := lines[0]
+	for _, next := range lines[1:] {
+		for _, run := range next.Runs {
+			run.X += line.Width
+			line.Runs = append(line.Runs, run)
+		}
+		line.Width += next.Width
+		line.End = next.End
+	}
+	return line
+}
+
+func (s *harfBuzzShaper) Wrap(face string, size float64, value string, width float64) []Line {
+	if width <= 0 {
+		width = math.MaxInt32
+	}
+	return s.layout(face, size, value, width)
+}
+
+// layout shapes and wraps each paragraph of value separately, because the
+// paragraphs may have different base directions.
+func (s *harfBuzzShaper) layout(name string, size float64, value string, width float64) []Line {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	faces := s.fontmapFor(name)
+	if len(faces) == 0 {
+		return nil
+	}
+	result := []Line{}
+	for _, paragraph := range paragraphs(value) {
+		lines := s.layoutParagraph(faces, size, value[paragraph[0]:paragraph[1]], width)
+		for _, line := range lines {
+			result = append(result, line.offset(paragraph[0]))
+		}
+	}
+	return result
+}
+
+// paragraphs returns the start and end byte offsets of each paragraph of
+// value, excluding the explicit line breaks that separate them.
+func paragraphs(value string) [][2]int {
+	result := [][2]int{}
+	start := 0
+	for _, lineBreak := range LineBreaks(value) {
+		if lineBreak.Mandatory && lineBreak.Index < len(value) {
+			end := strings.TrimRightFunc(value[:lineBreak.Index], isLineBreak)
+			result = append(result, [2]int{start, len(end)})
+			start = lineBreak.Index
+		}
+	}
+	end := strings.TrimRightFunc(value, isLineBreak)
+	return append(result, [2]int{start, int(math.Max(float64(start), float64(len(end))))})
+}
+
+func isLineBreak(r rune) bool {
+	switch r {
+	case '\n', '\v', '\f', '\r', 0x85, 0x2028, 0x2029:
+		return true
+	}
+	return false
+}
+
+// offset returns the Line with every byte offset moved forward by start.
+func (l Line) offset(start int) Line {
+	l.Start += start
+	l.End += start
+	runs := make([]Run, len(l.Runs))
+	for index, run := range l.Runs {
+		run.Start += start
+		run.End += start
+		glyphs := make([]Glyph, len(run.Glyphs))
+		for glyphIndex, glyph := range run.Glyphs {
+			glyph.Cluster += start
+			glyphs[glyphIndex] = glyph
+		}
+		run.Glyphs = glyphs
+		runs[index] = run
+	}
+	l.Runs = runs
+	return l
+}
+
+// layoutParagraph shapes value at a scale where the em size of the primary
+// face is its units per em, which keeps the fixed point values that HarfBuzz
+// works with precise, and then converts the wrapped lines to pixels.
+func (s *harfBuzzShaper) layoutParagraph(faces fontmap, size float64, value string, width float64) []Line {
+	primary := faces[0]
+	em := s.emSize(primary, size)
+	if em <= 0 {
+		return nil
+	}
+	scale := float64(primary.Upem()) / em
+
+	direction := BaseDirection(value)
+	empty := s.emptyLine(primary, em, direction)
+	runes := []rune(value)
+	if len(runes) == 0 {
+		return []Line{empty}
+	}
+
+	dir := di.DirectionLTR
+	if direction == RightToLeft {
+		dir = di.DirectionRTL
+	}
+	inputs := s.segmenter.Split(shaping.Input{
+		Direction: dir,
+		Face:      primary,
+		RunEnd:    len(runes),
+		Text:      runes,
+	}, faces)
+	outputs := make([]shaping.Output, len(inputs))
+	for index, input := range inputs {
+		input.Size = toFixed(s.emSize(input.Face, size) * scale)
+		outputs[index] = s.shaper.Shape(input)
+	}
+
+	config := shaping.WrapConfig{Direction: dir}
+	maxWidth := int(math.Min(width*scale, math.MaxInt32))
+	wrapped, _ := s.wrapper.WrapParagraph(config, maxWidth, runes, shaping.NewSliceIterator(outputs))
+
+	offsets := runeOffsets(value)
+	result := make([]Line, 0, len(wrapped))
+	for _, outputs := range wrapped {
+		result = append(result, s.convert(empty, outputs, offsets, value, scale))
+	}
+	return result
+}
+
+// emptyLine returns a Line with the vertical metrics of the provided face.
+func (s *harfBuzzShaper) emptyLine(face *gofont.Face, em float64, direction Direction) Line {
+	extents, _ := face.FontHExtents()
+	unit := em / float64(face.Upem())
+	line := Line{
+		Ascent:    float64(extents.Ascender) * unit,
+		Descent:   -float64(extents.Descender) * unit,
+		Direction: direction,
+	}
+	line.Height = line.Ascent + line.Descent + float64(extents.LineGap)*unit
+	return line
+}
+
+// convert orders the outputs of a wrapped line visually and converts them to
+// pixels.
+func (s *harfBuzzShaper) convert(line Line, outputs shaping.Line, offsets []int, value string, scale float64) Line {
+	ordered := make([]shaping.Output, len(outputs))
+	copy(ordered, outputs)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].VisualIndex < ordered[j].VisualIndex
+	})
+
+	line.Start = len(value)
+	for _, output := range ordered {
+		run := Run{
+			Advance:   fromFixed(output.Advance) / scale,
+			Direction: LeftToRight,
+			Face:      s.names[output.Face],
+			Size:      fromFixed(output.Size) / scale,
+			Start:     offsets[output.Runes.Offset],
+			End:       offsets[output.Runes.Offset+output.Runes.Count],
+			X:         line.Width,
+		}
+		if output.Direction.Progression() == di.TowardTopLeft {
+			run.Direction = RightToLeft
+		}
+		run.Text = value[run.Start:run.End]
+
+		pen := 0.0
+		for _, glyph := range output.Glyphs {
+			advance := fromFixed(glyph.XAdvance) / scale
+			run.Glyphs = append(run.Glyphs, Glyph{
+				Advance: advance,
+				Cluster: offsets[glyph.ClusterIndex],
+				ID:      uint32(glyph.GlyphID),
+				X:       pen + fromFixed(glyph.XOffset)/scale,
+				Y:       -fromFixed(glyph.YOffset) / scale,
+			})
+			pen += advance
+		}
+
+		line.Runs = append(line.Runs, run)
+		line.Width += run.Advance
+		if run.Start < line.Start {
+			line.Start = run.Start
+		}
+		if run.End > line.End {
+			line.End = run.End
+		}
+	}
+	return line
+}
+
+func (s *harfBuzzShaper) Draw(d Drawer, x, y float64, line Line) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, run := range line.Runs {
+		face := s.faces[run.Face]
+		if face == nil {
+			continue
+		}
+		unit := run.Size / float64(face.Upem())
+		for _, glyph := range run.Glyphs {
+			outline, ok := face.GlyphData(gofont.GID(glyph.ID)).(gofont.GlyphOutline)
+			if ok {
+				drawOutline(d, x+run.X+glyph.X, y+glyph.Y, unit, outline.Segments)
+			}
+		}
+	}
+}
+
+// drawOutline traces each contour of a glyph outline, which is in font units
+// with Y growing upward. Contours that wind against the largest contour are
+// reported as holes.
+func drawOutline(d Drawer, x, y, unit float64, segments []gofont.Segment) {
+	contours := [][]gofont.Segment{}
+	for _, segment := range segments {
+		if segment.Op == opentype.SegmentOpMoveTo || len(contours) == 0 {
+			contours = append(contours, nil)
+		}
+		contours[len(contours)-1] = append(contours[len(contours)-1], segment)
+	}
+
+	areas := make([]float64, len(contours))
+	largest := 0
+	for index, contour := range contours {
+		areas[index] = signedArea(contour)
+		if math.Abs(areas[index]) > math.Abs(areas[largest]) {
+			largest = index
+		}
+	}
+
+	holes, _ := d.(HoleDrawer)
+	for index, contour := range contours {
+		for _, segment := range contour {
+			args := segment.ArgsSlice()
+			px := func(i int) float64 { return x + float64(args[i].X)*unit }
+			py := func(i int) float64 { return y - float64(args[i].Y)*unit }
+			switch segment.Op {
+			case opentype.SegmentOpMoveTo:
+				d.MoveTo(px(0), py(0))
+			case opentype.SegmentOpLineTo:
+				d.LineTo(px(0), py(0))
+			case opentype.SegmentOpQuadTo:
+				d.QuadTo(px(0), py(0), px(1), py(1))
+			case opentype.SegmentOpCubeTo:
+				d.BezierTo(px(0), py(0), px(1), py(1), px(2), py(2))
+			}
+		}
+		d.ClosePath()
+		if holes != nil && index != largest && (areas[index] < 0) != (areas[largest] < 0) {
+			holes.Hole()
+		}
+	}
+}
+
+// signedArea returns the area of the polygon that connects the end points of
+// each segment, which is positive for counter-clockwise contours.
+func signedArea(contour []gofont.Segment) float64 {
+	points := []gofont.SegmentPoint{}
+	for _, segment := range contour {
+		args := segment.ArgsSlice()
+		points = append(points, args[len(args)-1])
+	}
+	area := 0.0
+	for index, point := range points {
+		next := points[(index+1)%len(points)]
+		area += float64(point.X)*float64(next.Y) - float64(next.X)*float64(point.Y)
+	}
+	return area / 2
+}
+
+func toFixed(value float64) fixed.Int26_6 {
+	return fixed.Int26_6(math.Round(value * 64))
+}
+
+func fromFixed(value fixed.Int26_6) float64 {
+	return float64(value) / 64
+}
+
+// NewShaper returns a Shaper that uses the faces of the provided Registry.
+// Faces that were added by URL (and have no data) cannot be shaped, and
+// produce empty Lines.
+func NewShaper(registry *font.Registry, options ...Option) Shaper {
+	s := &harfBuzzShaper{
+		faces:    map[string]*gofont.Face{},
+		names:    map[*gofont.Face]string{},
+		registry: registry,
+	}
+	for _, option := range options {
+		option(s)
+	}
+	return s
+}
@@ -0,0 +1,169 @@
+package text_test
+
+import (
+	"testing"
+
+	"github.com/waybeams/assert"
+	"github.com/waybeams/waybeams/pkg/font"
+	"github.com/waybeams/waybeams/pkg/text"
+	roboto "github.com/waybeams/waybeams/third_party/fonts/Roboto"
+)
+
+type fakeDrawer struct {
+	commands []string
+}
+
+func (d *fakeDrawer) BezierTo(c1x, c1y, c2x, c2y, x, y float64) {
+	d.commands = append(d.commands, "BezierTo")
+}
+func (d *fakeDrawer) ClosePath()                  { d.commands = append(d.commands, "ClosePath") }
+func (d *fakeDrawer) Hole()                       { d.commands = append(d.commands, "Hole") }
+func (d *fakeDrawer) LineTo(x, y float64)         { d.commands = append(d.commands, "LineTo") }
+func (d *fakeDrawer) MoveTo(x, y float64)         { d.commands = append(d.commands, "MoveTo") }
+func (d *fakeDrawer) QuadTo(cx, cy, x, y float64) { d.commands = append(d.commands, "QuadTo") }
+
+func (d *fakeDrawer) count(name string) int {
+	result := 0
+	for _, command := range d.commands {
+		if command == name {
+			result++
+		}
+	}
+	return result
+}
+
+func TestShaper(t *testing.T) {
+	shaper := text.NewShaper(roboto.NewRegistry())
+
+	t.Run("Shape", func(t *testing.T) {
+		line := shaper.Shape("Roboto", 20, "Hello World")
+		assert.Equal(len(line.Runs), 1)
+		assert.Equal(len(line.Runs[0].Glyphs), 11)
+		assert.Equal(line.Runs[0].Face, "Roboto")
+		assert.Equal(line.Runs[0].Text, "Hello World")
+		assert.True(line.Width > 100 && line.Width < 120)
+		assert.Equal(line.Start, 0)
+		assert.Equal(line.End, 11)
+	})
+
+	t.Run("Vertical metrics", func(t *testing.T) {
+		line := shaper.Shape("Roboto", 20, "Hello")
+		assert.True(line.Ascent > 0)
+		assert.True(line.Descent > 0)
+		assert.Equal(line.Height, line.Ascent+line.Descent)
+	})
+
+	t.Run("PixelHeight", func(t *testing.T) {
+		line := text.NewShaper(roboto.NewRegistry(), text.PixelHeight()).Shape("Roboto", 24, "Hello")
+		assert.Equal(line.Height, 24.0)
+	})
+
+	t.Run("Empty text has metrics", func(t *testing.T) {
+		line := shaper.Shape("Roboto", 20, "")
+		assert.Equal(len(line.Runs), 0)
+		assert.True(line.Height > 0)
+	})
+
+	t.Run("Unknown faces use the registry", func(t *testing.T) {
+		line := shaper.Shape("Roboto-700", 20, "abc")
+		assert.Equal(line.Runs[0].Face, "Roboto-700")
+		line = shaper.Shape("Helvetica", 20, "abc")
+		assert.Equal(line.Runs[0].Face, "Roboto")
+	})
+
+	t.Run("Faces without data are empty", func(t *testing.T) {
+		registry := font.NewRegistry()
+		registry.AddURL("Remote", font.Regular, font.Normal, "/fonts/remote.ttf")
+		line := text.NewShaper(registry).Shape("Remote", 20, "abc")
+		assert.Equal(line.Height, 0.0)
+	})
+
+	t.Run("Combining marks share a cluster", func(t *testing.T) {
+		line := shaper.Shape("Roboto", 20, "éx")
+		glyphs := line.Runs[0].Glyphs
+		assert.Equal(glyphs[len(glyphs)-1].Cluster, 3)
+		for _, glyph := range glyphs[:len(glyphs)-1] {
+			assert.Equal(glyph.Cluster, 0)
+		}
+	})
+
+	t.Run("Kerning", func(t *testing.T) {
+		a := shaper.Shape("Roboto", 48, "A").Width
+		v := shaper.Shape("Roboto", 48, "V").Width
+		assert.True(shaper.Shape("Roboto", 48, "AV").Width < a+v)
+	})
+
+	t.Run("Bidi", func(t *testing.T) {
+		t.Run("Orders runs visually", func(t *testing.T) {
+			line := shaper.Shape("Roboto", 20, "abc שלום def")
+			assert.Equal(line.Direction, text.LeftToRight)
+			assert.Equal(len(line.Runs), 3)
+			assert.Equal(line.Runs[0].Text, "abc ")
+			assert.Equal(line.Runs[1].Direction, text.RightToLeft)
+			assert.Equal(line.Runs[2].Text, " def")
+			assert.Equal(line.Runs[1].X, line.Runs[0].Advance)
+		})
+
+		t.Run("Right to left paragraphs", func(t *testing.T) {
+			line := shaper.Shape("Roboto", 20, "שלום abc")
+			assert.Equal(line.Direction, text.RightToLeft)
+			// The left to right run is visually first.
+			assert.Equal(line.Runs[0].Text, "abc")
+			assert.Equal(line.Runs[0].Direction, text.LeftToRight)
+			assert.Equal(line.Runs[1].Direction, text.RightToLeft)
+		})
+	})
+
+	t.Run("Wrap", func(t *testing.T) {
+		value := "The quick brown fox jumps over the lazy dog"
+
+		t.Run("Fits within width", func(t *testing.T) {
+			lines := shaper.Wrap("Roboto", 20, value, 150)
+			assert.True(len(lines) > 1)
+			for _, line := range lines {
+				assert.True(line.Width <= 150)
+			}
+			assert.Equal(lines[0].Start, 0)
+			assert.Equal(lines[len(lines)-1].End, len(value))
+			for index := 1; index < len(lines); index++ {
+				assert.Equal(lines[index].Start, lines[index-1].End)
+				assert.Equal(value[lines[index].Start-1:lines[index].Start], " ")
+			}
+		})
+
+		t.Run("Zero width only breaks at newlines", func(t *testing.T) {
+			assert.Equal(len(shaper.Wrap("Roboto", 20, value, 0)), 1)
+			assert.Equal(len(shaper.Wrap("Roboto", 20, "one\ntwo", 0)), 2)
+		})
+
+		t.Run("Breaks words only when necessary", func(t *testing.T) {
+			word := shaper.Shape("Roboto", 20, "Extraordinarily").Width
+			lines := shaper.Wrap("Roboto", 20, "An Extraordinarily", word+1)
+			assert.Equal(len(lines), 2)
+			assert.True(len(shaper.Wrap("Roboto", 20, "Extraordinarily", word/2)) > 1)
+		})
+	})
+
+	t.Run("Draw", func(t *testing.T) {
+		t.Run("Traces outlines", func(t *testing.T) {
+			drawer := &fakeDrawer{}
+			shaper.Draw(drawer, 10, 20, shaper.Shape("Roboto", 20, "l"))
+			assert.Equal(drawer.count("MoveTo"), 1)
+			assert.Equal(drawer.count("ClosePath"), 1)
+			assert.Equal(drawer.count("Hole"), 0)
+		})
+
+		t.Run("Marks holes", func(t *testing.T) {
+			drawer := &fakeDrawer{}
+			shaper.Draw(drawer, 0, 0, shaper.Shape("Roboto", 20, "o"))
+			assert.Equal(drawer.count("MoveTo"), 2)
+			assert.Equal(drawer.count("Hole"), 1)
+		})
+
+		t.Run("Skips spaces", func(t *testing.T) {
+			drawer := &fakeDrawer{}
+			shaper.Draw(drawer, 0, 0, shaper.Shape("Roboto", 20, " "))
+			assert.Equal(len(drawer.commands), 0)
+		})
+	})
+}
@@ -0,0 +1,92 @@
+// Package text shapes strings into positioned glyph runs, orders
+// bidirectional text for display and finds grapheme and line break
+// boundaries, so that scripts like Arabic, Hebrew and Devanagari, emoji and
+// combining marks can be measured and drawn correctly.
+package text
+
+// Direction is the writing direction of a paragraph or a Run.
+type Direction int
+
+const (
+	LeftToRight = Direction(iota)
+	RightToLeft
+)
+
+// Glyph is a single shaped glyph, positioned relative to the origin of the
+// Run that contains it. Y grows downward from the baseline.
+type Glyph struct {
+	Advance float64
+	// Cluster is the byte offset of the first character that produced the
+	// glyph.
+	Cluster int
+	ID      uint32
+	X       float64
+	Y       float64
+}
+
+// Run is a sequence of glyphs that were shaped with one face in one
+// direction. Glyphs are always in visual (left to right) order.
+type Run struct {
+	Advance   float64
+	Direction Direction
+	// Face is the font Registry name of the face used for the Run.
+	Face   string
+	Glyphs []Glyph
+	// Size is the em size of the Run in pixels.
+	Size float64
+	// Start and End are the byte offsets of the Run in the shaped string.
+	Start int
+	End   int
+	Text  string
+	// X is the offset of the Run from the start of its Line.
+	X float64
+}
+
+// Line is a single line of shaped text, with Runs in visual order.
+type Line struct {
+	Ascent float64
+	// Descent is the distance below the baseline, as a positive value.
+	Descent float64
+	// Direction is the base direction of the paragraph.
+	Direction Direction
+	End       int
+	Height    float64
+	Runs      []Run
+	Start     int
+	Width     float64
+}
+
+// Drawer receives glyph outlines as paths. Every spec.Surface is a Drawer.
+type Drawer interface {
+	BezierTo(c1x, c1y, c2x, c2y, x, y float64)
+	ClosePath()
+	LineTo(x, y float64)
+	MoveTo(x, y float64)
+	QuadTo(cx, cy, x, y float64)
+}
+
+// HoleDrawer is a Drawer that must be told which contours cut holes in a
+// glyph (e.g., the inside of "o"), because it does not fill with the nonzero
+// rule. Hole is called after the hole contour is closed.
+type HoleDrawer interface {
+	Drawer
+	Hole()
+}
+
+// Shaper converts strings into shaped Lines.
+type Shaper interface {
+	// Draw traces the outlines of each glyph in the Line, with the start of
+	// the Line at x and its baseline at y.
+	Draw(d Drawer, x, y float64, line Line)
+
+	// Shape returns the provided value as a single Line (apart from explicit
+	// line breaks, which are ignored), using the named face and the
+	// Registry fallbacks for any missing glyphs.
+	Shape(face string, size float64, value string) Line
+
+	// Wrap breaks the provided value into Lines that fit within width, at
+	// the opportunities defined by Unicode line breaking (UAX #14). Words
+	// that are wider than width are only broken if necessary. A width of
+	// zero only breaks at explicit line breaks.
+	Wrap(face string, size float64, value string, width float64) []Line
+}
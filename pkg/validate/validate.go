@@ -0,0 +1,129 @@
+// Package validate provides the field Validators that ctrl.Form checks
+// before it emits Submitted.
+//
+// Messages are translated with i18n.T, where {0} refers to the limit of the
+// Validator (e.g., "Must be at least {0} characters"). An empty message
+// selects the default message of the Validator.
+//
+// Validators other than Required accept empty values, so that optional
+// fields are only validated once they have been filled in.
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/waybeams/waybeams/pkg/i18n"
+	"github.com/waybeams/waybeams/pkg/text"
+)
+
+const (
+	DefaultMaxLengthMessage = "Must be at most {0} characters"
+	DefaultMinLengthMessage = "Must be at least {0} characters"
+	DefaultPatternMessage   = "Is not in the expected format"
+	DefaultRequiredMessage  = "Is required"
+)
+
+// Validator returns an error with a user facing message if value is not
+// valid.
+type Validator func(value interface{}) error
+
+// All returns the first error of the provided validators, or nil if value
+// is valid.
+func All(value interface{}, validators ...Validator) error {
+	for _, validator := range validators {
+		if err := validator(value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Func returns a Validator that fails with message if isValid returns false
+// for a non-empty value.
+func Func(message string, isValid func(value interface{}) bool) Validator {
+	return func(value interface{}) error {
+		if isEmpty(value) || isValid(value) {
+			return nil
+		}
+		return failure(message, "")
+	}
+}
+
+// MaxLength returns a Validator that fails if the value has more than
+// length characters (grapheme clusters).
+func MaxLength(length int, message string) Validator {
+	return func(value interface{}) error {
+		if characterCount(value) <= length {
+			return nil
+		}
+		return failure(message, DefaultMaxLengthMessage, length)
+	}
+}
+
+// MinLength returns a Validator that fails if a non-empty value has fewer
+// than length characters (grapheme clusters).
+func MinLength(length int, message string) Validator {
+	return func(value interface{}) error {
+		if isEmpty(value) || characterCount(value) >= length {
+			return nil
+		}
+		return failure(message, DefaultMinLengthMessage, length)
+	}
+}
+
+// Pattern returns a Validator that fails if a non-empty value does not
+// match the regular expression. Pattern panics if the expression does not
+// compile.
+func Pattern(pattern string, message string) Validator {
+	expression := regexp.MustCompile(pattern)
+	return func(value interface{}) error {
+		if isEmpty(value) || expression.MatchString(String(value)) {
+			return nil
+		}
+		return failure(message, DefaultPatternMessage)
+	}
+}
+
+// Required returns a Validator that fails if the value is nil, false, or a
+// string of only whitespace.
+func Required(message string) Validator {
+	return func(value interface{}) error {
+		if !isEmpty(value) {
+			return nil
+		}
+		return failure(message, DefaultRequiredMessage)
+	}
+}
+
+// String returns the text of a field value.
+func String(value interface{}) string {
+	switch value := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+func characterCount(value interface{}) int {
+	return len(text.Graphemes(String(value))) - 1
+}
+
+func failure(message, defaultMessage string, args ...interface{}) error {
+	if message == "" {
+		message = defaultMessage
+	}
+	return errors.New(i18n.T(message, args...))
+}
+
+func isEmpty(value interface{}) bool {
+	if isChecked, ok := value.(bool); ok {
+		return !isChecked
+	}
+	return strings.TrimSpace(String(value)) == ""
+}
@@ -0,0 +1,71 @@
+package validate_test
+
+import (
+	"testing"
+
+	"github.com/waybeams/assert"
+	"github.com/waybeams/waybeams/pkg/i18n"
+	"github.com/waybeams/waybeams/pkg/validate"
+)
+
+func TestValidate(t *testing.T) {
+	t.Run("Required", func(t *testing.T) {
+		required := validate.Required("")
+		assert.Equal(required("").Error(), validate.DefaultRequiredMessage)
+		assert.NotNil(required("  \t"))
+		assert.NotNil(required(nil))
+		assert.NotNil(required(false))
+		assert.Nil(required("abcd"))
+		assert.Nil(required(true))
+		assert.Nil(required(0))
+		assert.Equal(validate.Required("Name is required")("").Error(), "Name is required")
+	})
+
+	t.Run("Length", func(t *testing.T) {
+		min := validate.MinLength(3, "")
+		assert.Nil(min(""), "Empty values are left to Required")
+		assert.Equal(min("ab").Error(), "Must be at least 3 characters")
+		assert.Nil(min("abc"))
+		assert.Nil(min("e\u0301e\u0301e\u0301"))
+
+		max := validate.MaxLength(3, "No more than {0}")
+		assert.Nil(max(""))
+		assert.Nil(max("e\u0301e\u0301e\u0301"))
+		assert.Equal(max("abcd").Error(), "No more than 3")
+	})
+
+	t.Run("Pattern", func(t *testing.T) {
+		zip := validate.Pattern(`^\d{5}$`, "")
+		assert.Nil(zip(""))
+		assert.Nil(zip("12345"))
+		assert.Nil(zip(12345))
+		assert.Equal(zip("1234").Error(), validate.DefaultPatternMessage)
+		assert.Panic("missing closing", func() {
+			validate.Pattern(`(abcd`, "")
+		})
+	})
+
+	t.Run("Func and All", func(t *testing.T) {
+		isEven := validate.Func("Must be even", func(value interface{}) bool {
+			return value.(int)%2 == 0
+		})
+		assert.Nil(isEven(2))
+		assert.Equal(isEven(3).Error(), "Must be even")
+
+		err := validate.All("", validate.Required("first"), validate.MinLength(3, "second"))
+		assert.Equal(err.Error(), "first")
+		err = validate.All("ab", validate.Required("first"), validate.MinLength(3, "second"))
+		assert.Equal(err.Error(), "second")
+		assert.Nil(validate.All("abc"))
+	})
+
+	t.Run("Messages are translated", func(t *testing.T) {
+		catalog := i18n.NewCatalog("fr")
+		catalog.Add(validate.DefaultMinLengthMessage, "Au moins {0} caractères")
+		i18n.AddCatalog(catalog)
+		i18n.SetLocale("fr")
+		defer i18n.SetLocale(i18n.DefaultLocale)
+
+		assert.Equal(validate.MinLength(4, "")("ab").Error(), "Au moins 4 caractères")
+	})
+}
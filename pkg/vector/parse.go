@@ -0,0 +1,329 @@
+package vector
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// scanner reads numbers and flags from SVG path data, which allows values
+// to be separated by whitespace, commas, signs or a second decimal point
+// (e.g., "M1.5.5-2e1").
+type scanner struct {
+	data string
+	pos  int
+}
+
+func (s *scanner) skipSeparators() {
+	for s.pos < len(s.data) {
+		switch s.data[s.pos] {
+		case ' ', '\t', '\r', '\n', '\f', ',':
+			s.pos++
+		default:
+			return
+		}
+	}
+}
+
+func (s *scanner) isDone() bool {
+	s.skipSeparators()
+	return s.pos >= len(s.data)
+}
+
+// hasNumber returns true if the next token is the start of a number.
+func (s *scanner) hasNumber() bool {
+	s.skipSeparators()
+	if s.pos >= len(s.data) {
+		return false
+	}
+	char := s.data[s.pos]
+	return char == '-' || char == '+' || char == '.' || (char >= '0' && char <= '9')
+}
+
+func (s *scanner) number() (float64, error) {
+	s.skipSeparators()
+	start := s.pos
+	isDigit := func() bool {
+		return s.pos < len(s.data) && s.data[s.pos] >= '0' && s.data[s.pos] <= '9'
+	}
+	if s.pos < len(s.data) && (s.data[s.pos] == '-' || s.data[s.pos] == '+') {
+		s.pos++
+	}
+	for isDigit() {
+		s.pos++
+	}
+	if s.pos < len(s.data) && s.data[s.pos] == '.' {
+		s.pos++
+		for isDigit() {
+			s.pos++
+		}
+	}
+	if s.pos < len(s.data) && (s.data[s.pos] == 'e' || s.data[s.pos] == 'E') {
+		s.pos++
+		if s.pos < len(s.data) && (s.data[s.pos] == '-' || s.data[s.pos] == '+') {
+			s.pos++
+		}
+		for isDigit() {
+			s.pos++
+		}
+	}
+	value, err := strconv.ParseFloat(s.data[start:s.pos], 64)
+	if err != nil {
+		return 0, fmt.Errorf("vector: invalid number %q at offset %d", s.data[start:s.pos], start)
+	}
+	return value, nil
+}
+
+// flag reads an arc flag, which may not be separated from the next value.
+func (s *scanner) flag() (float64, error) {
+	s.skipSeparators()
+	if s.pos < len(s.data) {
+		switch s.data[s.pos] {
+		case '0':
+			s.pos++
+			return 0, nil
+		case '1':
+			s.pos++
+			return 1, nil
+		}
+	}
+	return 0, fmt.Errorf("vector: invalid flag at offset %d", s.pos)
+}
+
+func (s *scanner) numbers(count int) ([]float64, error) {
+	values := make([]float64, count)
+	for index := range values {
+		value, err := s.number()
+		if err != nil {
+			return nil, err
+		}
+		values[index] = value
+	}
+	return values, nil
+}
+
+// Parse reads SVG path data (the "d" attribute of a <path> element) and
+// returns the equivalent Path. All commands (M, L, H, V, C, S, Q, T, A and
+// Z, in absolute and relative form) are supported, and arcs are converted to
+// bezier segments. As SVG renderers do, Parse stops at the first error and
+// returns the Path that was built up to that point along with the error.
+func Parse(data string) (*Path, error) {
+	p := New()
+	s := &scanner{data: data}
+
+	var currentX, currentY, startX, startY float64
+	// Reflected control points for the S and T shorthands.
+	var lastCubicX, lastCubicY, lastQuadX, lastQuadY float64
+	var previous byte
+
+	for !s.isDone() {
+		command := s.data[s.pos]
+		s.pos++
+
+		isRelative := command >= 'a' && command <= 'z'
+		offsetX, offsetY := 0.0, 0.0
+		if isRelative {
+			command -= 'a' - 'A'
+		}
+
+		isFirst := true
+		for isFirst || s.hasNumber() {
+			if isRelative {
+				offsetX, offsetY = currentX, currentY
+			}
+
+			switch command {
+			case 'M', 'L':
+				args, err := s.numbers(2)
+				if err != nil {
+					return p, err
+				}
+				currentX, currentY = args[0]+offsetX, args[1]+offsetY
+				// Subsequent pairs after a moveto are implicit linetos.
+				if command == 'M' && isFirst {
+					startX, startY = currentX, currentY
+					p.MoveTo(currentX, currentY)
+				} else {
+					p.LineTo(currentX, currentY)
+				}
+			case 'H':
+				x, err := s.number()
+				if err != nil {
+					return p, err
+				}
+				currentX = x + offsetX
+				p.LineTo(currentX, currentY)
+			case 'V':
+				y, err := s.number()
+				if err != nil {
+					return p, err
+				}
+				currentY = y + offsetY
+				p.LineTo(currentX, currentY)
+			case 'C', 'S':
+				var c1x, c1y float64
+				var args []float64
+				var err error
+				if command == 'C' {
+					args, err = s.numbers(6)
+					if err != nil {
+						return p, err
+					}
+					c1x, c1y = args[0]+offsetX, args[1]+offsetY
+					args = args[2:]
+				} else {
+					args, err = s.numbers(4)
+					if err != nil {
+						return p, err
+					}
+					c1x, c1y = currentX, currentY
+					if previous == 'C' || previous == 'S' {
+						c1x, c1y = 2*currentX-lastCubicX, 2*currentY-lastCubicY
+					}
+				}
+				lastCubicX, lastCubicY = args[0]+offsetX, args[1]+offsetY
+				currentX, currentY = args[2]+offsetX, args[3]+offsetY
+				p.BezierTo(c1x, c1y, lastCubicX, lastCubicY, currentX, currentY)
+			case 'Q':
+				args, err := s.numbers(4)
+				if err != nil {
+					return p, err
+				}
+				lastQuadX, lastQuadY = args[0]+offsetX, args[1]+offsetY
+				currentX, currentY = args[2]+offsetX, args[3]+offsetY
+				p.QuadTo(lastQuadX, lastQuadY, currentX, currentY)
+			case 'T':
+				args, err := s.numbers(2)
+				if err != nil {
+					return p, err
+				}
+				if previous == 'Q' || previous == 'T' {
+					lastQuadX, lastQuadY = 2*currentX-lastQuadX, 2*currentY-lastQuadY
+				} else {
+					lastQuadX, lastQuadY = currentX, currentY
+				}
+				currentX, currentY = args[0]+offsetX, args[1]+offsetY
+				p.QuadTo(lastQuadX, lastQuadY, currentX, currentY)
+			case 'A':
+				radii, err := s.numbers(3)
+				if err != nil {
+					return p, err
+				}
+				largeArc, err := s.flag()
+				if err != nil {
+					return p, err
+				}
+				sweep, err := s.flag()
+				if err != nil {
+					return p, err
+				}
+				end, err := s.numbers(2)
+				if err != nil {
+					return p, err
+				}
+				x, y := end[0]+offsetX, end[1]+offsetY
+				arcTo(p, currentX, currentY, radii[0], radii[1], radii[2], largeArc == 1, sweep == 1, x, y)
+				currentX, currentY = x, y
+			case 'Z':
+				p.ClosePath()
+				currentX, currentY = startX, startY
+			default:
+				return p, fmt.Errorf("vector: unknown command %q at offset %d", command, s.pos-1)
+			}
+
+			previous = command
+			isFirst = false
+			if command == 'Z' {
+				break
+			}
+		}
+	}
+	return p, nil
+}
+
+// MustParse is like Parse, but panics if the path data is invalid. It is
+// intended for path data that is declared in source.
+func MustParse(data string) *Path {
+	p, err := Parse(data)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// arcTo converts an SVG elliptical arc into cubic bezier segments of no
+// more than a quarter turn each, following the endpoint to center
+// parameterization from the SVG specification (appendix F.6).
+func arcTo(p *Path, x1, y1, rx, ry, rotation float64, largeArc, sweep bool, x2, y2 float64) {
+	if x1 == x2 && y1 == y2 {
+		return
+	}
+	rx, ry = math.Abs(rx), math.Abs(ry)
+	if rx == 0 || ry == 0 {
+		p.LineTo(x2, y2)
+		return
+	}
+
+	phi := rotation * math.Pi / 180
+	sinPhi, cosPhi := math.Sin(phi), math.Cos(phi)
+
+	dx, dy := (x1-x2)/2, (y1-y2)/2
+	x1p := cosPhi*dx + sinPhi*dy
+	y1p := -sinPhi*dx + cosPhi*dy
+
+	// Scale up radii that are too small to reach the end point.
+	lambda := (x1p*x1p)/(rx*rx) + (y1p*y1p)/(ry*ry)
+	if lambda > 1 {
+		scale := math.Sqrt(lambda)
+		rx, ry = rx*scale, ry*scale
+	}
+
+	numerator := rx*rx*ry*ry - rx*rx*y1p*y1p - ry*ry*x1p*x1p
+	denominator := rx*rx*y1p*y1p + ry*ry*x1p*x1p
+	coefficient := math.Sqrt(math.Max(0, numerator/denominator))
+	if largeArc == sweep {
+		coefficient = -coefficient
+	}
+	cxp := coefficient * rx * y1p / ry
+	cyp := -coefficient * ry * x1p / rx
+
+	cx := cosPhi*cxp - sinPhi*cyp + (x1+x2)/2
+	cy := sinPhi*cxp + cosPhi*cyp + (y1+y2)/2
+
+	angle := func(ux, uy, vx, vy float64) float64 {
+		return math.Atan2(ux*vy-uy*vx, ux*vx+uy*vy)
+	}
+	theta := angle(1, 0, (x1p-cxp)/rx, (y1p-cyp)/ry)
+	delta := angle((x1p-cxp)/rx, (y1p-cyp)/ry, (-x1p-cxp)/rx, (-y1p-cyp)/ry)
+	if !sweep && delta > 0 {
+		delta -= 2 * math.Pi
+	} else if sweep && delta < 0 {
+		delta += 2 * math.Pi
+	}
+
+	segments := int(math.Ceil(math.Abs(delta) / (math.Pi / 2)))
+	step := delta / float64(segments)
+	k := 4.0 / 3.0 * math.Tan(step/4)
+
+	point := func(t float64) (float64, float64) {
+		x, y := rx*math.Cos(t), ry*math.Sin(t)
+		return cosPhi*x - sinPhi*y + cx, sinPhi*x + cosPhi*y + cy
+	}
+	derivative := func(t float64) (float64, float64) {
+		x, y := -rx*math.Sin(t), ry*math.Cos(t)
+		return cosPhi*x - sinPhi*y, sinPhi*x + cosPhi*y
+	}
+
+	for i := 0; i < segments; i++ {
+		t1 := theta + float64(i)*step
+		t2 := t1 + step
+		startX, startY := point(t1)
+		endX, endY := point(t2)
+		if i == segments-1 {
+			endX, endY = x2, y2
+		}
+		d1x, d1y := derivative(t1)
+		d2x, d2y := derivative(t2)
+		p.BezierTo(startX+k*d1x, startY+k*d1y, endX-k*d2x, endY-k*d2y, endX, endY)
+	}
+}
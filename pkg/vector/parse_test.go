@@ -0,0 +1,108 @@
+package vector_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/waybeams/assert"
+	"github.com/waybeams/waybeams/pkg/vector"
+)
+
+func commandTypes(p *vector.Path) []vector.CommandType {
+	result := []vector.CommandType{}
+	for _, command := range p.Commands() {
+		result = append(result, command.Type)
+	}
+	return result
+}
+
+func lastPoint(p *vector.Path) []float64 {
+	commands := p.Commands()
+	args := commands[len(commands)-1].Args
+	return []float64{round(args[len(args)-2]), round(args[len(args)-1])}
+}
+
+func round(value float64) float64 {
+	return math.Round(value*1000) / 1000
+}
+
+func TestParse(t *testing.T) {
+	t.Run("Absolute commands", func(t *testing.T) {
+		p, err := vector.Parse("M10 20 L30 40 H50 V60 C1 2 3 4 5 6 Q7 8 9 10 Z")
+		assert.Nil(err)
+		assert.Equal(commandTypes(p), []vector.CommandType{
+			vector.MoveToCommand,
+			vector.LineToCommand,
+			vector.LineToCommand,
+			vector.LineToCommand,
+			vector.BezierToCommand,
+			vector.QuadToCommand,
+			vector.ClosePathCommand,
+		})
+		commands := p.Commands()
+		assert.Equal(commands[2].Args, []float64{50, 40})
+		assert.Equal(commands[3].Args, []float64{50, 60})
+	})
+
+	t.Run("Relative commands", func(t *testing.T) {
+		p, err := vector.Parse("m10 10 l5 5 h10 v-5 z m1 1 l1 1")
+		assert.Nil(err)
+		commands := p.Commands()
+		assert.Equal(commands[1].Args, []float64{15, 15})
+		assert.Equal(commands[2].Args, []float64{25, 15})
+		assert.Equal(commands[3].Args, []float64{25, 10})
+		assert.Equal(commands[5].Args, []float64{11, 11}, "Relative to the closed sub-path start")
+		assert.Equal(commands[6].Args, []float64{12, 12})
+	})
+
+	t.Run("Implicit commands and compact numbers", func(t *testing.T) {
+		p, err := vector.Parse("M1.5.5-2e1,3 4-5")
+		assert.Nil(err)
+		commands := p.Commands()
+		assert.Equal(len(commands), 3)
+		assert.Equal(commands[0].Args, []float64{1.5, 0.5})
+		assert.Equal(commands[1].Type, vector.LineToCommand)
+		assert.Equal(commands[1].Args, []float64{-20, 3})
+		assert.Equal(commands[2].Args, []float64{4, -5})
+	})
+
+	t.Run("Smooth curves reflect control points", func(t *testing.T) {
+		p, err := vector.Parse("M0 0 C0 10 10 10 10 0 S20 -10 20 0 Q25 5 30 0 T40 0")
+		assert.Nil(err)
+		commands := p.Commands()
+		assert.Equal(commands[2].Args[:2], []float64{10, -10})
+		assert.Equal(commands[4].Args[:2], []float64{35, -5})
+	})
+
+	t.Run("Arcs become bezier segments", func(t *testing.T) {
+		p, err := vector.Parse("M0 10 A10 10 0 0 1 20 10")
+		assert.Nil(err)
+		types := commandTypes(p)
+		assert.Equal(len(types), 3, "A half circle is two quarter turns")
+		assert.Equal(lastPoint(p), []float64{20, 10})
+		x, y, w, h := p.Bounds()
+		assert.Equal([]float64{round(x), round(y), round(w), round(h)}, []float64{0, 0, 20, 10})
+	})
+
+	t.Run("Arc flags may be compact", func(t *testing.T) {
+		p, err := vector.Parse("M0 0a5 5 0 1110 0")
+		assert.Nil(err)
+		assert.Equal(lastPoint(p), []float64{10, 0})
+	})
+
+	t.Run("Returns partial path with error", func(t *testing.T) {
+		p, err := vector.Parse("M0 0 L10 10 X5 5")
+		assert.NotNil(err)
+		assert.Equal(len(p.Commands()), 2)
+
+		_, err = vector.Parse("M0 0 L10")
+		assert.NotNil(err)
+	})
+
+	t.Run("MustParse panics on error", func(t *testing.T) {
+		defer func() {
+			assert.NotNil(recover())
+		}()
+		vector.MustParse("M0")
+	})
+}
@@ -0,0 +1,176 @@
+package vector
+
+import (
+	"math"
+
+	"github.com/waybeams/waybeams/pkg/spec"
+)
+
+// CommandType identifies a single path drawing instruction.
+type CommandType int
+
+const (
+	MoveToCommand = CommandType(iota)
+	LineToCommand
+	BezierToCommand
+	QuadToCommand
+	ClosePathCommand
+	EllipseCommand
+	WindingCommand
+)
+
+// Command is a single path instruction and its arguments.
+type Command struct {
+	Args []float64
+	Type CommandType
+}
+
+// Drawer receives path instructions. Every spec.Surface is a Drawer.
+type Drawer interface {
+	BezierTo(c1x, c1y, c2x, c2y, x, y float64)
+	ClosePath()
+	Ellipse(cx, cy, rx, ry float64)
+	LineTo(x, y float64)
+	MoveTo(x, y float64)
+	PathWinding(winding spec.Winding)
+	QuadTo(cx, cy, x, y float64)
+}
+
+// Path is a backend independent description of a vector shape that can be
+// built up in code or parsed from SVG path data, and later drawn onto any
+// Surface.
+type Path struct {
+	commands []Command
+}
+
+func (p *Path) push(commandType CommandType, args ...float64) {
+	p.commands = append(p.commands, Command{Type: commandType, Args: args})
+}
+
+// MoveTo starts a new sub-path at x and y.
+func (p *Path) MoveTo(x, y float64) {
+	p.push(MoveToCommand, x, y)
+}
+
+// LineTo adds a line segment from the current point to x and y.
+func (p *Path) LineTo(x, y float64) {
+	p.push(LineToCommand, x, y)
+}
+
+// BezierTo adds a cubic bezier segment.
+func (p *Path) BezierTo(c1x, c1y, c2x, c2y, x, y float64) {
+	p.push(BezierToCommand, c1x, c1y, c2x, c2y, x, y)
+}
+
+// QuadTo adds a quadratic bezier segment.
+func (p *Path) QuadTo(cx, cy, x, y float64) {
+	p.push(QuadToCommand, cx, cy, x, y)
+}
+
+// ClosePath closes the current sub-path.
+func (p *Path) ClosePath() {
+	p.push(ClosePathCommand)
+}
+
+// Ellipse adds an ellipse sub-path.
+func (p *Path) Ellipse(cx, cy, rx, ry float64) {
+	p.push(EllipseCommand, cx, cy, rx, ry)
+}
+
+// PathWinding sets the winding of the current sub-path.
+func (p *Path) PathWinding(winding spec.Winding) {
+	p.push(WindingCommand, float64(winding))
+}
+
+// Commands returns the instructions that make up the Path.
+func (p *Path) Commands() []Command {
+	return p.commands
+}
+
+// IsEmpty returns true if the Path has no instructions.
+func (p *Path) IsEmpty() bool {
+	return len(p.commands) == 0
+}
+
+// Bounds returns the box that contains every point of the Path. Bezier
+// control points are included, so curved paths may report slightly larger
+// bounds than they draw.
+func (p *Path) Bounds() (x, y, width, height float64) {
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+	include := func(px, py float64) {
+		minX, minY = math.Min(minX, px), math.Min(minY, py)
+		maxX, maxY = math.Max(maxX, px), math.Max(maxY, py)
+	}
+	for _, command := range p.commands {
+		switch command.Type {
+		case EllipseCommand:
+			a := command.Args
+			include(a[0]-a[2], a[1]-a[3])
+			include(a[0]+a[2], a[1]+a[3])
+		case ClosePathCommand, WindingCommand:
+		default:
+			for i := 0; i+1 < len(command.Args); i += 2 {
+				include(command.Args[i], command.Args[i+1])
+			}
+		}
+	}
+	if math.IsInf(minX, 1) {
+		return 0, 0, 0, 0
+	}
+	return minX, minY, maxX - minX, maxY - minY
+}
+
+// Transform returns a copy of the Path that has been scaled and then
+// translated by the provided values.
+func (p *Path) Transform(translateX, translateY, scaleX, scaleY float64) *Path {
+	result := &Path{commands: make([]Command, len(p.commands))}
+	for index, command := range p.commands {
+		args := make([]float64, len(command.Args))
+		copy(args, command.Args)
+		switch command.Type {
+		case WindingCommand:
+		case EllipseCommand:
+			args[0] = args[0]*scaleX + translateX
+			args[1] = args[1]*scaleY + translateY
+			args[2] = args[2] * math.Abs(scaleX)
+			args[3] = args[3] * math.Abs(scaleY)
+		default:
+			for i := 0; i+1 < len(args); i += 2 {
+				args[i] = args[i]*scaleX + translateX
+				args[i+1] = args[i+1]*scaleY + translateY
+			}
+		}
+		result.commands[index] = Command{Type: command.Type, Args: args}
+	}
+	return result
+}
+
+// Draw sends each instruction to the provided Drawer. Callers are
+// responsible for BeginPath and for Fill or Stroke.
+func (p *Path) Draw(d Drawer) {
+	for _, command := range p.commands {
+		a := command.Args
+		switch command.Type {
+		case MoveToCommand:
+			d.MoveTo(a[0], a[1])
+		case LineToCommand:
+			d.LineTo(a[0], a[1])
+		case BezierToCommand:
+			d.BezierTo(a[0], a[1], a[2], a[3], a[4], a[5])
+		case QuadToCommand:
+			d.QuadTo(a[0], a[1], a[2], a[3])
+		case ClosePathCommand:
+			d.ClosePath()
+		case EllipseCommand:
+			d.Ellipse(a[0], a[1], a[2], a[3])
+		case WindingCommand:
+			d.PathWinding(spec.Winding(a[0]))
+		}
+	}
+}
+
+// New returns an empty Path.
+func New() *Path {
+	return &Path{}
+}
@@ -0,0 +1,53 @@
+package vector_test
+
+import (
+	"testing"
+
+	"github.com/waybeams/assert"
+	"github.com/waybeams/waybeams/pkg/env/fake"
+	"github.com/waybeams/waybeams/pkg/spec"
+	"github.com/waybeams/waybeams/pkg/vector"
+)
+
+func TestPath(t *testing.T) {
+	t.Run("Draws commands in order", func(t *testing.T) {
+		p := vector.New()
+		p.MoveTo(0, 0)
+		p.LineTo(10, 0)
+		p.QuadTo(10, 10, 0, 10)
+		p.BezierTo(0, 8, 2, 6, 4, 4)
+		p.ClosePath()
+		p.Ellipse(5, 5, 2, 1)
+		p.PathWinding(spec.HoleWinding)
+
+		s := fake.NewSurface()
+		p.Draw(s)
+		commands := s.GetCommands()
+		names := []string{}
+		for _, command := range commands {
+			names = append(names, command.Name)
+		}
+		assert.Equal(names, []string{"MoveTo", "LineTo", "QuadTo", "BezierTo", "ClosePath", "Ellipse", "PathWinding"})
+		assert.Equal(commands[6].Args[0], spec.HoleWinding)
+	})
+
+	t.Run("Bounds", func(t *testing.T) {
+		p := vector.New()
+		assert.Equal(p.IsEmpty(), true)
+		p.MoveTo(5, 10)
+		p.LineTo(25, 30)
+		p.Ellipse(0, 20, 3, 3)
+		x, y, w, h := p.Bounds()
+		assert.Equal([]float64{x, y, w, h}, []float64{-3, 10, 28, 20})
+	})
+
+	t.Run("Transform scales then translates", func(t *testing.T) {
+		p := vector.New()
+		p.MoveTo(1, 2)
+		p.Ellipse(1, 1, 2, 3)
+		result := p.Transform(10, 20, 2, -1).Commands()
+		assert.Equal(result[0].Args, []float64{12, 18})
+		assert.Equal(result[1].Args, []float64{12, 19, 4, 3})
+		assert.Equal(p.Commands()[0].Args, []float64{1, 2}, "Original is unchanged")
+	})
+}
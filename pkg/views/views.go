@@ -1,47 +1,659 @@
 package views
 
 import (
+	"math"
+
 	"github.com/waybeams/waybeams/pkg/spec"
+	"github.com/waybeams/waybeams/pkg/svg"
+	"github.com/waybeams/waybeams/pkg/text"
+	"github.com/waybeams/waybeams/pkg/vector"
 )
 
 var DefaultRectangleRadius = 3.0
 
+// DefaultAccentColor fills checked and selected indicators.
+var DefaultAccentColor uint = 0x00acd7ff
+
+// DefaultBackdropColor dims the content beneath a modal overlay.
+var DefaultBackdropColor uint = 0x00000066
+
+// DefaultMarkColor draws check marks and switch knobs on top of the
+// DefaultAccentColor.
+var DefaultMarkColor uint = 0xffffffff
+
+// DefaultTrackColor fills the track of unchecked switches and sliders.
+var DefaultTrackColor uint = 0xdbd9d6ff
+
 func RectangleView(s spec.Surface, r spec.Reader) {
-	// fmt.Println("Rectangle with:", spec.Path(r), "x:", r.X(), "y:", r.Y(), "w:", r.Width(), "h:", r.Height())
+	drawBox(s, r, r.CornerRadii())
+}
+
+// BackdropView fills the bounds of the provided Spec (usually root) with
+// DefaultBackdropColor, beneath a modal overlay.
+func BackdropView(s spec.Surface, r spec.Reader) {
 	s.BeginPath()
 	s.Rect(r.X(), r.Y(), r.Width(), r.Height())
-	s.SetFillColor(r.BgColor())
+	s.SetFillColor(DefaultBackdropColor)
 	s.Fill()
+}
+
+func RoundedRectView(s spec.Surface, r spec.Reader) {
+	corners := r.CornerRadii()
+	if corners.IsZero() {
+		corners = spec.Corners{
+			TopLeft:     DefaultRectangleRadius,
+			TopRight:    DefaultRectangleRadius,
+			BottomRight: DefaultRectangleRadius,
+			BottomLeft:  DefaultRectangleRadius,
+		}
+	}
+	drawBox(s, r, corners)
+}
+
+func LabelView(s spec.Surface, r spec.Reader) {
+	drawBackground(s, r)
+	if r.Text() != "" {
+		s.SetFontSize(r.FontSize())
+		s.SetFontFace(s.Fonts().FaceNameFor(r.FontFace(), r.FontWeight(), r.FontStyle()))
+		s.SetFillColor(r.FontColor())
+		if shaped, ok := r.(LinesReader); ok && len(shaped.Lines()) > 0 {
+			drawLines(s, r, shaped.Lines())
+		} else if display, ok := r.(DisplayTextReader); ok {
+			s.Text(r.TextX(), r.TextY(), display.DisplayText())
+		} else {
+			s.Text(r.TextX(), r.TextY(), r.Text())
+		}
+	}
+}
+
+// TextInputView draws a LabelView, and underlines uncommitted IME text.
+func TextInputView(s spec.Surface, r spec.Reader) {
+	LabelView(s, r)
+	if composing, ok := r.(PreeditReader); ok {
+		x, width, ok := composing.PreeditBounds()
+		if ok && width > 0 {
+			x += r.TextX()
+			// TextY is the baseline.
+			y := r.TextY() + 2
+			s.BeginPath()
+			s.MoveTo(x, y)
+			s.LineTo(x+width, y)
+			s.SetStrokeWidth(1)
+			s.SetStrokeColor(r.FontColor())
+			s.Stroke()
+		}
+	}
+}
 
+// CheckboxView draws a LabelView, and a box before the text that is filled
+// and marked when it is checked or indeterminate.
+func CheckboxView(s spec.Surface, r spec.Reader) {
+	LabelView(s, r)
+	check, ok := r.(CheckReader)
+	if !ok {
+		return
+	}
+	x, y, width, height := check.IndicatorRect()
+	isMarked := check.IsChecked() || check.IsIndeterminate()
 	s.BeginPath()
-	s.Rect(r.X()-0.5, r.Y()-0.5, r.Width()+1, r.Height()+1)
-	s.SetStrokeWidth(r.StrokeSize())
-	s.SetStrokeColor(r.StrokeColor())
+	s.RoundedRect(x+0.5, y+0.5, width-1, height-1, DefaultRectangleRadius)
+	if isMarked {
+		s.SetFillColor(DefaultAccentColor)
+		s.Fill()
+	}
+	s.SetStrokeWidth(1)
+	s.SetStrokeColor(r.FontColor())
+	s.Stroke()
+	if !isMarked {
+		return
+	}
+
+	s.BeginPath()
+	if check.IsIndeterminate() {
+		s.MoveTo(x+width*0.25, y+height*0.5)
+		s.LineTo(x+width*0.75, y+height*0.5)
+	} else {
+		s.MoveTo(x+width*0.22, y+height*0.52)
+		s.LineTo(x+width*0.42, y+height*0.72)
+		s.LineTo(x+width*0.78, y+height*0.3)
+	}
+	s.SetStrokeWidth(math.Max(1.5, height/8))
+	s.SetStrokeColor(DefaultMarkColor)
 	s.Stroke()
 }
 
-func RoundedRectView(s spec.Surface, r spec.Reader) {
-	// TODO(lbayes): Get the radius from control values.
+// RadioView draws a LabelView, and a circle before the text with a dot in
+// it when it is checked.
+func RadioView(s spec.Surface, r spec.Reader) {
+	LabelView(s, r)
+	check, ok := r.(CheckReader)
+	if !ok {
+		return
+	}
+	x, y, width, height := check.IndicatorRect()
+	radius := math.Min(width, height) / 2
+	cx, cy := x+width/2, y+height/2
+	s.BeginPath()
+	s.Ellipse(cx, cy, radius-0.5, radius-0.5)
+	s.SetStrokeWidth(1)
+	s.SetStrokeColor(r.FontColor())
+	s.Stroke()
+	if check.IsChecked() {
+		s.BeginPath()
+		s.Ellipse(cx, cy, radius/2, radius/2)
+		s.SetFillColor(DefaultAccentColor)
+		s.Fill()
+	}
+}
+
+// ToggleView draws a LabelView, and a switch before the text with the knob
+// on the right and the track filled when it is checked.
+func ToggleView(s spec.Surface, r spec.Reader) {
+	LabelView(s, r)
+	check, ok := r.(CheckReader)
+	if !ok {
+		return
+	}
+	x, y, width, height := check.IndicatorRect()
+	radius := height / 2
+	trackColor := DefaultTrackColor
+	knobX := x + radius
+	if check.IsChecked() {
+		trackColor = DefaultAccentColor
+		knobX = x + width - radius
+	}
+	s.BeginPath()
+	s.RoundedRect(x, y, width, height, radius)
+	s.SetFillColor(trackColor)
+	s.Fill()
+
+	s.BeginPath()
+	s.Ellipse(knobX, y+radius, radius-2, radius-2)
+	s.SetFillColor(DefaultMarkColor)
+	s.Fill()
+}
+
+// ProgressBarView draws the track of a ProgressBar, and then its filled
+// part.
+func ProgressBarView(s spec.Surface, r spec.Reader) {
+	drawBackground(s, r)
+	track, ok := r.(TrackReader)
+	if !ok {
+		return
+	}
+	x, y, width, height := track.TrackRect()
+	radius := math.Min(width, height) / 2
+	s.BeginPath()
+	s.RoundedRect(x, y, width, height, radius)
+	s.SetFillColor(DefaultTrackColor)
+	s.Fill()
+
+	x, y, width, height = track.FillRect()
+	if width > 0 && height > 0 {
+		s.BeginPath()
+		s.RoundedRect(x, y, width, height, math.Min(radius, math.Min(width, height)/2))
+		s.SetFillColor(DefaultAccentColor)
+		s.Fill()
+	}
+}
+
+// SliderView draws a ProgressBarView of the track, and then the thumb.
+func SliderView(s spec.Surface, r spec.Reader) {
+	ProgressBarView(s, r)
+	thumb, ok := r.(ThumbReader)
+	if !ok {
+		return
+	}
+	x, y, width, height := thumb.ThumbRect()
+	radius := math.Min(width, height) / 2
+	s.BeginPath()
+	s.Ellipse(x+width/2, y+height/2, radius-0.5, radius-0.5)
+	s.SetFillColor(DefaultMarkColor)
+	s.Fill()
+	s.SetStrokeWidth(1)
+	s.SetStrokeColor(DefaultAccentColor)
+	s.Stroke()
+}
+
+// SelectView draws a LabelView, and a chevron after the text that points
+// up while the list of choices is open.
+func SelectView(s spec.Surface, r spec.Reader) {
+	LabelView(s, r)
+	drawChevron(s, r)
+}
+
+// ComboBoxView draws a TextInputView, and a chevron after the text that
+// points up while the list of choices is open.
+func ComboBoxView(s spec.Surface, r spec.Reader) {
+	TextInputView(s, r)
+	drawChevron(s, r)
+}
+
+func drawChevron(s spec.Surface, r spec.Reader) {
+	dropDown, ok := r.(DropDownReader)
+	if !ok {
+		return
+	}
+	x, y, width, height := dropDown.ChevronRect()
+	top, bottom := y, y+height
+	if dropDown.IsOpen() {
+		top, bottom = bottom, top
+	}
+	s.BeginPath()
+	s.MoveTo(x, top)
+	s.LineTo(x+width/2, bottom)
+	s.LineTo(x+width, top)
+	s.SetStrokeWidth(1.5)
+	s.SetStrokeColor(r.FontColor())
+	s.Stroke()
+}
+
+// DropDownReader is a Reader that opens a list of choices.
+type DropDownReader interface {
+	spec.Reader
+	ChevronRect() (x, y, width, height float64)
+	IsOpen() bool
+}
+
+// ThumbReader is a Reader with a thumb that is dragged along its track.
+type ThumbReader interface {
+	spec.Reader
+	ThumbRect() (x, y, width, height float64)
+}
+
+// TrackReader is a Reader that fills part of a track to show its value.
+type TrackReader interface {
+	spec.Reader
+	FillRect() (x, y, width, height float64)
+	TrackRect() (x, y, width, height float64)
+}
+
+// CheckReader is a Reader that draws an indicator of whether it is checked.
+type CheckReader interface {
+	spec.Reader
+	IndicatorRect() (x, y, width, height float64)
+	IsChecked() bool
+	IsIndeterminate() bool
+}
+
+// PreeditReader is a Reader that may be drawing uncommitted IME text.
+type PreeditReader interface {
+	spec.Reader
+	PreeditBounds() (x, width float64, ok bool)
+}
+
+// DisplayTextReader is a Reader that draws something other than its Text,
+// like a masked password.
+type DisplayTextReader interface {
+	spec.Reader
+	DisplayText() string
+}
+
+// LinesReader is a Reader whose text was shaped into lines.
+type LinesReader interface {
+	spec.Reader
+	Lines() []text.Line
+}
+
+// drawLines draws each shaped line below the previous one. Right to left
+// paragraphs are aligned to the right edge of the padded bounds.
+func drawLines(s spec.Surface, r spec.Reader, lines []text.Line) {
+	x, y := r.TextX(), r.TextY()
+	width := r.Width() - r.HorizontalPadding()
+	for _, line := range lines {
+		lineX := x
+		if line.Direction == text.RightToLeft {
+			lineX = x + width - line.Width
+		}
+		s.DrawGlyphs(lineX, y, line)
+		y += line.Height
+	}
+}
+
+// drawBackground draws a RectangleView if the Spec has a background, a
+// stroke or decorations.
+func drawBackground(s spec.Surface, r spec.Reader) {
+	if r.BgColor() != 0 || r.StrokeColor() != 0 || hasDecoration(r) {
+		RectangleView(s, r)
+	}
+}
+
+func hasDecoration(r spec.Reader) bool {
+	return r.Gradient() != nil || len(r.Shadows()) > 0 || !r.Borders().IsZero()
+}
+
+// drawBox draws drop shadows, the background fill, inset shadows, the stroke
+// and finally any borders, in that order.
+func drawBox(s spec.Surface, r spec.Reader, corners spec.Corners) {
+	x, y, width, height := r.X(), r.Y(), r.Width(), r.Height()
+
+	for _, shadow := range r.Shadows() {
+		if !shadow.Inset {
+			drawDropShadow(s, x, y, width, height, corners, shadow)
+		}
+	}
+
 	s.BeginPath()
-	s.RoundedRect(r.X(), r.Y(), r.Width(), r.Height(), DefaultRectangleRadius)
-	s.SetFillColor(r.BgColor())
+	boxPath(s, x, y, width, height, corners)
+	setFill(s, r, x, y, width, height)
 	s.Fill()
 
+	for _, shadow := range r.Shadows() {
+		if shadow.Inset {
+			drawInsetShadow(s, x, y, width, height, corners, shadow)
+		}
+	}
+
 	s.BeginPath()
-	s.RoundedRect(r.X()-0.5, r.Y()-0.5, r.Width()+1, r.Height()+1, DefaultRectangleRadius)
+	boxPath(s, x-0.5, y-0.5, width+1, height+1, corners)
 	s.SetStrokeWidth(r.StrokeSize())
 	s.SetStrokeColor(r.StrokeColor())
 	s.Stroke()
+
+	drawBorders(s, x, y, width, height, corners, r.Borders())
 }
 
-func LabelView(s spec.Surface, r spec.Reader) {
-	if r.BgColor() != 0 || r.StrokeColor() != 0 {
-		RectangleView(s, r)
+// boxPath adds the outline of a box to the current path using the simplest
+// shape that the corners allow.
+func boxPath(s spec.Surface, x, y, width, height float64, corners spec.Corners) {
+	switch {
+	case corners.IsZero():
+		s.Rect(x, y, width, height)
+	case corners.TopLeft == corners.TopRight && corners.TopLeft == corners.BottomRight && corners.TopLeft == corners.BottomLeft:
+		s.RoundedRect(x, y, width, height, corners.TopLeft)
+	default:
+		s.RoundedRectVarying(x, y, width, height, corners.TopLeft, corners.TopRight, corners.BottomRight, corners.BottomLeft)
 	}
-	if r.Text() != "" {
-		s.SetFontSize(r.FontSize())
-		s.SetFontFace(r.FontFace())
-		s.SetFillColor(r.FontColor())
-		s.Text(r.TextX(), r.TextY(), r.Text())
+}
+
+func setFill(s spec.Surface, r spec.Reader, x, y, width, height float64) {
+	gradient := r.Gradient()
+	if gradient == nil {
+		s.SetFillColor(r.BgColor())
+		return
+	}
+	startX := x + gradient.StartX*width
+	startY := y + gradient.StartY*height
+	switch gradient.Type {
+	case spec.RadialGradientType:
+		s.SetFillRadialGradient(startX, startY, gradient.InnerRadius, gradient.OuterRadius, gradient.StartColor, gradient.EndColor)
+	default:
+		endX := x + gradient.EndX*width
+		endY := y + gradient.EndY*height
+		s.SetFillLinearGradient(startX, startY, endX, endY, gradient.StartColor, gradient.EndColor)
+	}
+}
+
+func drawDropShadow(s spec.Surface, x, y, width, height float64, corners spec.Corners, shadow spec.BoxShadow) {
+	sx := x + shadow.OffsetX - shadow.Spread
+	sy := y + shadow.OffsetY - shadow.Spread
+	sw := width + shadow.Spread*2
+	sh := height + shadow.Spread*2
+	radius := math.Max(0, maxCorner(corners)+shadow.Spread)
+
+	s.BeginPath()
+	s.Rect(sx-shadow.Blur, sy-shadow.Blur, sw+shadow.Blur*2, sh+shadow.Blur*2)
+	s.SetFillBoxGradient(sx, sy, sw, sh, radius, shadow.Blur, shadow.Color, transparent(shadow.Color))
+	s.Fill()
+}
+
+func drawInsetShadow(s spec.Surface, x, y, width, height float64, corners spec.Corners, shadow spec.BoxShadow) {
+	sx := x + shadow.OffsetX + shadow.Spread
+	sy := y + shadow.OffsetY + shadow.Spread
+	sw := math.Max(0, width-shadow.Spread*2)
+	sh := math.Max(0, height-shadow.Spread*2)
+	radius := math.Max(0, maxCorner(corners)-shadow.Spread)
+
+	s.BeginPath()
+	boxPath(s, x, y, width, height, corners)
+	s.SetFillBoxGradient(sx, sy, sw, sh, radius, shadow.Blur, transparent(shadow.Color), shadow.Color)
+	s.Fill()
+}
+
+// drawBorders strokes uniform borders along the box outline and, when the
+// sides differ, strokes each side along its own stretch of that outline.
+func drawBorders(s spec.Surface, x, y, width, height float64, corners spec.Corners, borders spec.Borders) {
+	if borders.IsZero() {
+		return
+	}
+	if borders.IsUniform() {
+		inset := borders.Top.Width / 2
+		s.BeginPath()
+		boxPath(s, x+inset, y+inset, width-inset*2, height-inset*2, expand(corners, -inset))
+		s.SetStrokeWidth(borders.Top.Width)
+		s.SetStrokeColor(borders.Top.Color)
+		s.Stroke()
+		return
+	}
+
+	sides := []spec.BorderSide{borders.Top, borders.Right, borders.Bottom, borders.Left}
+	for index, side := range sides {
+		if side.Width <= 0 {
+			continue
+		}
+		inset := side.Width / 2
+		s.BeginPath()
+		borderSidePath(s, index, x+inset, y+inset, width-side.Width, height-side.Width, inset, expand(corners, -inset))
+		s.SetStrokeWidth(side.Width)
+		s.SetStrokeColor(side.Color)
+		s.Stroke()
+	}
+}
+
+// borderSidePath traces one side (0 is the top, then clockwise) of the
+// rounded box, reaching halfway around each adjacent rounded corner. Square
+// corners are extended by inset so that adjacent sides meet at the bounds.
+func borderSidePath(s spec.Surface, side int, x, y, width, height, inset float64, corners spec.Corners) {
+	radii := []float64{corners.TopLeft, corners.TopRight, corners.BottomRight, corners.BottomLeft}
+	cornerX := []float64{x, x + width, x + width, x}
+	cornerY := []float64{y, y, y + height, y + height}
+	inwardX := []float64{1, -1, -1, 1}
+	inwardY := []float64{1, 1, -1, -1}
+	tangentX := []float64{1, 0, -1, 0}
+	tangentY := []float64{0, 1, 0, -1}
+
+	for n, corner := range []int{side, (side + 1) % 4} {
+		radius := radii[corner]
+		if radius == 0 {
+			offset := inset
+			if n == 0 {
+				offset = -inset
+			}
+			px := cornerX[corner] + tangentX[side]*offset
+			py := cornerY[corner] + tangentY[side]*offset
+			if n == 0 {
+				s.MoveTo(px, py)
+			} else {
+				s.LineTo(px, py)
+			}
+			continue
+		}
+		centerX := cornerX[corner] + inwardX[corner]*radius
+		centerY := cornerY[corner] + inwardY[corner]*radius
+		start := math.Pi + float64(corner)*math.Pi/2
+		if n == 0 {
+			s.Arc(centerX, centerY, radius, start+math.Pi/4, start+math.Pi/2)
+		} else {
+			s.Arc(centerX, centerY, radius, start, start+math.Pi/4)
+		}
+	}
+}
+
+// expand grows (or, with a negative amount, shrinks) every rounded corner.
+func expand(corners spec.Corners, amount float64) spec.Corners {
+	grow := func(radius float64) float64 {
+		if radius == 0 {
+			return 0
+		}
+		return math.Max(0, radius+amount)
+	}
+	return spec.Corners{
+		TopLeft:     grow(corners.TopLeft),
+		TopRight:    grow(corners.TopRight),
+		BottomRight: grow(corners.BottomRight),
+		BottomLeft:  grow(corners.BottomLeft),
+	}
+}
+
+func maxCorner(corners spec.Corners) float64 {
+	return math.Max(math.Max(corners.TopLeft, corners.TopRight), math.Max(corners.BottomRight, corners.BottomLeft))
+}
+
+// transparent returns the provided RGBA color with a zero alpha channel.
+func transparent(color uint) uint {
+	return color &^ 0xff
+}
+
+// PathReader is a spec.Reader that provides a vector Path to draw, along
+// with the view box that the Path coordinates are relative to.
+type PathReader interface {
+	spec.Reader
+	Path() *vector.Path
+	ViewBox() (x, y, width, height float64)
+}
+
+// ShapeView scales the Path from its view box to fit within the padded
+// bounds of the Spec (preserving aspect ratio and centering it), then fills
+// it with the BgColor or Gradient and strokes it with the StrokeColor.
+func ShapeView(s spec.Surface, r spec.Reader) {
+	shape, ok := r.(PathReader)
+	if !ok || shape.Path() == nil || shape.Path().IsEmpty() {
+		return
+	}
+	viewX, viewY, viewWidth, viewHeight := shape.ViewBox()
+	x := r.X() + r.PaddingLeft()
+	y := r.Y() + r.PaddingTop()
+	width := r.Width() - r.HorizontalPadding()
+	height := r.Height() - r.VerticalPadding()
+	if viewWidth <= 0 || viewHeight <= 0 || width <= 0 || height <= 0 {
+		return
+	}
+	scale := math.Min(width/viewWidth, height/viewHeight)
+	offsetX := x + (width-viewWidth*scale)/2 - viewX*scale
+	offsetY := y + (height-viewHeight*scale)/2 - viewY*scale
+
+	s.BeginPath()
+	shape.Path().Transform(offsetX, offsetY, scale, scale).Draw(s)
+	if r.BgColor() != 0 || r.Gradient() != nil {
+		setFill(s, r, x, y, width, height)
+		s.Fill()
+	}
+	if r.StrokeSize() > 0 && r.StrokeColor() != 0 {
+		s.SetStrokeWidth(r.StrokeSize())
+		s.SetStrokeColor(r.StrokeColor())
+		s.Stroke()
+	}
+}
+
+// ImageReader is a spec.Reader that provides a named image for ImageView.
+type ImageReader interface {
+	spec.Reader
+	ImageFit() spec.ImageFit
+	ImageName() string
+	NineSlice() (top, right, bottom, left float64)
+}
+
+// ImageView draws the named image into the padded bounds of the Spec, using
+// nine-slice scaling when insets are configured and the ImageFit otherwise.
+func ImageView(s spec.Surface, r spec.Reader) {
+	drawBackground(s, r)
+
+	image, ok := r.(ImageReader)
+	if !ok {
+		return
+	}
+	imageWidth, imageHeight, ok := s.ImageSize(image.ImageName())
+	if !ok || imageWidth <= 0 || imageHeight <= 0 {
+		return
+	}
+	x := r.X() + r.PaddingLeft()
+	y := r.Y() + r.PaddingTop()
+	width := r.Width() - r.HorizontalPadding()
+	height := r.Height() - r.VerticalPadding()
+	if width <= 0 || height <= 0 {
+		return
+	}
+
+	top, right, bottom, left := image.NineSlice()
+	if top > 0 || right > 0 || bottom > 0 || left > 0 {
+		drawNineSlice(s, image.ImageName(), imageWidth, imageHeight, top, right, bottom, left, x, y, width, height)
+		return
+	}
+
+	scaleX, scaleY := 1.0, 1.0
+	switch image.ImageFit() {
+	case spec.FitContain:
+		scaleX = math.Min(width/imageWidth, height/imageHeight)
+		scaleY = scaleX
+	case spec.FitCover:
+		scaleX = math.Max(width/imageWidth, height/imageHeight)
+		scaleY = scaleX
+	case spec.FitFill:
+		scaleX = width / imageWidth
+		scaleY = height / imageHeight
+	}
+
+	// Center the scaled image, then crop both the destination and source to
+	// the bounds.
+	drawWidth := imageWidth * scaleX
+	drawHeight := imageHeight * scaleY
+	drawX := x + (width-drawWidth)/2
+	drawY := y + (height-drawHeight)/2
+
+	clipX := math.Max(drawX, x)
+	clipY := math.Max(drawY, y)
+	clipWidth := math.Min(drawX+drawWidth, x+width) - clipX
+	clipHeight := math.Min(drawY+drawHeight, y+height) - clipY
+
+	s.DrawImageRegion(image.ImageName(),
+		(clipX-drawX)/scaleX, (clipY-drawY)/scaleY, clipWidth/scaleX, clipHeight/scaleY,
+		clipX, clipY, clipWidth, clipHeight)
+}
+
+// drawNineSlice keeps the corners of the image at their intrinsic size,
+// stretches the edges along one axis and stretches the center along both.
+// Corners shrink proportionally if the bounds are smaller than the insets.
+func drawNineSlice(s spec.Surface, name string, imageWidth, imageHeight, top, right, bottom, left, x, y, width, height float64) {
+	scaleX := math.Min(1, width/(left+right))
+	scaleY := math.Min(1, height/(top+bottom))
+
+	srcX := []float64{0, left, imageWidth - right, imageWidth}
+	srcY := []float64{0, top, imageHeight - bottom, imageHeight}
+	dstX := []float64{x, x + left*scaleX, x + width - right*scaleX, x + width}
+	dstY := []float64{y, y + top*scaleY, y + height - bottom*scaleY, y + height}
+
+	for row := 0; row < 3; row++ {
+		for column := 0; column < 3; column++ {
+			srcWidth := srcX[column+1] - srcX[column]
+			srcHeight := srcY[row+1] - srcY[row]
+			dstWidth := dstX[column+1] - dstX[column]
+			dstHeight := dstY[row+1] - dstY[row]
+			if srcWidth <= 0 || srcHeight <= 0 || dstWidth <= 0 || dstHeight <= 0 {
+				continue
+			}
+			s.DrawImageRegion(name, srcX[column], srcY[row], srcWidth, srcHeight, dstX[column], dstY[row], dstWidth, dstHeight)
+		}
+	}
+}
+
+// IconReader is a spec.Reader that provides an SVG Document for IconView.
+type IconReader interface {
+	spec.Reader
+	Icon() *svg.Document
+}
+
+// IconView draws the SVG Document scaled into the padded bounds of the Spec
+// and tinted with the FontColor, so that icons match neighboring text.
+func IconView(s spec.Surface, r spec.Reader) {
+	drawBackground(s, r)
+	icon, ok := r.(IconReader)
+	if !ok || icon.Icon() == nil {
+		return
 	}
+	icon.Icon().DrawTinted(s,
+		r.X()+r.PaddingLeft(),
+		r.Y()+r.PaddingTop(),
+		r.Width()-r.HorizontalPadding(),
+		r.Height()-r.VerticalPadding(),
+		r.FontColor())
 }
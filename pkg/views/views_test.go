@@ -0,0 +1,196 @@
+package views_test
+
+import (
+	"testing"
+
+	"github.com/waybeams/assert"
+	"github.com/waybeams/waybeams/pkg/ctrl"
+	surface "github.com/waybeams/waybeams/pkg/env/fake"
+	"github.com/waybeams/waybeams/pkg/fakes"
+	"github.com/waybeams/waybeams/pkg/opts"
+	"github.com/waybeams/waybeams/pkg/spec"
+	"github.com/waybeams/waybeams/pkg/views"
+)
+
+func TestViews(t *testing.T) {
+	t.Run("RectangleView draws fill and stroke", func(t *testing.T) {
+		s := surface.NewSurface()
+		views.RectangleView(s, ctrl.Box(opts.Width(100), opts.Height(50), opts.BgColor(0xff0000ff)))
+
+		assert.Equal(s.CommandNames(), []string{
+			"BeginPath", "Rect", "SetFillColor", "Fill",
+			"BeginPath", "Rect", "SetLineWidth", "SetStrokeColor", "Stroke",
+		})
+	})
+
+	t.Run("Uniform corner radius", func(t *testing.T) {
+		s := surface.NewSurface()
+		views.RectangleView(s, ctrl.Box(opts.Width(100), opts.Height(50), opts.CornerRadius(8)))
+
+		args := s.CommandsNamed("RoundedRect")
+		assert.Equal(len(args), 2)
+		assert.Equal(args[0].Args[4], 8.0)
+	})
+
+	t.Run("Varying corner radii", func(t *testing.T) {
+		s := surface.NewSurface()
+		views.RectangleView(s, ctrl.Box(opts.Width(100), opts.Height(50), opts.CornerRadii(1, 2, 3, 4)))
+
+		args := s.CommandsNamed("RoundedRectVarying")
+		assert.Equal(len(args), 2)
+		assert.Equal(args[0].Args[4:], []interface{}{1.0, 2.0, 3.0, 4.0})
+	})
+
+	t.Run("RoundedRectView uses default radius", func(t *testing.T) {
+		s := surface.NewSurface()
+		views.RoundedRectView(s, ctrl.Box(opts.Width(100), opts.Height(50)))
+
+		args := s.CommandsNamed("RoundedRect")
+		assert.Equal(args[0].Args[4], views.DefaultRectangleRadius)
+	})
+
+	t.Run("Linear gradient is scaled to bounds", func(t *testing.T) {
+		s := surface.NewSurface()
+		box := ctrl.Box(opts.X(10), opts.Y(20), opts.Width(100), opts.Height(50),
+			opts.LinearGradient(0, 0, 0, 1, 0xffffffff, 0x000000ff))
+		views.RectangleView(s, box)
+
+		assert.Equal(len(s.CommandsNamed("SetFillColor")), 0)
+		args := s.CommandsNamed("SetFillLinearGradient")
+		assert.Equal(args[0].Args, []interface{}{10.0, 20.0, 10.0, 70.0, uint(0xffffffff), uint(0x000000ff)})
+	})
+
+	t.Run("Radial gradient", func(t *testing.T) {
+		s := surface.NewSurface()
+		box := ctrl.Box(opts.Width(100), opts.Height(50),
+			opts.RadialGradient(0.5, 0.5, 0, 40, 0xffffffff, 0x000000ff))
+		views.RectangleView(s, box)
+
+		args := s.CommandsNamed("SetFillRadialGradient")
+		assert.Equal(args[0].Args, []interface{}{50.0, 25.0, 0.0, 40.0, uint(0xffffffff), uint(0x000000ff)})
+	})
+
+	t.Run("Drop shadow is drawn before the fill", func(t *testing.T) {
+		s := surface.NewSurface()
+		box := ctrl.Box(opts.Width(100), opts.Height(50), opts.Shadow(2, 4, 6, 0, 0x00000080))
+		views.RectangleView(s, box)
+
+		names := s.CommandNames()
+		assert.Equal(names[2], "SetFillBoxGradient")
+		args := s.CommandsNamed("SetFillBoxGradient")
+		assert.Equal(args[0].Args, []interface{}{2.0, 4.0, 100.0, 50.0, 0.0, 6.0, uint(0x00000080), uint(0x00000000)})
+		rect := s.CommandsNamed("Rect")[0].Args
+		assert.Equal(rect, []interface{}{-4.0, -2.0, 112.0, 62.0})
+	})
+
+	t.Run("Inner shadow is drawn after the fill", func(t *testing.T) {
+		s := surface.NewSurface()
+		box := ctrl.Box(opts.Width(100), opts.Height(50), opts.InnerShadow(0, 0, 4, 0, 0x000000ff))
+		views.RectangleView(s, box)
+
+		names := s.CommandNames()
+		assert.Equal(names[2], "SetFillColor")
+		assert.Equal(names[6], "SetFillBoxGradient")
+		args := s.CommandsNamed("SetFillBoxGradient")
+		assert.Equal(args[0].Args[6:], []interface{}{uint(0x00000000), uint(0x000000ff)})
+	})
+
+	t.Run("Uniform border is stroked inside the bounds", func(t *testing.T) {
+		s := surface.NewSurface()
+		box := ctrl.Box(opts.Width(100), opts.Height(50), opts.Border(2, 0xff0000ff))
+		views.RectangleView(s, box)
+
+		strokes := s.CommandsNamed("SetLineWidth")
+		assert.Equal(strokes[1].Args[0], 2.0)
+		rects := s.CommandsNamed("Rect")
+		assert.Equal(rects[2].Args, []interface{}{1.0, 1.0, 98.0, 48.0})
+	})
+
+	t.Run("Per side borders are stroked", func(t *testing.T) {
+		s := surface.NewSurface()
+		box := ctrl.Box(opts.Width(100), opts.Height(50),
+			opts.BorderTop(1, 0xff0000ff), opts.BorderLeft(3, 0x00ff00ff))
+		views.RectangleView(s, box)
+
+		moves := s.CommandsNamed("MoveTo")
+		lines := s.CommandsNamed("LineTo")
+		assert.Equal(len(moves), 2)
+		assert.Equal(moves[0].Args, []interface{}{0.0, 0.5})
+		assert.Equal(lines[0].Args, []interface{}{100.0, 0.5})
+		assert.Equal(moves[1].Args, []interface{}{1.5, 50.0})
+		assert.Equal(lines[1].Args, []interface{}{1.5, 0.0})
+		strokes := s.CommandsNamed("SetLineWidth")
+		assert.Equal(strokes[1].Args[0], 1.0)
+		assert.Equal(strokes[2].Args[0], 3.0)
+		colors := s.CommandsNamed("SetStrokeColor")
+		assert.Equal(colors[1].Args[0], uint(0xff0000ff))
+		assert.Equal(colors[2].Args[0], uint(0x00ff00ff))
+	})
+
+	t.Run("Per side borders follow rounded corners", func(t *testing.T) {
+		s := surface.NewSurface()
+		box := ctrl.Box(opts.Width(100), opts.Height(50), opts.CornerRadius(10),
+			opts.BorderTop(2, 0xff0000ff), opts.BorderBottom(4, 0x00ff00ff))
+		views.RectangleView(s, box)
+
+		arcs := s.CommandsNamed("Arc")
+		assert.Equal(len(arcs), 4)
+		assert.Equal(arcs[0].Args[:3], []interface{}{10.0, 10.0, 9.0})
+		assert.Equal(arcs[1].Args[:3], []interface{}{90.0, 10.0, 9.0})
+		assert.Equal(arcs[2].Args[:3], []interface{}{90.0, 40.0, 8.0})
+		assert.Equal(arcs[3].Args[:3], []interface{}{10.0, 40.0, 8.0})
+		assert.Equal(len(s.CommandsNamed("Rect")), 0)
+	})
+
+	t.Run("LabelView draws decorations without a BgColor", func(t *testing.T) {
+		s := surface.NewSurface()
+		views.LabelView(s, ctrl.Label(opts.Border(1, 0xff0000ff)))
+		assert.True(len(s.CommandsNamed("Stroke")) > 0)
+	})
+
+	t.Run("ImageView", func(t *testing.T) {
+		draw := func(options ...spec.Option) []interface{} {
+			s := surface.NewSurface()
+			s.CreateImageFromBytes("photo", fakes.PNG(200, 100))
+			options = append([]spec.Option{opts.Width(100), opts.Height(100), ctrl.ImageName("photo")}, options...)
+			views.ImageView(s, ctrl.Image(options...))
+			args := s.CommandsNamed("DrawImageRegion")
+			assert.Equal(len(args), 1)
+			return args[0].Args[1:]
+		}
+
+		t.Run("Contain", func(t *testing.T) {
+			assert.Equal(draw(), []interface{}{0.0, 0.0, 200.0, 100.0, 0.0, 25.0, 100.0, 50.0})
+		})
+
+		t.Run("Cover", func(t *testing.T) {
+			assert.Equal(draw(ctrl.Fit(spec.FitCover)), []interface{}{50.0, 0.0, 100.0, 100.0, 0.0, 0.0, 100.0, 100.0})
+		})
+
+		t.Run("Fill", func(t *testing.T) {
+			assert.Equal(draw(ctrl.Fit(spec.FitFill)), []interface{}{0.0, 0.0, 200.0, 100.0, 0.0, 0.0, 100.0, 100.0})
+		})
+
+		t.Run("None", func(t *testing.T) {
+			assert.Equal(draw(ctrl.Fit(spec.FitNone)), []interface{}{50.0, 0.0, 100.0, 100.0, 0.0, 0.0, 100.0, 100.0})
+		})
+
+		t.Run("Skips unknown images", func(t *testing.T) {
+			s := surface.NewSurface()
+			views.ImageView(s, ctrl.Image(opts.Width(10), opts.Height(10), ctrl.ImageName("missing")))
+			assert.Equal(len(s.GetCommands()), 0)
+		})
+
+		t.Run("Nine slice", func(t *testing.T) {
+			s := surface.NewSurface()
+			s.CreateImageFromBytes("skin", fakes.PNG(30, 30))
+			views.ImageView(s, ctrl.Image(opts.Width(100), opts.Height(40), ctrl.ImageName("skin"), ctrl.NineSlice(10, 10, 10, 10)))
+
+			args := s.CommandsNamed("DrawImageRegion")
+			assert.Equal(len(args), 9)
+			assert.Equal(args[0].Args[1:], []interface{}{0.0, 0.0, 10.0, 10.0, 0.0, 0.0, 10.0, 10.0}, "Top left corner keeps its size")
+			assert.Equal(args[4].Args[1:], []interface{}{10.0, 10.0, 10.0, 10.0, 10.0, 10.0, 80.0, 20.0}, "Center stretches")
+			assert.Equal(args[8].Args[1:], []interface{}{20.0, 20.0, 10.0, 10.0, 90.0, 30.0, 10.0, 10.0}, "Bottom right corner keeps its size")
+		})
+	})
+}
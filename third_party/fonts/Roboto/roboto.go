@@ -0,0 +1,89 @@
+// Package roboto embeds the Roboto font family so that Surfaces can measure
+// and draw text without locating font files at runtime.
+package roboto
+
+import (
+	"embed"
+	"sync"
+
+	"github.com/waybeams/waybeams/pkg/font"
+)
+
+// Family is the name that the embedded faces are registered under.
+const Family = "Roboto"
+
+//go:embed *.ttf
+var files embed.FS
+
+var faces = []struct {
+	file   string
+	style  font.Style
+	weight int
+}{
+	{"Roboto-Regular.ttf", font.Normal, font.Regular},
+	{"Roboto-Italic.ttf", font.Italic, font.Regular},
+	{"Roboto-Thin.ttf", font.Normal, font.Thin},
+	{"Roboto-ThinItalic.ttf", font.Italic, font.Thin},
+	{"Roboto-Light.ttf", font.Normal, font.Light},
+	{"Roboto-LightItalic.ttf", font.Italic, font.Light},
+	{"Roboto-Medium.ttf", font.Normal, font.Medium},
+	{"Roboto-MediumItalic.ttf", font.Italic, font.Medium},
+	{"Roboto-Bold.ttf", font.Normal, font.Bold},
+	{"Roboto-BoldItalic.ttf", font.Italic, font.Bold},
+	{"Roboto-Black.ttf", font.Normal, font.Black},
+	{"Roboto-BlackItalic.ttf", font.Italic, font.Black},
+}
+
+// Bytes returns the embedded data for the provided file name (e.g.,
+// "Roboto-Regular.ttf").
+func Bytes(name string) ([]byte, error) {
+	return files.ReadFile(name)
+}
+
+var (
+	loadOnce sync.Once
+	loaded   [][]byte
+	loadErr  error
+)
+
+// load reads each embedded file once, so that every Registry shares the same
+// font data.
+func load() ([][]byte, error) {
+	loadOnce.Do(func() {
+		for _, face := range faces {
+			data, err := files.ReadFile(face.file)
+			if err != nil {
+				loadErr = err
+				return
+			}
+			loaded = append(loaded, data)
+		}
+	})
+	return loaded, loadErr
+}
+
+// Register adds every embedded Roboto face to the provided Registry.
+func Register(registry *font.Registry) error {
+	data, err := load()
+	if err != nil {
+		return err
+	}
+	for index, face := range faces {
+		if err := registry.AddBytes(Family, face.weight, face.style, data[index]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NewRegistry returns a Registry that contains every embedded Roboto face.
+// This is the default Registry for each Surface.
+func NewRegistry() *font.Registry {
+	registry := font.NewRegistry()
+	// The embedded files are known to be valid, so this can only fail if
+	// the package itself is broken.
+	if err := Register(registry); err != nil {
+		panic(err)
+	}
+	return registry
+}
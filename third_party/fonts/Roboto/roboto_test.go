@@ -0,0 +1,36 @@
+package roboto_test
+
+import (
+	"testing"
+
+	"github.com/waybeams/assert"
+	"github.com/waybeams/waybeams/pkg/font"
+	roboto "github.com/waybeams/waybeams/third_party/fonts/Roboto"
+)
+
+func TestRoboto(t *testing.T) {
+	t.Run("Registers every face", func(t *testing.T) {
+		registry := roboto.NewRegistry()
+		assert.Equal(len(registry.Faces()), 12)
+
+		face, ok := registry.Resolve(roboto.Family, font.Light, font.Italic)
+		assert.True(ok)
+		assert.Equal(face.Name, "Roboto-300-Italic")
+		assert.True(len(face.Data) > 0)
+	})
+
+	t.Run("Registries share font data", func(t *testing.T) {
+		first, _ := roboto.NewRegistry().Resolve(roboto.Family, font.Regular, font.Normal)
+		second, _ := roboto.NewRegistry().Resolve(roboto.Family, font.Regular, font.Normal)
+		assert.True(&first.Data[0] == &second.Data[0])
+	})
+
+	t.Run("Bytes", func(t *testing.T) {
+		data, err := roboto.Bytes("Roboto-Regular.ttf")
+		assert.Nil(err)
+		assert.True(len(data) > 0)
+
+		_, err = roboto.Bytes("Missing.ttf")
+		assert.NotNil(err)
+	})
+}